@@ -60,5 +60,7 @@ manage your Blockchain configurations and live deployments.`,
 	cmd.AddCommand(newValidatorsCmd())
 	// subnet changeOwner
 	cmd.AddCommand(newChangeOwnerCmd())
+	// blockchain trace
+	cmd.AddCommand(newTraceCmd())
 	return cmd
 }
@@ -45,6 +45,10 @@ type CreateFlags struct {
 	useLatestReleasedVMVersion    bool
 	useLatestPreReleasedVMVersion bool
 	useExternalGasToken           bool
+	useCreate2Factory             bool
+	useMulticall                  bool
+	// teleporter was given by the caller, and not only through the flag
+	teleporterSet bool
 }
 
 var (
@@ -57,7 +61,7 @@ var (
 	errIllegalNameCharacter = errors.New(
 		"illegal name character: only letters, no special characters allowed")
 	errMutuallyExlusiveVersionOptions   = errors.New("version flags --latest,--pre-release,vm-version are mutually exclusive")
-	errMutuallyExclusiveVMConfigOptions = errors.New("--genesis flag disables --evm-chain-id,--evm-defaults,--production-defaults,--test-defaults")
+	errMutuallyExclusiveVMConfigOptions = errors.New("--genesis flag disables --evm-chain-id,--evm-defaults,--production-defaults,--test-defaults,--create2-factory,--multicall")
 )
 
 // avalanche blockchain create
@@ -101,6 +105,8 @@ configuration, pass the -f flag.`,
 	cmd.Flags().BoolVar(&createFlags.useWarp, "warp", true, "generate a vm with warp support (needed for teleporter)")
 	cmd.Flags().BoolVar(&createFlags.useTeleporter, "teleporter", false, "interoperate with other blockchains using teleporter")
 	cmd.Flags().BoolVar(&createFlags.useExternalGasToken, "external-gas-token", false, "use a gas token from another blockchain")
+	cmd.Flags().BoolVar(&createFlags.useCreate2Factory, "create2-factory", false, "include the deterministic deployment proxy (CREATE2 factory) at genesis")
+	cmd.Flags().BoolVar(&createFlags.useMulticall, "multicall", false, "include Multicall3 at genesis, at its canonical address")
 	return cmd
 }
 
@@ -139,6 +145,41 @@ func CallCreate(
 	return createBlockchainConfig(cmd, []string{blockchainName})
 }
 
+// CallCreateEvm creates, without prompting, the configuration of a Subnet-EVM blockchain,
+// either from [genesisFileParam], or with the test defaults and [evmChainIDParam]. The
+// CREATE2 factory is included at genesis for the latter. An empty [vmVersionParam] means
+// the latest Subnet-EVM release
+func CallCreateEvm(
+	cmd *cobra.Command,
+	blockchainName string,
+	forceCreateParam bool,
+	genesisFileParam string,
+	vmVersionParam string,
+	evmChainIDParam uint64,
+	tokenSymbolParam string,
+	useTeleporterParam bool,
+) error {
+	forceCreate = forceCreateParam
+	genesisFile = genesisFileParam
+	vmFile = ""
+	customVMRepoURL = ""
+	customVMBranch = ""
+	customVMBuildScript = ""
+	createFlags = CreateFlags{
+		useSubnetEvm:               true,
+		chainID:                    evmChainIDParam,
+		tokenSymbol:                tokenSymbolParam,
+		useTestDefaults:            genesisFileParam == "",
+		useWarp:                    true,
+		useTeleporter:              useTeleporterParam,
+		teleporterSet:              true,
+		vmVersion:                  vmVersionParam,
+		useLatestReleasedVMVersion: vmVersionParam == "",
+		useCreate2Factory:          genesisFileParam == "",
+	}
+	return createBlockchainConfig(cmd, []string{blockchainName})
+}
+
 // override postrun function from root.go, so that we don't double send metrics for the same command
 func handlePostRun(_ *cobra.Command, _ []string) {}
 
@@ -171,7 +212,7 @@ func createBlockchainConfig(cmd *cobra.Command, args []string) error {
 	}
 
 	// genesis flags exclusiveness
-	if genesisFile != "" && (createFlags.chainID != 0 || defaultsKind != vm.NoDefaults) {
+	if genesisFile != "" && (createFlags.chainID != 0 || defaultsKind != vm.NoDefaults || createFlags.useCreate2Factory || createFlags.useMulticall) {
 		return errMutuallyExclusiveVMConfigOptions
 	}
 
@@ -201,7 +242,7 @@ func createBlockchainConfig(cmd *cobra.Command, args []string) error {
 
 	// get teleporter flag as a pointer (3 values: undef/true/false)
 	flagName := "teleporter"
-	if flag := cmd.Flags().Lookup(flagName); flag != nil && flag.Changed {
+	if flag := cmd.Flags().Lookup(flagName); createFlags.teleporterSet || (flag != nil && flag.Changed) {
 		useTeleporterFlag = &createFlags.useTeleporter
 	}
 
@@ -272,6 +313,8 @@ func createBlockchainConfig(cmd *cobra.Command, args []string) error {
 			if err != nil {
 				return err
 			}
+			params.UseCreate2Factory = createFlags.useCreate2Factory
+			params.UseMulticall = createFlags.useMulticall
 			deployTeleporter = params.UseTeleporter
 			useExternalGasToken = params.UseExternalGasToken
 			genesisBytes, err = vm.CreateEvmGenesis(
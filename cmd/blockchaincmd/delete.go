@@ -83,3 +83,8 @@ func deleteBlockchain(_ *cobra.Command, args []string) error {
 	}
 	return nil
 }
+
+// CallDelete deletes the configuration of [blockchainName], as blockchain delete does
+func CallDelete(blockchainName string) error {
+	return deleteBlockchain(nil, []string{blockchainName})
+}
@@ -123,6 +123,24 @@ func CallDeploy(
 	return deployBlockchain(cmd, []string{blockchainName})
 }
 
+// CallDeployLocal deploys [blockchainName] into the local network, without prompting, using
+// the same defaults as blockchain deploy --local
+func CallDeployLocal(cmd *cobra.Command, blockchainName string) error {
+	userProvidedAvagoVersion = latest
+	avagoBinaryPath = ""
+	teleporterEsp = subnet.TeleporterEsp{Version: latest}
+	return CallDeploy(
+		cmd,
+		false,
+		blockchainName,
+		networkoptions.NetworkFlags{UseLocal: true},
+		"",
+		false,
+		false,
+		false,
+	)
+}
+
 func getChainsInSubnet(blockchainName string) ([]string, error) {
 	subnets, err := os.ReadDir(app.GetSubnetDir())
 	if err != nil {
@@ -4,6 +4,7 @@ package blockchaincmd
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
@@ -115,6 +116,9 @@ func PrintSubnetInfo(blockchainName string, onlyLocalnetInfo bool) error {
 	localChainID := ""
 	for net, data := range sc.Networks {
 		network, err := networkoptions.GetNetworkFromSidecarNetworkName(app, net)
+		if errors.Is(err, networkoptions.ErrOtherLocalNetwork) {
+			continue
+		}
 		if err != nil {
 			return err
 		}
@@ -174,6 +178,9 @@ func PrintSubnetInfo(blockchainName string, onlyLocalnetInfo bool) error {
 	hasTeleporterInfo := false
 	for net, data := range sc.Networks {
 		network, err := networkoptions.GetNetworkFromSidecarNetworkName(app, net)
+		if errors.Is(err, networkoptions.ErrOtherLocalNetwork) {
+			continue
+		}
 		if err != nil {
 			return err
 		}
@@ -216,7 +216,7 @@ func removeFromLocal(blockchainName string) error {
 		return err
 	}
 
-	subnetID := sc.Networks[models.Local.String()].SubnetID
+	subnetID := sc.Networks[models.NewLocalNetwork().Name()].SubnetID
 	if subnetID == ids.Empty {
 		return errNoSubnetID
 	}
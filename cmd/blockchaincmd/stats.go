@@ -166,10 +166,10 @@ func findAPIEndpoint(network models.Network) (platformvm.Client, info.Client) {
 
 	// first try local node
 	ctx := context.Background()
-	c := platformvm.NewClient(constants.LocalAPIEndpoint)
+	c := platformvm.NewClient(models.NewLocalNetwork().Endpoint)
 	_, err := c.GetHeight(ctx)
 	if err == nil {
-		i = info.NewClient(constants.LocalAPIEndpoint)
+		i = info.NewClient(models.NewLocalNetwork().Endpoint)
 		// try calling it to make sure it actually worked
 		_, _, err := i.GetNodeID(ctx)
 		if err == nil {
@@ -183,7 +183,7 @@ func findAPIEndpoint(network models.Network) (platformvm.Client, info.Client) {
 	_, err = c.GetHeight(ctx)
 	if err == nil {
 		// also try to get a local client
-		i = info.NewClient(constants.LocalAPIEndpoint)
+		i = info.NewClient(models.NewLocalNetwork().Endpoint)
 	}
 	return c, i
 }
@@ -0,0 +1,274 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package blockchaincmd
+
+import (
+	"fmt"
+	"strings"
+
+	cmdflags "github.com/ava-labs/avalanche-cli/cmd/flags"
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/spf13/cobra"
+)
+
+type TraceFlags struct {
+	Network    networkoptions.NetworkFlags
+	chainFlags contract.ChainFlags
+}
+
+var (
+	traceSupportedNetworkOptions = []networkoptions.NetworkOption{
+		networkoptions.Local,
+		networkoptions.Devnet,
+		networkoptions.Fuji,
+		networkoptions.Mainnet,
+	}
+	traceFlags TraceFlags
+)
+
+// avalanche blockchain trace
+func newTraceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "trace [txHash]",
+		Short: "Show the call tree of a transaction, and why it failed",
+		Long: `The blockchain trace command prints the call tree of an EVM transaction, with the gas
+given to and used by each call, and the decoded revert reason of the failed calls.
+
+Calls and custom errors of Teleporter, ICTT, ERC20 and precompile contracts are shown by name.
+
+The call tree is obtained with debug_traceTransaction, that requires the "debug-tracer" API
+to be enabled on the blockchain nodes (eth-apis chain config). If it is not available, the
+command falls back to show the transaction revert reason only.`,
+		RunE: trace,
+		Args: cobrautils.ExactArgs(1),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &traceFlags.Network, true, traceSupportedNetworkOptions)
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&traceFlags.chainFlags,
+		"trace the transaction",
+		"blockchain",
+		"",
+	)
+	return cmd
+}
+
+func trace(_ *cobra.Command, args []string) error {
+	txHashStr := args[0]
+	bs, err := hexutil.Decode(txHashStr)
+	if err != nil || len(bs) != common.HashLength {
+		return fmt.Errorf("invalid tx hash %q", txHashStr)
+	}
+	txHash := common.BytesToHash(bs)
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		traceFlags.Network,
+		true,
+		false,
+		traceSupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	if !cmdflags.EnsureMutuallyExclusive([]bool{
+		traceFlags.chainFlags.SubnetName != "",
+		traceFlags.chainFlags.CChain,
+	}) {
+		return fmt.Errorf("--blockchain and --c-chain are mutually exclusive flags")
+	}
+	if traceFlags.chainFlags.SubnetName == "" && !traceFlags.chainFlags.CChain {
+		blockchainNames, err := app.GetSubnetNamesOnNetwork(network)
+		if err != nil {
+			return err
+		}
+		cancel, _, _, cChain, blockchainName, err := prompts.PromptChain(
+			app.Prompt,
+			"Which blockchain is the transaction on?",
+			blockchainNames,
+			true,
+			true,
+			false,
+			"",
+		)
+		if err != nil {
+			return err
+		}
+		if cancel {
+			return nil
+		}
+		traceFlags.chainFlags.SubnetName = blockchainName
+		traceFlags.chainFlags.CChain = cChain
+	}
+	rpcURL, err := contract.GetRPCURL(
+		app,
+		network,
+		traceFlags.chainFlags.SubnetName,
+		traceFlags.chainFlags.CChain,
+	)
+	if err != nil {
+		return err
+	}
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	tx, err := evm.GetTransaction(client, txHash)
+	if err != nil {
+		return err
+	}
+	receipt, err := evm.GetTransactionReceipt(client, txHash)
+	if err != nil {
+		return err
+	}
+	names := getTraceAddressNames(network, traceFlags.chainFlags)
+	status := "Success"
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		status = "Failed"
+	}
+	ux.Logger.PrintToUser("Tx %s", txHash.Hex())
+	ux.Logger.PrintToUser("Block: %d", receipt.BlockNumber.Uint64())
+	ux.Logger.PrintToUser("Status: %s", status)
+	ux.Logger.PrintToUser("Gas Used: %d of %d", receipt.GasUsed, tx.Gas())
+	ux.Logger.PrintToUser("")
+	callTrace, err := evm.GetCallTrace(rpcURL, txHash.Hex())
+	if err == nil {
+		printCallFrame(*callTrace, names, "", "")
+		return nil
+	}
+	ux.Logger.PrintToUser("Call tree not available: debug-tracer API may not be enabled on the blockchain nodes")
+	ux.Logger.PrintToUser("")
+	from, err := evm.GetTransactionSender(tx)
+	if err != nil {
+		return err
+	}
+	to := receipt.ContractAddress
+	if tx.To() != nil {
+		to = *tx.To()
+	}
+	callType := "CALL"
+	if tx.To() == nil {
+		callType = "CREATE"
+	}
+	frame := evm.CallFrame{
+		Type:    callType,
+		From:    from,
+		To:      to,
+		Gas:     hexutil.Uint64(tx.Gas()),
+		GasUsed: hexutil.Uint64(receipt.GasUsed),
+		Input:   tx.Data(),
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		frame.Error = "execution reverted"
+		if reason, err := contract.GetRevertReason(rpcURL, tx, receipt); err != nil {
+			ux.Logger.RedXToUser("failure obtaining revert reason: %s", err)
+		} else {
+			frame.RevertReason = reason
+		}
+	}
+	printCallFrame(frame, names, "", "")
+	return nil
+}
+
+// names the teleporter contracts of the traced blockchain, in addition to the
+// ones known at contract pkg
+func getTraceAddressNames(
+	network models.Network,
+	chainFlags contract.ChainFlags,
+) map[common.Address]string {
+	names := map[common.Address]string{}
+	_, _, _, _, messengerAddress, registryAddress, _, err := teleporter.GetSubnetParams(
+		app,
+		network,
+		chainFlags.SubnetName,
+		chainFlags.CChain,
+	)
+	if err != nil {
+		// blockchain not enabled for teleporter
+		return names
+	}
+	names[common.HexToAddress(messengerAddress)] = "TeleporterMessenger"
+	if registryAddress != "" {
+		names[common.HexToAddress(registryAddress)] = "TeleporterRegistry"
+	}
+	return names
+}
+
+func printCallFrame(
+	frame evm.CallFrame,
+	names map[common.Address]string,
+	prefix string,
+	childPrefix string,
+) {
+	target := frame.To.Hex()
+	if name, ok := names[frame.To]; ok {
+		target = fmt.Sprintf("%s[%s]", name, target)
+	} else if name, ok := contract.GetKnownAddressName(frame.To); ok {
+		target = fmt.Sprintf("%s[%s]", name, target)
+	}
+	line := fmt.Sprintf(
+		"%s%s %s::%s (gas used %d of %d)",
+		prefix,
+		frame.Type,
+		target,
+		describeCallInput(frame),
+		uint64(frame.GasUsed),
+		uint64(frame.Gas),
+	)
+	if frame.Value != nil && frame.Value.ToInt().Sign() > 0 {
+		line += fmt.Sprintf(" value %s", frame.Value.ToInt())
+	}
+	if frame.Error != "" {
+		reason := frame.Error
+		switch {
+		case len(frame.Output) > 0:
+			reason = contract.DecodeRevertReason(frame.Output)
+		case frame.RevertReason != "":
+			reason = frame.RevertReason
+		}
+		line += " " + logging.Red.Wrap(fmt.Sprintf("reverted: %s", reason))
+	}
+	ux.Logger.PrintToUser("%s", line)
+	for i, call := range frame.Calls {
+		if i == len(frame.Calls)-1 {
+			printCallFrame(call, names, childPrefix+"└─ ", childPrefix+"   ")
+		} else {
+			printCallFrame(call, names, childPrefix+"├─ ", childPrefix+"│  ")
+		}
+	}
+}
+
+func describeCallInput(frame evm.CallFrame) string {
+	callType := strings.ToUpper(frame.Type)
+	if callType == "CREATE" || callType == "CREATE2" {
+		return "constructor"
+	}
+	if len(frame.Input) == 0 {
+		return "receive()"
+	}
+	method, ok := contract.GetKnownMethod(frame.Input)
+	if !ok {
+		if len(frame.Input) < 4 {
+			return "fallback()"
+		}
+		return fmt.Sprintf("0x%s", common.Bytes2Hex(frame.Input[:4]))
+	}
+	values, err := method.Inputs.Unpack(frame.Input[4:])
+	if err != nil {
+		return method.Sig
+	}
+	return fmt.Sprintf("%s(%s)", method.RawName, contract.FormatValues(values))
+}
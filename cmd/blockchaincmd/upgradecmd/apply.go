@@ -108,7 +108,7 @@ func applyCmd(_ *cobra.Command, args []string) error {
 	switch networkToUpgrade {
 	// update a locally running network
 	case localDeployment:
-		return applyLocalNetworkUpgrade(blockchainName, models.Local.String(), &sc)
+		return applyLocalNetworkUpgrade(blockchainName, models.NewLocalNetwork().Name(), &sc)
 	case fujiDeployment:
 		return applyPublicNetworkUpgrade(blockchainName, models.Fuji.String(), &sc)
 	case mainnetDeployment:
@@ -253,7 +253,7 @@ func applyPublicNetworkUpgrade(blockchainName, networkKey string, sc *models.Sid
 	if print {
 		blockchainIDstr := "<your-blockchain-id>"
 		if sc.Networks != nil &&
-			sc.Networks[networkKey] != (models.NetworkData{}) &&
+			!sc.Networks[networkKey].IsEmpty() &&
 			sc.Networks[networkKey].BlockchainID != ids.Empty {
 			blockchainIDstr = sc.Networks[networkKey].BlockchainID.String()
 		}
@@ -318,7 +318,7 @@ func applyPublicNetworkUpgrade(blockchainName, networkKey string, sc *models.Sid
 
 func validateUpgrade(blockchainName, networkKey string, sc *models.Sidecar, skipPrompting bool) ([]params.PrecompileUpgrade, string, error) {
 	// if there's no entry in the Sidecar, we assume there hasn't been a deploy yet
-	if sc.Networks[networkKey] == (models.NetworkData{}) {
+	if sc.Networks[networkKey].IsEmpty() {
 		return nil, "", subnetNotYetDeployed()
 	}
 	chainID := sc.Networks[networkKey].BlockchainID
@@ -542,7 +542,7 @@ func getCClient(apiEndpoint string, blockchainID string) (ethclient.Client, erro
 }
 
 func ensureHaveBalanceLocalNetwork(which string, addresses []common.Address, blockchainID string) error {
-	cClient, err := getCClient(constants.LocalAPIEndpoint, blockchainID)
+	cClient, err := getCClient(models.NewLocalNetwork().Endpoint, blockchainID)
 	if err != nil {
 		return err
 	}
@@ -570,7 +570,7 @@ func ensureHaveBalance(
 	switch sc.VM {
 	case models.SubnetEvm:
 		// Currently only checking if admins have balance for subnets deployed in Local Network
-		if networkData, ok := sc.Networks["Local Network"]; ok {
+		if networkData, ok := sc.Networks[models.NewLocalNetwork().Name()]; ok {
 			blockchainID := networkData.BlockchainID.String()
 			if err := ensureHaveBalanceLocalNetwork(which, addresses, blockchainID); err != nil {
 				return err
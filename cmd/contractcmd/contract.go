@@ -22,5 +22,15 @@ and interacting with smart contracts.`,
 	app = injectedApp
 	// contract deploy
 	cmd.AddCommand(newDeployCmd())
+	// contract upgrade
+	cmd.AddCommand(newUpgradeCmd())
+	// contract events
+	cmd.AddCommand(newEventsCmd())
+	// contract nft
+	cmd.AddCommand(newNFTCmd())
+	// contract safe
+	cmd.AddCommand(newSafeCmd())
+	// contract multicall
+	cmd.AddCommand(newMulticallCmd())
 	return cmd
 }
@@ -18,5 +18,17 @@ smart contracts.`,
 	}
 	// contract deploy erc20
 	cmd.AddCommand(newDeployERC20Cmd())
+	// contract deploy erc721
+	cmd.AddCommand(newDeployERC721Cmd())
+	// contract deploy erc1155
+	cmd.AddCommand(newDeployERC1155Cmd())
+	// contract deploy safe
+	cmd.AddCommand(newDeploySafeCmd())
+	// contract deploy bytecode
+	cmd.AddCommand(newDeployBytecodeCmd())
+	// contract deploy create2-factory
+	cmd.AddCommand(newDeployCreate2FactoryCmd())
+	// contract deploy multicall
+	cmd.AddCommand(newDeployMulticallCmd())
 	return cmd
 }
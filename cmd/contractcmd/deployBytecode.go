@@ -0,0 +1,179 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contractcmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/spf13/cobra"
+)
+
+type DeployBytecodeFlags struct {
+	Network         networkoptions.NetworkFlags
+	PrivateKeyFlags contract.PrivateKeyFlags
+	chainFlags      contract.ChainFlags
+	create2Flags    Create2Flags
+	bytecodePath    string
+	constructorEsp  string
+	proxyFlags      ProxyFlags
+}
+
+var (
+	deployBytecodeSupportedNetworkOptions = []networkoptions.NetworkOption{
+		networkoptions.Local,
+		networkoptions.Devnet,
+		networkoptions.Fuji,
+	}
+	deployBytecodeFlags DeployBytecodeFlags
+)
+
+// avalanche contract deploy bytecode
+func newDeployBytecodeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bytecode [constructorArgs]",
+		Short: "Deploy a contract from its bytecode into a given Network and Blockchain",
+		Long: `Deploys a contract, given its creation bytecode, into a given Network and Blockchain.
+
+The bytecode path can point either to an hex encoded bytecode file (as generated by solc --bin),
+or to a Foundry artifact (out/<File>.sol/<Contract>.json).
+
+Constructor params are described with --constructor, using the CLI ESP syntax (eg "(address, uint256)"),
+and given as positional arguments. Arrays are given as "[v1,v2]".
+
+With --create2, the contract is deployed through the CREATE2 factory, so the contract gets the same
+address on every blockchain where it is deployed with the same bytecode, constructor args and --salt.
+
+With --proxy, the contract is deployed as the implementation of an upgradeable ERC1967 proxy, either
+transparent (administered by a ProxyAdmin contract, owned by the deployer or --proxy-admin-owner) or
+uups (upgraded through the implementation itself). The proxy is initialized by calling --initializer
+(eg "initialize(address, uint256)") with --initializer-args, and can later be upgraded with
+avalanche contract upgrade.`,
+		RunE: deployBytecode,
+		Args: cobrautils.MinimumNArgs(0),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &deployBytecodeFlags.Network, true, deployBytecodeSupportedNetworkOptions)
+	contract.AddPrivateKeyFlagsToCmd(cmd, &deployBytecodeFlags.PrivateKeyFlags, "as contract deployer")
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&deployBytecodeFlags.chainFlags,
+		"deploy the contract",
+		"",
+		"",
+	)
+	cmd.Flags().StringVar(&deployBytecodeFlags.bytecodePath, "bytecode-path", "", "path to the contract bytecode or foundry artifact")
+	cmd.Flags().StringVar(&deployBytecodeFlags.constructorEsp, "constructor", "", "constructor params types (eg \"(address, uint256)\")")
+	addCreate2FlagsToCmd(cmd, &deployBytecodeFlags.create2Flags)
+	addProxyFlagsToCmd(cmd, &deployBytecodeFlags.proxyFlags)
+	return cmd
+}
+
+func deployBytecode(_ *cobra.Command, args []string) error {
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		deployBytecodeFlags.Network,
+		true,
+		false,
+		deployBytecodeSupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	if deployBytecodeFlags.bytecodePath == "" {
+		deployBytecodeFlags.bytecodePath, err = app.Prompt.CaptureExistingFilepath("Contract bytecode path")
+		if err != nil {
+			return err
+		}
+	}
+	binBytes, err := contract.LoadContractBytecode(deployBytecodeFlags.bytecodePath)
+	if err != nil {
+		return err
+	}
+	constructorEsp := deployBytecodeFlags.constructorEsp
+	if constructorEsp == "" {
+		constructorEsp = "()"
+	}
+	params, err := contract.ParseEspValues(constructorEsp, args)
+	if err != nil {
+		return err
+	}
+	if deployBytecodeFlags.proxyFlags.enabled() && deployBytecodeFlags.create2Flags.enabled() {
+		return fmt.Errorf("--proxy can not be used together with --create2")
+	}
+	initData, err := deployBytecodeFlags.proxyFlags.getInitData()
+	if err != nil {
+		return err
+	}
+	cancel, err := promptChain(
+		network,
+		&deployBytecodeFlags.chainFlags,
+		"Where do you want to Deploy the Contract?",
+	)
+	if err != nil {
+		return err
+	}
+	if cancel {
+		return nil
+	}
+	privateKey, _, err := getDeployerPrivateKey(
+		network,
+		deployBytecodeFlags.chainFlags,
+		deployBytecodeFlags.PrivateKeyFlags,
+	)
+	if err != nil {
+		return err
+	}
+	rpcURL, err := contract.GetRPCURL(
+		app,
+		network,
+		deployBytecodeFlags.chainFlags.SubnetName,
+		deployBytecodeFlags.chainFlags.CChain,
+	)
+	if err != nil {
+		return err
+	}
+	var (
+		address         common.Address
+		alreadyDeployed bool
+	)
+	if deployBytecodeFlags.create2Flags.enabled() {
+		address, alreadyDeployed, err = contract.DeployContractCreate2(
+			rpcURL,
+			privateKey,
+			contract.GetCreate2Salt(deployBytecodeFlags.create2Flags.salt),
+			binBytes,
+			constructorEsp,
+			params...,
+		)
+	} else {
+		address, err = contract.DeployContract(
+			rpcURL,
+			privateKey,
+			binBytes,
+			constructorEsp,
+			params...,
+		)
+	}
+	if err != nil {
+		return err
+	}
+	if deployBytecodeFlags.proxyFlags.enabled() {
+		return deployProxy(rpcURL, privateKey, address, initData, deployBytecodeFlags.proxyFlags)
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Contract Address: %s", address.Hex())
+	ux.Logger.PrintToUser("")
+	if alreadyDeployed {
+		ux.Logger.PrintToUser("Contract was already deployed")
+		return nil
+	}
+	ux.Logger.PrintToUser("Contract Successfully Deployed!")
+	return nil
+}
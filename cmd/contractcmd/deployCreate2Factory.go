@@ -0,0 +1,108 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contractcmd
+
+import (
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+
+	"github.com/spf13/cobra"
+)
+
+type DeployCreate2FactoryFlags struct {
+	Network         networkoptions.NetworkFlags
+	PrivateKeyFlags contract.PrivateKeyFlags
+	chainFlags      contract.ChainFlags
+}
+
+var (
+	deployCreate2FactorySupportedNetworkOptions = []networkoptions.NetworkOption{
+		networkoptions.Local,
+		networkoptions.Devnet,
+		networkoptions.Fuji,
+	}
+	deployCreate2FactoryFlags DeployCreate2FactoryFlags
+)
+
+// avalanche contract deploy create2-factory
+func newDeployCreate2FactoryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create2-factory",
+		Short: "Deploy the CREATE2 factory into a given Network and Blockchain",
+		Long: `Deploys the deterministic deployment proxy (CREATE2 factory) into a given Network and Blockchain.
+
+The factory is deployed with a keyless presigned transaction, the same way Teleporter Messenger
+is, so it has the same address on every blockchain. Afterwards, contracts deployed with
+--create2 get the same address on every blockchain where the factory is available.`,
+		RunE: deployCreate2Factory,
+		Args: cobrautils.ExactArgs(0),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &deployCreate2FactoryFlags.Network, true, deployCreate2FactorySupportedNetworkOptions)
+	contract.AddPrivateKeyFlagsToCmd(cmd, &deployCreate2FactoryFlags.PrivateKeyFlags, "to fund the factory deployer")
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&deployCreate2FactoryFlags.chainFlags,
+		"deploy the CREATE2 factory",
+		"",
+		"",
+	)
+	return cmd
+}
+
+func deployCreate2Factory(_ *cobra.Command, _ []string) error {
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		deployCreate2FactoryFlags.Network,
+		true,
+		false,
+		deployCreate2FactorySupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	cancel, err := promptChain(
+		network,
+		&deployCreate2FactoryFlags.chainFlags,
+		"Where do you want to Deploy the CREATE2 Factory?",
+	)
+	if err != nil {
+		return err
+	}
+	if cancel {
+		return nil
+	}
+	privateKey, _, err := getDeployerPrivateKey(
+		network,
+		deployCreate2FactoryFlags.chainFlags,
+		deployCreate2FactoryFlags.PrivateKeyFlags,
+	)
+	if err != nil {
+		return err
+	}
+	rpcURL, err := contract.GetRPCURL(
+		app,
+		network,
+		deployCreate2FactoryFlags.chainFlags.SubnetName,
+		deployCreate2FactoryFlags.chainFlags.CChain,
+	)
+	if err != nil {
+		return err
+	}
+	alreadyDeployed, err := contract.DeployCreate2Factory(rpcURL, privateKey)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("CREATE2 Factory Address: %s", contract.Create2FactoryAddress)
+	ux.Logger.PrintToUser("")
+	if alreadyDeployed {
+		ux.Logger.PrintToUser("CREATE2 Factory was already deployed")
+		return nil
+	}
+	ux.Logger.PrintToUser("CREATE2 Factory Successfully Deployed!")
+	return nil
+}
@@ -6,13 +6,14 @@ import (
 	"fmt"
 	"math/big"
 
-	cmdflags "github.com/ava-labs/avalanche-cli/cmd/flags"
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
 	"github.com/ava-labs/avalanche-cli/pkg/prompts"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/spf13/cobra"
 )
@@ -21,9 +22,18 @@ type DeployERC20Flags struct {
 	Network         networkoptions.NetworkFlags
 	PrivateKeyFlags contract.PrivateKeyFlags
 	chainFlags      contract.ChainFlags
+	create2Flags    Create2Flags
 	symbol          string
+	name            string
 	funded          string
 	supply          uint64
+	decimals        uint8
+	mintable        bool
+	minter          string
+	burnable        bool
+	cap             uint64
+	pausable        bool
+	permit          bool
 }
 
 var (
@@ -40,9 +50,16 @@ func newDeployERC20Cmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "erc20",
 		Short: "Deploy an ERC20 token into a given Network and Blockchain",
-		Long:  "Deploy an ERC20 token into a given Network and Blockchain",
-		RunE:  deployERC20,
-		Args:  cobrautils.ExactArgs(0),
+		Long: `Deploys an ERC20 token into a given Network and Blockchain.
+
+By default a fixed supply token with 18 decimals is deployed. Optional behaviours can be
+added with --mintable (by the deployer, or by --minter), --burnable, --cap, --pausable, --permit
+(EIP-2612) and --decimals.
+
+Tokens deployed into CLI blockchains are recorded, so other commands can refer to them by
+name or symbol instead of by address.`,
+		RunE: deployERC20,
+		Args: cobrautils.ExactArgs(0),
 	}
 	networkoptions.AddNetworkFlagsToCmd(cmd, &deployERC20Flags.Network, true, deployERC20SupportedNetworkOptions)
 	contract.AddPrivateKeyFlagsToCmd(cmd, &deployERC20Flags.PrivateKeyFlags, "as contract deployer")
@@ -54,8 +71,17 @@ func newDeployERC20Cmd() *cobra.Command {
 		"",
 	)
 	cmd.Flags().StringVar(&deployERC20Flags.symbol, "symbol", "", "set the token symbol")
+	cmd.Flags().StringVar(&deployERC20Flags.name, "name", "", "set the token name (defaults to \"<symbol> Token\")")
 	cmd.Flags().Uint64Var(&deployERC20Flags.supply, "supply", 0, "set the token supply")
 	cmd.Flags().StringVar(&deployERC20Flags.funded, "funded", "", "set the funded address")
+	cmd.Flags().Uint8Var(&deployERC20Flags.decimals, "decimals", contract.DefaultERC20Decimals, "set the token decimals")
+	cmd.Flags().BoolVar(&deployERC20Flags.mintable, "mintable", false, "allow new tokens to be minted")
+	cmd.Flags().StringVar(&deployERC20Flags.minter, "minter", "", "address allowed to mint tokens (defaults to the deployer; implies --mintable)")
+	cmd.Flags().BoolVar(&deployERC20Flags.burnable, "burnable", false, "allow holders to burn their tokens")
+	cmd.Flags().Uint64Var(&deployERC20Flags.cap, "cap", 0, "max token supply (implies --mintable)")
+	cmd.Flags().BoolVar(&deployERC20Flags.pausable, "pausable", false, "allow the deployer to pause token transfers")
+	cmd.Flags().BoolVar(&deployERC20Flags.permit, "permit", false, "support EIP-2612 gasless approvals")
+	addCreate2FlagsToCmd(cmd, &deployERC20Flags.create2Flags)
 	return cmd
 }
 
@@ -72,70 +98,25 @@ func deployERC20(_ *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
-	// flags exclusiveness
-	if !cmdflags.EnsureMutuallyExclusive([]bool{
-		deployERC20Flags.chainFlags.SubnetName != "",
-		deployERC20Flags.chainFlags.CChain,
-	}) {
-		return fmt.Errorf("--subnet and --c-chain are mutually exclusive flags")
-	}
-	if deployERC20Flags.chainFlags.SubnetName == "" && !deployERC20Flags.chainFlags.CChain {
-		subnetNames, err := app.GetSubnetNamesOnNetwork(network)
-		if err != nil {
-			return err
-		}
-		prompt := "Where do you want to Deploy the ERC-20 Token?"
-		cancel, _, _, cChain, subnetName, err := prompts.PromptChain(
-			app.Prompt,
-			prompt,
-			subnetNames,
-			true,
-			true,
-			false,
-			"",
-		)
-		if cancel {
-			return nil
-		}
-		if err == nil {
-			deployERC20Flags.chainFlags.SubnetName = subnetName
-			deployERC20Flags.chainFlags.CChain = cChain
-		}
-	}
-	genesisAddress, genesisPrivateKey, err := contract.GetEVMSubnetPrefundedKey(
-		app,
+	cancel, err := promptChain(
 		network,
-		deployERC20Flags.chainFlags.SubnetName,
-		deployERC20Flags.chainFlags.CChain,
-		"",
+		&deployERC20Flags.chainFlags,
+		"Where do you want to Deploy the ERC-20 Token?",
 	)
 	if err != nil {
 		return err
 	}
-	privateKey, err := contract.GetPrivateKeyFromFlags(
-		app,
+	if cancel {
+		return nil
+	}
+	privateKey, genesisAddress, err := getDeployerPrivateKey(
+		network,
+		deployERC20Flags.chainFlags,
 		deployERC20Flags.PrivateKeyFlags,
-		genesisPrivateKey,
 	)
 	if err != nil {
 		return err
 	}
-	if privateKey == "" {
-		ux.Logger.PrintToUser("A private key is needed to pay for the contract deploy fees.")
-		ux.Logger.PrintToUser("It will also be considered the owner address of the contract, beign able to call")
-		ux.Logger.PrintToUser("the contract methods only available to owners.")
-		privateKey, err = prompts.PromptPrivateKey(
-			app.Prompt,
-			"deploy the contract",
-			app.GetKeyDir(),
-			app.GetKey,
-			genesisAddress,
-			genesisPrivateKey,
-		)
-		if err != nil {
-			return err
-		}
-	}
 	if deployERC20Flags.symbol == "" {
 		ux.Logger.PrintToUser("Which is the token symbol?")
 		deployERC20Flags.symbol, err = app.Prompt.CaptureString("Token symbol")
@@ -176,19 +157,109 @@ func deployERC20(_ *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
-	address, err := contract.DeployERC20(
-		rpcURL,
-		privateKey,
-		deployERC20Flags.symbol,
-		common.HexToAddress(deployERC20Flags.funded),
-		supply,
+	features := models.ERC20Features{
+		Decimals: deployERC20Flags.decimals,
+		Mintable: deployERC20Flags.mintable || deployERC20Flags.minter != "" || deployERC20Flags.cap != 0,
+		Minter:   deployERC20Flags.minter,
+		Burnable: deployERC20Flags.burnable,
+		Pausable: deployERC20Flags.pausable,
+		Permit:   deployERC20Flags.permit,
+	}
+	if deployERC20Flags.cap != 0 {
+		features.Cap = new(big.Int).SetUint64(deployERC20Flags.cap)
+	}
+	tokenName := deployERC20Flags.name
+	if tokenName == "" {
+		tokenName = contract.GetDefaultERC20Name(deployERC20Flags.symbol)
+	}
+	var (
+		address         common.Address
+		alreadyDeployed bool
 	)
+	switch {
+	case contract.IsDefaultERC20(features) && tokenName == contract.GetDefaultERC20Name(deployERC20Flags.symbol):
+		if deployERC20Flags.create2Flags.enabled() {
+			address, alreadyDeployed, err = contract.DeployERC20Create2(
+				rpcURL,
+				privateKey,
+				contract.GetCreate2Salt(deployERC20Flags.create2Flags.salt),
+				deployERC20Flags.symbol,
+				common.HexToAddress(deployERC20Flags.funded),
+				supply,
+			)
+		} else {
+			address, err = contract.DeployERC20(
+				rpcURL,
+				privateKey,
+				deployERC20Flags.symbol,
+				common.HexToAddress(deployERC20Flags.funded),
+				supply,
+			)
+		}
+	default:
+		if features.Minter != "" {
+			if err := prompts.ValidateAddress(features.Minter); err != nil {
+				return fmt.Errorf("failure validating minter address %s: %w", features.Minter, err)
+			}
+		}
+		pk, err := crypto.HexToECDSA(privateKey)
+		if err != nil {
+			return err
+		}
+		admin := crypto.PubkeyToAddress(pk.PublicKey)
+		if deployERC20Flags.create2Flags.enabled() {
+			address, alreadyDeployed, err = contract.DeployConfigurableERC20Create2(
+				rpcURL,
+				privateKey,
+				contract.GetCreate2Salt(deployERC20Flags.create2Flags.salt),
+				tokenName,
+				deployERC20Flags.symbol,
+				common.HexToAddress(deployERC20Flags.funded),
+				supply,
+				admin,
+				features,
+			)
+		} else {
+			address, err = contract.DeployConfigurableERC20(
+				rpcURL,
+				privateKey,
+				tokenName,
+				deployERC20Flags.symbol,
+				common.HexToAddress(deployERC20Flags.funded),
+				supply,
+				admin,
+				features,
+			)
+		}
+		if err != nil {
+			return err
+		}
+	}
 	if err != nil {
 		return err
 	}
 	ux.Logger.PrintToUser("")
 	ux.Logger.PrintToUser("Token Address: %s", address.Hex())
 	ux.Logger.PrintToUser("")
+	if deployERC20Flags.chainFlags.SubnetName != "" {
+		if err := contract.RecordERC20Token(
+			app,
+			network,
+			deployERC20Flags.chainFlags.SubnetName,
+			models.ERC20Token{
+				Name:     tokenName,
+				Symbol:   deployERC20Flags.symbol,
+				Address:  address.Hex(),
+				Features: features,
+			},
+		); err != nil {
+			return err
+		}
+	}
+	if alreadyDeployed {
+		ux.Logger.PrintToUser("ERC20 Contract was already deployed")
+		return nil
+	}
 	ux.Logger.PrintToUser("ERC20 Contract Successfully Deployed!")
 	return nil
 }
@@ -0,0 +1,112 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contractcmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+
+	"github.com/spf13/cobra"
+)
+
+type DeployMulticallFlags struct {
+	Network         networkoptions.NetworkFlags
+	PrivateKeyFlags contract.PrivateKeyFlags
+	chainFlags      contract.ChainFlags
+}
+
+var (
+	deployMulticallSupportedNetworkOptions = []networkoptions.NetworkOption{
+		networkoptions.Local,
+		networkoptions.Devnet,
+		networkoptions.Fuji,
+	}
+	deployMulticallFlags DeployMulticallFlags
+)
+
+// avalanche contract deploy multicall
+func newDeployMulticallCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "multicall",
+		Short: "Deploy Multicall3 into a given Network and Blockchain",
+		Long: `Deploys Multicall3 into a given Network and Blockchain, so several contract calls
+can be batched into one request (see avalanche contract multicall).
+
+Multicall3 is deployed through the CREATE2 factory, so it has the same address on every
+blockchain. If the factory is not available, deploy it first with avalanche contract deploy
+create2-factory. New blockchains can instead include Multicall3 at genesis, at its canonical
+address, with avalanche blockchain create --multicall.`,
+		RunE: deployMulticall,
+		Args: cobrautils.ExactArgs(0),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &deployMulticallFlags.Network, true, deployMulticallSupportedNetworkOptions)
+	contract.AddPrivateKeyFlagsToCmd(cmd, &deployMulticallFlags.PrivateKeyFlags, "as contract deployer")
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&deployMulticallFlags.chainFlags,
+		"deploy Multicall3",
+		"",
+		"",
+	)
+	return cmd
+}
+
+func deployMulticall(_ *cobra.Command, _ []string) error {
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		deployMulticallFlags.Network,
+		true,
+		false,
+		deployMulticallSupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	cancel, err := promptChain(
+		network,
+		&deployMulticallFlags.chainFlags,
+		"Where do you want to Deploy Multicall3?",
+	)
+	if err != nil {
+		return err
+	}
+	if cancel {
+		return nil
+	}
+	rpcURL, err := contract.GetRPCURL(
+		app,
+		network,
+		deployMulticallFlags.chainFlags.SubnetName,
+		deployMulticallFlags.chainFlags.CChain,
+	)
+	if err != nil {
+		return err
+	}
+	if address, err := contract.GetMulticallAddress(rpcURL); err == nil {
+		ux.Logger.PrintToUser("Multicall3 is already available at %s", address.Hex())
+		return nil
+	}
+	privateKey, _, err := getDeployerPrivateKey(
+		network,
+		deployMulticallFlags.chainFlags,
+		deployMulticallFlags.PrivateKeyFlags,
+	)
+	if err != nil {
+		return err
+	}
+	address, _, err := contract.DeployMulticall(rpcURL, privateKey)
+	if err != nil {
+		return fmt.Errorf("%w. The CREATE2 factory can be deployed with avalanche contract deploy create2-factory", err)
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Multicall3 Address: %s", address.Hex())
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Multicall3 Successfully Deployed!")
+	return nil
+}
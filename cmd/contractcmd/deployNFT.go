@@ -0,0 +1,162 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contractcmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/spf13/cobra"
+)
+
+type DeployNFTFlags struct {
+	Network         networkoptions.NetworkFlags
+	PrivateKeyFlags contract.PrivateKeyFlags
+	chainFlags      contract.ChainFlags
+	name            string
+	symbol          string
+	uri             string
+}
+
+var (
+	deployNFTSupportedNetworkOptions = []networkoptions.NetworkOption{
+		networkoptions.Local,
+		networkoptions.Devnet,
+		networkoptions.Fuji,
+	}
+	deployERC721Flags  DeployNFTFlags
+	deployERC1155Flags DeployNFTFlags
+)
+
+// avalanche contract deploy erc721
+func newDeployERC721Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "erc721",
+		Short: "Deploy an ERC721 NFT collection into a given Network and Blockchain",
+		Long: `Deploys an ERC721 NFT collection into a given Network and Blockchain.
+
+The deployer becomes the collection owner, the only one able to mint tokens with
+avalanche contract nft mint. Token ids are assigned sequentially starting at 0, and
+their URIs are given by the base URI followed by the token id.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return deployNFT(contract.ERC721, &deployERC721Flags)
+		},
+		Args: cobrautils.ExactArgs(0),
+	}
+	addDeployNFTFlags(cmd, &deployERC721Flags, "base-uri", "base URI of the token metadata (eg https://example.com/metadata/)")
+	return cmd
+}
+
+// avalanche contract deploy erc1155
+func newDeployERC1155Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "erc1155",
+		Short: "Deploy an ERC1155 multi token collection into a given Network and Blockchain",
+		Long: `Deploys an ERC1155 multi token collection into a given Network and Blockchain.
+
+The deployer becomes the collection owner, the only one able to mint tokens with
+avalanche contract nft mint. Token URIs are given by the collection URI, on which
+clients replace {id} by the hex encoded token id.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return deployNFT(contract.ERC1155, &deployERC1155Flags)
+		},
+		Args: cobrautils.ExactArgs(0),
+	}
+	addDeployNFTFlags(cmd, &deployERC1155Flags, "uri", "URI of the token metadata (eg https://example.com/metadata/{id}.json)")
+	return cmd
+}
+
+func addDeployNFTFlags(
+	cmd *cobra.Command,
+	flags *DeployNFTFlags,
+	uriFlagName string,
+	uriFlagDesc string,
+) {
+	networkoptions.AddNetworkFlagsToCmd(cmd, &flags.Network, true, deployNFTSupportedNetworkOptions)
+	contract.AddPrivateKeyFlagsToCmd(cmd, &flags.PrivateKeyFlags, "as contract deployer and collection owner")
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&flags.chainFlags,
+		"deploy the collection",
+		"",
+		"",
+	)
+	cmd.Flags().StringVar(&flags.name, "name", "", "set the collection name")
+	cmd.Flags().StringVar(&flags.symbol, "symbol", "", "set the collection symbol")
+	cmd.Flags().StringVar(&flags.uri, uriFlagName, "", uriFlagDesc)
+}
+
+func deployNFT(standard contract.NFTStandard, flags *DeployNFTFlags) error {
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		flags.Network,
+		true,
+		false,
+		deployNFTSupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	cancel, err := promptChain(
+		network,
+		&flags.chainFlags,
+		fmt.Sprintf("Where do you want to Deploy the %s Collection?", standard),
+	)
+	if err != nil {
+		return err
+	}
+	if cancel {
+		return nil
+	}
+	privateKey, _, err := getDeployerPrivateKey(
+		network,
+		flags.chainFlags,
+		flags.PrivateKeyFlags,
+	)
+	if err != nil {
+		return err
+	}
+	if flags.name == "" {
+		flags.name, err = app.Prompt.CaptureString("Collection name")
+		if err != nil {
+			return err
+		}
+	}
+	if flags.symbol == "" {
+		flags.symbol, err = app.Prompt.CaptureString("Collection symbol")
+		if err != nil {
+			return err
+		}
+	}
+	rpcURL, err := contract.GetRPCURL(
+		app,
+		network,
+		flags.chainFlags.SubnetName,
+		flags.chainFlags.CChain,
+	)
+	if err != nil {
+		return err
+	}
+	var address common.Address
+	switch standard {
+	case contract.ERC721:
+		address, err = contract.DeployERC721(rpcURL, privateKey, flags.name, flags.symbol, flags.uri)
+	case contract.ERC1155:
+		address, err = contract.DeployERC1155(rpcURL, privateKey, flags.name, flags.symbol, flags.uri)
+	}
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Collection Address: %s", address.Hex())
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("%s Contract Successfully Deployed!", standard)
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contractcmd
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/spf13/cobra"
+)
+
+type DeploySafeFlags struct {
+	Network         networkoptions.NetworkFlags
+	PrivateKeyFlags contract.PrivateKeyFlags
+	chainFlags      contract.ChainFlags
+	owners          []string
+	threshold       uint64
+	saltNonce       uint64
+}
+
+var (
+	deploySafeSupportedNetworkOptions = []networkoptions.NetworkOption{
+		networkoptions.Local,
+		networkoptions.Devnet,
+		networkoptions.Fuji,
+		networkoptions.Mainnet,
+	}
+	deploySafeFlags DeploySafeFlags
+)
+
+// avalanche contract deploy safe
+func newDeploySafeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "safe",
+		Short: "Deploy a Safe multisig wallet into a given Network and Blockchain",
+		Long: `Deploys a Safe multisig wallet into a given Network and Blockchain.
+
+The Safe singleton, proxy factory and fallback handler contracts are deployed first, if
+needed. When the CREATE2 factory is available on the blockchain (see avalanche contract
+deploy create2-factory), they are deployed at deterministic addresses and shared by all
+Safe wallets. If not, a new set of them is deployed.
+
+The wallet is owned by --owners, and requires --threshold of them to sign every tx. Txs are
+prepared and signed with avalanche contract safe propose|sign|execute.
+
+The wallet address can then be set as admin of precompiles allow lists, or as owner of
+other contracts, so they are managed by the multisig instead of by a single key.`,
+		RunE: deploySafe,
+		Args: cobrautils.ExactArgs(0),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &deploySafeFlags.Network, true, deploySafeSupportedNetworkOptions)
+	contract.AddPrivateKeyFlagsToCmd(cmd, &deploySafeFlags.PrivateKeyFlags, "as contract deployer")
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&deploySafeFlags.chainFlags,
+		"deploy the Safe",
+		"",
+		"",
+	)
+	cmd.Flags().StringSliceVar(&deploySafeFlags.owners, "owners", nil, "addresses of the Safe owners")
+	cmd.Flags().Uint64Var(&deploySafeFlags.threshold, "threshold", 0, "number of owner signatures required to execute a Safe tx")
+	cmd.Flags().Uint64Var(&deploySafeFlags.saltNonce, "salt-nonce", 0, "nonce used to derive the Safe address, to create several Safes with the same owners")
+	return cmd
+}
+
+func deploySafe(_ *cobra.Command, _ []string) error {
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		deploySafeFlags.Network,
+		true,
+		false,
+		deploySafeSupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	cancel, err := promptChain(
+		network,
+		&deploySafeFlags.chainFlags,
+		"Where do you want to Deploy the Safe?",
+	)
+	if err != nil {
+		return err
+	}
+	if cancel {
+		return nil
+	}
+	privateKey, _, err := getDeployerPrivateKey(
+		network,
+		deploySafeFlags.chainFlags,
+		deploySafeFlags.PrivateKeyFlags,
+	)
+	if err != nil {
+		return err
+	}
+	var owners []common.Address
+	if len(deploySafeFlags.owners) == 0 {
+		owners, err = app.Prompt.CaptureAddresses("Safe owners addresses")
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, owner := range deploySafeFlags.owners {
+			if err := prompts.ValidateAddress(owner); err != nil {
+				return fmt.Errorf("failure validating address %s: %w", owner, err)
+			}
+			owners = append(owners, common.HexToAddress(owner))
+		}
+	}
+	if deploySafeFlags.threshold == 0 {
+		deploySafeFlags.threshold, err = app.Prompt.CaptureUint64Compare(
+			"Number of owner signatures required to execute a Safe tx",
+			[]prompts.Comparator{
+				{
+					Label: "Number of owners",
+					Type:  prompts.LessThanEq,
+					Value: uint64(len(owners)),
+				},
+				{
+					Label: "Zero",
+					Type:  prompts.MoreThan,
+					Value: 0,
+				},
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	rpcURL, err := contract.GetRPCURL(
+		app,
+		network,
+		deploySafeFlags.chainFlags.SubnetName,
+		deploySafeFlags.chainFlags.CChain,
+	)
+	if err != nil {
+		return err
+	}
+	contracts, deterministic, err := contract.DeploySafeContracts(rpcURL, privateKey)
+	if err != nil {
+		return err
+	}
+	if !deterministic {
+		ux.Logger.PrintToUser("CREATE2 factory not found: Safe contracts were deployed at non deterministic addresses")
+	}
+	ux.Logger.PrintToUser("Safe Singleton Address: %s", contracts.Singleton.Hex())
+	ux.Logger.PrintToUser("Safe Proxy Factory Address: %s", contracts.ProxyFactory.Hex())
+	ux.Logger.PrintToUser("Safe Fallback Handler Address: %s", contracts.FallbackHandler.Hex())
+	safe, err := contract.CreateSafe(
+		rpcURL,
+		privateKey,
+		contracts,
+		owners,
+		deploySafeFlags.threshold,
+		new(big.Int).SetUint64(deploySafeFlags.saltNonce),
+	)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Safe Address: %s", safe.Hex())
+	ux.Logger.PrintToUser("Threshold: %d of %d owners", deploySafeFlags.threshold, len(owners))
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Safe Successfully Deployed!")
+	return nil
+}
@@ -0,0 +1,257 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contractcmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jedib0t/go-pretty/v6/table"
+
+	"github.com/spf13/cobra"
+)
+
+type EventsFlags struct {
+	Network       networkoptions.NetworkFlags
+	chainFlags    contract.ChainFlags
+	event         string
+	indexedFields []int
+	fromBlock     uint64
+	toBlock       uint64
+	follow        bool
+	poll          bool
+	jsonOutput    bool
+}
+
+var (
+	eventsSupportedNetworkOptions = []networkoptions.NetworkOption{
+		networkoptions.Local,
+		networkoptions.Devnet,
+		networkoptions.Fuji,
+		networkoptions.Mainnet,
+	}
+	eventsFlags EventsFlags
+)
+
+// avalanche contract events
+func newEventsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events [contractAddress]",
+		Short: "Show decoded events emitted by a contract",
+		Long: `Shows the events emitted by a contract, decoded according to the given event signature.
+
+The event is described with the CLI ESP syntax (eg "Transfer(address,address,uint256)"), and
+the positions of its indexed fields are given with --indexed (eg --indexed 0,1).
+
+By default, it shows the events already emitted, from --from-block (the last 100000 blocks if
+not given) to --to-block (last block if not given). With --follow, it keeps waiting for new events,
+starting at the last block if --from-block is not given, and reconnecting to the blockchain
+websocket endpoint if the connection is lost. Events are then shown one per line, as they arrive.`,
+		RunE: events,
+		Args: cobrautils.ExactArgs(1),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &eventsFlags.Network, true, eventsSupportedNetworkOptions)
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&eventsFlags.chainFlags,
+		"watch events",
+		"",
+		"",
+	)
+	cmd.Flags().StringVar(&eventsFlags.event, "event", "", "event signature (eg \"Transfer(address,address,uint256)\")")
+	cmd.Flags().IntSliceVar(&eventsFlags.indexedFields, "indexed", []int{}, "positions of the indexed event fields (eg 0,1)")
+	cmd.Flags().Uint64Var(&eventsFlags.fromBlock, "from-block", 0, "show events starting at the given block (default: the last 100000 blocks)")
+	cmd.Flags().Uint64Var(&eventsFlags.toBlock, "to-block", 0, "show events up to the given block (ignored on --follow)")
+	cmd.Flags().BoolVar(&eventsFlags.follow, "follow", false, "keep waiting for new events")
+	cmd.Flags().BoolVar(&eventsFlags.poll, "poll", false, "on --follow, poll the RPC endpoint instead of using a websocket subscription")
+	cmd.Flags().BoolVar(&eventsFlags.jsonOutput, "json", false, "output events as JSON lines")
+	return cmd
+}
+
+func events(cmd *cobra.Command, args []string) error {
+	if err := prompts.ValidateAddress(args[0]); err != nil {
+		return fmt.Errorf("failure validating address %s: %w", args[0], err)
+	}
+	contractAddress := common.HexToAddress(args[0])
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		eventsFlags.Network,
+		true,
+		false,
+		eventsSupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	cancel, err := promptChain(
+		network,
+		&eventsFlags.chainFlags,
+		"Which blockchain do you want to get the events from?",
+	)
+	if err != nil {
+		return err
+	}
+	if cancel {
+		return nil
+	}
+	if eventsFlags.event == "" {
+		eventsFlags.event, err = app.Prompt.CaptureString("Event signature (eg Transfer(address,address,uint256))")
+		if err != nil {
+			return err
+		}
+	}
+	event, err := contract.GetEvent(eventsFlags.event, eventsFlags.indexedFields)
+	if err != nil {
+		return err
+	}
+	rpcURL, err := contract.GetRPCURL(
+		app,
+		network,
+		eventsFlags.chainFlags.SubnetName,
+		eventsFlags.chainFlags.CChain,
+	)
+	if err != nil {
+		return err
+	}
+	query := interfaces.FilterQuery{
+		Addresses: []common.Address{contractAddress},
+		Topics:    [][]common.Hash{{event.ID}},
+	}
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	headBlock, err := evm.GetBlockNumber(client)
+	if err != nil {
+		return err
+	}
+	printer := newEventsPrinter(eventsFlags.event, eventsFlags.indexedFields, eventsFlags.jsonOutput, eventsFlags.follow)
+	if !eventsFlags.follow {
+		toBlock := headBlock
+		if cmd.Flags().Changed("to-block") && eventsFlags.toBlock < headBlock {
+			toBlock = eventsFlags.toBlock
+		}
+		fromBlock := evm.GetRecentBlocksStart(headBlock)
+		if cmd.Flags().Changed("from-block") {
+			fromBlock = eventsFlags.fromBlock
+		}
+		logs, err := evm.GetLogsInRange(client, query, fromBlock, toBlock)
+		if err != nil {
+			return err
+		}
+		return printer.print(logs)
+	}
+	fromBlock := headBlock + 1
+	if cmd.Flags().Changed("from-block") {
+		fromBlock = eventsFlags.fromBlock
+	}
+	wsURL := ""
+	if !eventsFlags.poll {
+		wsURL, err = contract.GetWSURL(
+			app,
+			network,
+			eventsFlags.chainFlags.SubnetName,
+			eventsFlags.chainFlags.CChain,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return evm.WatchLogs(ctx, rpcURL, wsURL, query, fromBlock, printer.print)
+}
+
+type eventsPrinter struct {
+	eventEsp      string
+	eventName     string
+	indexedFields []int
+	jsonOutput    bool
+	// on follow, events are printed as lines, as each polled batch arrives
+	follow bool
+}
+
+func newEventsPrinter(eventEsp string, indexedFields []int, jsonOutput bool, follow bool) *eventsPrinter {
+	eventName := eventEsp
+	if index := strings.Index(eventEsp, "("); index != -1 {
+		eventName = eventEsp[:index]
+	}
+	return &eventsPrinter{
+		eventEsp:      eventEsp,
+		eventName:     eventName,
+		indexedFields: indexedFields,
+		jsonOutput:    jsonOutput,
+		follow:        follow,
+	}
+}
+
+type eventJSON struct {
+	BlockNumber uint64   `json:"blockNumber"`
+	TxHash      string   `json:"txHash"`
+	LogIndex    uint     `json:"logIndex"`
+	Address     string   `json:"address"`
+	Event       string   `json:"event"`
+	Args        []string `json:"args"`
+}
+
+func (p *eventsPrinter) print(logs []types.Log) error {
+	t := table.NewWriter()
+	t.Style().Options.SeparateRows = false
+	t.AppendHeader(table.Row{"Block", "Tx Hash", "Log Index", "Event"})
+	for _, log := range logs {
+		values, err := contract.UnpackLogValues(p.eventEsp, p.indexedFields, log)
+		if err != nil {
+			return err
+		}
+		args := []string{}
+		for _, value := range values {
+			args = append(args, contract.FormatValue(value))
+		}
+		if p.jsonOutput {
+			bs, err := json.Marshal(eventJSON{
+				BlockNumber: log.BlockNumber,
+				TxHash:      log.TxHash.Hex(),
+				LogIndex:    log.Index,
+				Address:     log.Address.Hex(),
+				Event:       p.eventName,
+				Args:        args,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bs))
+			continue
+		}
+		event := fmt.Sprintf("%s(%s)", p.eventName, strings.Join(args, ", "))
+		if p.follow {
+			fmt.Printf("[block %d] %s %d %s\n", log.BlockNumber, log.TxHash.Hex(), log.Index, event)
+			continue
+		}
+		t.AppendRow(table.Row{
+			log.BlockNumber,
+			log.TxHash.Hex(),
+			log.Index,
+			event,
+		})
+	}
+	if !p.jsonOutput && !p.follow && t.Length() > 0 {
+		fmt.Println(t.Render())
+	}
+	return nil
+}
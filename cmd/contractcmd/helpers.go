@@ -0,0 +1,255 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contractcmd
+
+import (
+	"fmt"
+
+	cmdflags "github.com/ava-labs/avalanche-cli/cmd/flags"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/spf13/cobra"
+)
+
+type Create2Flags struct {
+	create2 bool
+	salt    string
+}
+
+func addCreate2FlagsToCmd(cmd *cobra.Command, create2Flags *Create2Flags) {
+	cmd.Flags().BoolVar(
+		&create2Flags.create2,
+		"create2",
+		false,
+		"deploy through the CREATE2 factory, so the contract address is the same on all blockchains",
+	)
+	cmd.Flags().StringVar(
+		&create2Flags.salt,
+		"salt",
+		"",
+		"CREATE2 salt, either 32 bytes hex encoded or a string to be hashed (implies --create2)",
+	)
+}
+
+func (f Create2Flags) enabled() bool {
+	return f.create2 || f.salt != ""
+}
+
+type ProxyFlags struct {
+	proxy           string
+	initializer     string
+	initializerArgs []string
+	proxyAdminOwner string
+}
+
+func addProxyFlagsToCmd(cmd *cobra.Command, proxyFlags *ProxyFlags) {
+	cmd.Flags().StringVar(
+		&proxyFlags.proxy,
+		"proxy",
+		"",
+		fmt.Sprintf("deploy the contract behind an upgradeable proxy (%s or %s)", contract.TransparentProxy, contract.UUPSProxy),
+	)
+	cmd.Flags().StringVar(
+		&proxyFlags.initializer,
+		"initializer",
+		"",
+		"method to call on the proxy at deploy time (eg \"initialize(address, uint256)\")",
+	)
+	cmd.Flags().StringArrayVar(
+		&proxyFlags.initializerArgs,
+		"initializer-args",
+		nil,
+		"args for the initializer method (one flag per arg)",
+	)
+	cmd.Flags().StringVar(
+		&proxyFlags.proxyAdminOwner,
+		"proxy-admin-owner",
+		"",
+		"owner of the ProxyAdmin of a transparent proxy (defaults to the deployer)",
+	)
+}
+
+func (f ProxyFlags) enabled() bool {
+	return f.proxy != ""
+}
+
+// returns the calldata for the initializer given by the flags, if any
+func (f ProxyFlags) getInitData() ([]byte, error) {
+	if !f.enabled() {
+		if f.initializer != "" || len(f.initializerArgs) > 0 || f.proxyAdminOwner != "" {
+			return nil, fmt.Errorf("--initializer, --initializer-args and --proxy-admin-owner can only be used together with --proxy")
+		}
+		return nil, nil
+	}
+	return getMethodCallData(f.initializer, f.initializerArgs)
+}
+
+// packs the call to [methodEsp] with the string values [args], as given by the user
+func getMethodCallData(methodEsp string, args []string) ([]byte, error) {
+	if methodEsp == "" {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("method args given without a method")
+		}
+		return nil, nil
+	}
+	params, err := contract.ParseMethodEspValues(methodEsp, args)
+	if err != nil {
+		return nil, err
+	}
+	return contract.PackMethodCall(methodEsp, params...)
+}
+
+// deploys a proxy of kind given by [proxyFlags] for [implementation], and reports it to the user
+func deployProxy(
+	rpcURL string,
+	privateKey string,
+	implementation common.Address,
+	initData []byte,
+	proxyFlags ProxyFlags,
+) error {
+	kind, err := contract.GetProxyKind(proxyFlags.proxy)
+	if err != nil {
+		return err
+	}
+	if proxyFlags.proxyAdminOwner != "" {
+		if kind != contract.TransparentProxy {
+			return fmt.Errorf("--proxy-admin-owner is only supported for %s proxies", contract.TransparentProxy)
+		}
+		if err := prompts.ValidateAddress(proxyFlags.proxyAdminOwner); err != nil {
+			return fmt.Errorf("failure validating address %s: %w", proxyFlags.proxyAdminOwner, err)
+		}
+	}
+	deployment, err := contract.DeployProxy(rpcURL, privateKey, kind, implementation, initData)
+	if err != nil {
+		return err
+	}
+	if proxyFlags.proxyAdminOwner != "" {
+		if err := contract.TransferProxyAdminOwnership(
+			rpcURL,
+			privateKey,
+			deployment.ProxyAdmin,
+			common.HexToAddress(proxyFlags.proxyAdminOwner),
+		); err != nil {
+			return err
+		}
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Implementation Address: %s", deployment.Implementation.Hex())
+	if kind == contract.TransparentProxy {
+		ux.Logger.PrintToUser("Proxy Admin Address: %s", deployment.ProxyAdmin.Hex())
+	}
+	ux.Logger.PrintToUser("Proxy Address: %s", deployment.Proxy.Hex())
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Upgradeable Contract Successfully Deployed!")
+	return nil
+}
+
+// asks the user for the blockchain to use, if not given by [chainFlags]
+// returns true if the user cancelled the operation
+func promptChain(
+	network models.Network,
+	chainFlags *contract.ChainFlags,
+	prompt string,
+) (bool, error) {
+	if !cmdflags.EnsureMutuallyExclusive([]bool{
+		chainFlags.SubnetName != "",
+		chainFlags.CChain,
+	}) {
+		return false, fmt.Errorf("--subnet and --c-chain are mutually exclusive flags")
+	}
+	if chainFlags.SubnetName == "" && !chainFlags.CChain {
+		subnetNames, err := app.GetSubnetNamesOnNetwork(network)
+		if err != nil {
+			return false, err
+		}
+		cancel, _, _, cChain, subnetName, err := prompts.PromptChain(
+			app.Prompt,
+			prompt,
+			subnetNames,
+			true,
+			true,
+			false,
+			"",
+		)
+		if cancel {
+			return true, nil
+		}
+		if err == nil {
+			chainFlags.SubnetName = subnetName
+			chainFlags.CChain = cChain
+		}
+	}
+	return false, nil
+}
+
+// gets the private key to pay for the deploy from [privateKeyFlags], or
+// prompts the user for it
+// returns the private key and the address of the blockchain genesis funded key
+// if it is managed by CLI
+func getDeployerPrivateKey(
+	network models.Network,
+	chainFlags contract.ChainFlags,
+	privateKeyFlags contract.PrivateKeyFlags,
+) (string, string, error) {
+	return getSignerPrivateKey(
+		network,
+		chainFlags,
+		privateKeyFlags,
+		"deploy the contract",
+		"A private key is needed to pay for the contract deploy fees.",
+		"It will also be considered the owner address of the contract, beign able to call",
+		"the contract methods only available to owners.",
+	)
+}
+
+// gets the private key to sign txs for [goal] from [privateKeyFlags], or
+// prompts the user for it, after showing [explanation]
+// returns the private key and the address of the blockchain genesis funded key
+// if it is managed by CLI
+func getSignerPrivateKey(
+	network models.Network,
+	chainFlags contract.ChainFlags,
+	privateKeyFlags contract.PrivateKeyFlags,
+	goal string,
+	explanation ...string,
+) (string, string, error) {
+	genesisAddress, genesisPrivateKey, err := contract.GetEVMSubnetPrefundedKey(
+		app,
+		network,
+		chainFlags.SubnetName,
+		chainFlags.CChain,
+		"",
+	)
+	if err != nil {
+		return "", "", err
+	}
+	privateKey, err := contract.GetPrivateKeyFromFlags(
+		app,
+		privateKeyFlags,
+		genesisPrivateKey,
+	)
+	if err != nil {
+		return "", "", err
+	}
+	if privateKey == "" {
+		for _, line := range explanation {
+			ux.Logger.PrintToUser("%s", line)
+		}
+		privateKey, err = prompts.PromptPrivateKey(
+			app.Prompt,
+			goal,
+			app.GetKeyDir(),
+			app.GetKey,
+			genesisAddress,
+			genesisPrivateKey,
+		)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return privateKey, genesisAddress, nil
+}
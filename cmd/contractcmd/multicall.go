@@ -0,0 +1,147 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contractcmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+
+	"github.com/spf13/cobra"
+)
+
+type MulticallFlags struct {
+	Network         networkoptions.NetworkFlags
+	PrivateKeyFlags contract.PrivateKeyFlags
+	chainFlags      contract.ChainFlags
+	file            string
+	send            bool
+}
+
+var (
+	multicallSupportedNetworkOptions = []networkoptions.NetworkOption{
+		networkoptions.Local,
+		networkoptions.Devnet,
+		networkoptions.Fuji,
+		networkoptions.Mainnet,
+	}
+	multicallFlags MulticallFlags
+)
+
+// avalanche contract multicall
+func newMulticallCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "multicall",
+		Short: "Batch several contract calls into one request",
+		Long: `Executes the contract calls described at --file in one request, through Multicall3.
+
+The file is a YAML list of calls, as in:
+
+calls:
+  - to: "0x..."
+    method: "balanceOf(address)->(uint256)"
+    args: ["0x..."]
+  - to: "0x..."
+    method: "transfer(address, uint256)"
+    args: ["0x...", "1000"]
+    value: "0"
+    allowFailure: true
+
+By default the calls are read only, executed in one eth_call, and their outputs are shown.
+With --send, they are issued in one transaction signed by the given key. Note that the called
+contracts then see Multicall3, not the signer, as the caller.
+
+Multicall3 can be deployed with avalanche contract deploy multicall. Read only calls are made one
+by one if it is not available.`,
+		RunE: multicall,
+		Args: cobrautils.ExactArgs(0),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &multicallFlags.Network, true, multicallSupportedNetworkOptions)
+	contract.AddPrivateKeyFlagsToCmd(cmd, &multicallFlags.PrivateKeyFlags, "to sign the multicall tx (--send)")
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&multicallFlags.chainFlags,
+		"execute the calls",
+		"",
+		"",
+	)
+	cmd.Flags().StringVar(&multicallFlags.file, "file", "", "YAML file describing the calls")
+	cmd.Flags().BoolVar(&multicallFlags.send, "send", false, "issue the calls in one transaction, instead of reading their outputs")
+	return cmd
+}
+
+func multicall(_ *cobra.Command, _ []string) error {
+	if multicallFlags.file == "" {
+		return fmt.Errorf("--file is required")
+	}
+	calls, err := contract.LoadCallsFile(multicallFlags.file)
+	if err != nil {
+		return err
+	}
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		multicallFlags.Network,
+		true,
+		false,
+		multicallSupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	cancel, err := promptChain(
+		network,
+		&multicallFlags.chainFlags,
+		"Which blockchain are the contracts on?",
+	)
+	if err != nil {
+		return err
+	}
+	if cancel {
+		return nil
+	}
+	rpcURL, err := contract.GetRPCURL(
+		app,
+		network,
+		multicallFlags.chainFlags.SubnetName,
+		multicallFlags.chainFlags.CChain,
+	)
+	if err != nil {
+		return err
+	}
+	if multicallFlags.send {
+		privateKey, _, err := getSignerPrivateKey(
+			network,
+			multicallFlags.chainFlags,
+			multicallFlags.PrivateKeyFlags,
+			"issue the multicall tx",
+			"A private key is needed to pay for the multicall tx fees and call values.",
+		)
+		if err != nil {
+			return err
+		}
+		tx, _, err := contract.MulticallWrite(rpcURL, privateKey, calls)
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("")
+		ux.Logger.PrintToUser("%d calls issued at tx %s", len(calls), tx.Hash().Hex())
+		return nil
+	}
+	results, err := contract.MulticallRead(rpcURL, calls)
+	if err != nil {
+		return err
+	}
+	for i, result := range results {
+		if !result.Success {
+			ux.Logger.RedXToUser("%d: %s on %s failed", i, calls[i].MethodEsp, calls[i].Target.Hex())
+			continue
+		}
+		ux.Logger.PrintToUser("%d: %s on %s -> %s", i, calls[i].MethodEsp, calls[i].Target.Hex(), contract.FormatValues(result.Values))
+	}
+	return nil
+}
@@ -0,0 +1,328 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contractcmd
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/spf13/cobra"
+)
+
+type NFTFlags struct {
+	Network         networkoptions.NetworkFlags
+	PrivateKeyFlags contract.PrivateKeyFlags
+	chainFlags      contract.ChainFlags
+	to              string
+	account         string
+	tokenID         string
+	amount          uint64
+}
+
+var (
+	nftSupportedNetworkOptions = []networkoptions.NetworkOption{
+		networkoptions.Local,
+		networkoptions.Devnet,
+		networkoptions.Fuji,
+		networkoptions.Mainnet,
+	}
+	nftFlags NFTFlags
+)
+
+// avalanche contract nft
+func newNFTCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "nft",
+		Short: "Mint, transfer and query ERC721 and ERC1155 tokens",
+		Long: `The nft command suite provides a collection of tools for operating on ERC721 and
+ERC1155 collections, as the ones deployed with avalanche contract deploy erc721|erc1155.
+
+The collection standard is detected from the contract itself.`,
+		RunE: cobrautils.CommandSuiteUsage,
+	}
+	// contract nft mint
+	cmd.AddCommand(newNFTMintCmd())
+	// contract nft transfer
+	cmd.AddCommand(newNFTTransferCmd())
+	// contract nft owner
+	cmd.AddCommand(newNFTOwnerCmd())
+	return cmd
+}
+
+func addNFTCommonFlags(cmd *cobra.Command, signerGoal string) {
+	networkoptions.AddNetworkFlagsToCmd(cmd, &nftFlags.Network, true, nftSupportedNetworkOptions)
+	if signerGoal != "" {
+		contract.AddPrivateKeyFlagsToCmd(cmd, &nftFlags.PrivateKeyFlags, signerGoal)
+	}
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&nftFlags.chainFlags,
+		"operate on the collection",
+		"",
+		"",
+	)
+}
+
+// avalanche contract nft mint
+func newNFTMintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mint [collectionAddress]",
+		Short: "Mint a token of a collection",
+		Long: `Mints a token of a collection into --to address. Only the collection owner can mint.
+
+For ERC721 collections, the next token id is minted and shown. For ERC1155 collections,
+--amount units of --token-id are minted.`,
+		RunE: nftMint,
+		Args: cobrautils.ExactArgs(1),
+	}
+	addNFTCommonFlags(cmd, "as collection owner")
+	cmd.Flags().StringVar(&nftFlags.to, "to", "", "address to mint the token into")
+	cmd.Flags().StringVar(&nftFlags.tokenID, "token-id", "", "token id to mint (ERC1155 only)")
+	cmd.Flags().Uint64Var(&nftFlags.amount, "amount", 1, "amount of tokens to mint (ERC1155 only)")
+	return cmd
+}
+
+// avalanche contract nft transfer
+func newNFTTransferCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer [collectionAddress]",
+		Short: "Transfer a token of a collection",
+		Long: `Transfers a token of a collection, owned by the signer, into --to address.
+
+For ERC1155 collections, --amount units of --token-id are transferred.`,
+		RunE: nftTransfer,
+		Args: cobrautils.ExactArgs(1),
+	}
+	addNFTCommonFlags(cmd, "as token owner")
+	cmd.Flags().StringVar(&nftFlags.to, "to", "", "address to transfer the token to")
+	cmd.Flags().StringVar(&nftFlags.tokenID, "token-id", "", "token id to transfer")
+	cmd.Flags().Uint64Var(&nftFlags.amount, "amount", 1, "amount of tokens to transfer (ERC1155 only)")
+	return cmd
+}
+
+// avalanche contract nft owner
+func newNFTOwnerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "owner [collectionAddress]",
+		Short: "Show the owner of a token of a collection",
+		Long: `Shows the owner and URI of an ERC721 token, or the balance of --account for an
+ERC1155 token.`,
+		RunE: nftOwner,
+		Args: cobrautils.ExactArgs(1),
+	}
+	addNFTCommonFlags(cmd, "")
+	cmd.Flags().StringVar(&nftFlags.tokenID, "token-id", "", "token id to query")
+	cmd.Flags().StringVar(&nftFlags.account, "account", "", "account to get the balance for (ERC1155 only)")
+	return cmd
+}
+
+// validates the collection address, gets the network, chain, and collection standard
+func getNFTCollection(collectionAddressStr string) (string, common.Address, contract.NFTStandard, bool, error) {
+	if err := prompts.ValidateAddress(collectionAddressStr); err != nil {
+		return "", common.Address{}, contract.UndefinedNFT, false, fmt.Errorf("failure validating address %s: %w", collectionAddressStr, err)
+	}
+	collectionAddress := common.HexToAddress(collectionAddressStr)
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		nftFlags.Network,
+		true,
+		false,
+		nftSupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return "", common.Address{}, contract.UndefinedNFT, false, err
+	}
+	cancel, err := promptChain(
+		network,
+		&nftFlags.chainFlags,
+		"Which blockchain is the collection on?",
+	)
+	if err != nil || cancel {
+		return "", common.Address{}, contract.UndefinedNFT, cancel, err
+	}
+	rpcURL, err := contract.GetRPCURL(
+		app,
+		network,
+		nftFlags.chainFlags.SubnetName,
+		nftFlags.chainFlags.CChain,
+	)
+	if err != nil {
+		return "", common.Address{}, contract.UndefinedNFT, false, err
+	}
+	standard, err := contract.GetNFTStandard(rpcURL, collectionAddress)
+	if err != nil {
+		return "", common.Address{}, contract.UndefinedNFT, false, err
+	}
+	return rpcURL, collectionAddress, standard, false, nil
+}
+
+// gets the private key of the signer of the nft txs, and its address
+func getNFTSigner(goal string) (string, common.Address, error) {
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		nftFlags.Network,
+		true,
+		false,
+		nftSupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return "", common.Address{}, err
+	}
+	privateKey, _, err := getSignerPrivateKey(
+		network,
+		nftFlags.chainFlags,
+		nftFlags.PrivateKeyFlags,
+		goal,
+	)
+	if err != nil {
+		return "", common.Address{}, err
+	}
+	pk, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		return "", common.Address{}, err
+	}
+	return privateKey, crypto.PubkeyToAddress(pk.PublicKey), nil
+}
+
+func getNFTAddressFlag(flagValue string, goal string) (common.Address, error) {
+	if flagValue == "" {
+		addr, err := app.Prompt.CaptureAddress(fmt.Sprintf("Enter the address to %s", goal))
+		if err != nil {
+			return common.Address{}, err
+		}
+		return addr, nil
+	}
+	if err := prompts.ValidateAddress(flagValue); err != nil {
+		return common.Address{}, fmt.Errorf("failure validating address %s: %w", flagValue, err)
+	}
+	return common.HexToAddress(flagValue), nil
+}
+
+func getNFTTokenID() (*big.Int, error) {
+	if nftFlags.tokenID == "" {
+		tokenID, err := app.Prompt.CaptureUint64Compare("Token ID", nil)
+		if err != nil {
+			return nil, err
+		}
+		return new(big.Int).SetUint64(tokenID), nil
+	}
+	tokenID, ok := new(big.Int).SetString(nftFlags.tokenID, 0)
+	if !ok || tokenID.Sign() < 0 {
+		return nil, fmt.Errorf("invalid token id %q", nftFlags.tokenID)
+	}
+	return tokenID, nil
+}
+
+func nftMint(_ *cobra.Command, args []string) error {
+	rpcURL, collectionAddress, standard, cancel, err := getNFTCollection(args[0])
+	if err != nil || cancel {
+		return err
+	}
+	privateKey, _, err := getNFTSigner("mint the token")
+	if err != nil {
+		return err
+	}
+	to, err := getNFTAddressFlag(nftFlags.to, "mint the token into")
+	if err != nil {
+		return err
+	}
+	switch standard {
+	case contract.ERC721:
+		tokenID, err := contract.ERC721Mint(rpcURL, privateKey, collectionAddress, to)
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Token %s minted into %s", tokenID, to.Hex())
+	case contract.ERC1155:
+		tokenID, err := getNFTTokenID()
+		if err != nil {
+			return err
+		}
+		amount := new(big.Int).SetUint64(nftFlags.amount)
+		if err := contract.ERC1155Mint(rpcURL, privateKey, collectionAddress, to, tokenID, amount); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("%s units of token %s minted into %s", amount, tokenID, to.Hex())
+	}
+	return nil
+}
+
+func nftTransfer(_ *cobra.Command, args []string) error {
+	rpcURL, collectionAddress, standard, cancel, err := getNFTCollection(args[0])
+	if err != nil || cancel {
+		return err
+	}
+	privateKey, from, err := getNFTSigner("transfer the token")
+	if err != nil {
+		return err
+	}
+	tokenID, err := getNFTTokenID()
+	if err != nil {
+		return err
+	}
+	to, err := getNFTAddressFlag(nftFlags.to, "transfer the token to")
+	if err != nil {
+		return err
+	}
+	switch standard {
+	case contract.ERC721:
+		if err := contract.ERC721Transfer(rpcURL, privateKey, collectionAddress, from, to, tokenID); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Token %s transferred to %s", tokenID, to.Hex())
+	case contract.ERC1155:
+		amount := new(big.Int).SetUint64(nftFlags.amount)
+		if err := contract.ERC1155Transfer(rpcURL, privateKey, collectionAddress, from, to, tokenID, amount); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("%s units of token %s transferred to %s", amount, tokenID, to.Hex())
+	}
+	return nil
+}
+
+func nftOwner(_ *cobra.Command, args []string) error {
+	rpcURL, collectionAddress, standard, cancel, err := getNFTCollection(args[0])
+	if err != nil || cancel {
+		return err
+	}
+	tokenID, err := getNFTTokenID()
+	if err != nil {
+		return err
+	}
+	switch standard {
+	case contract.ERC721:
+		owner, err := contract.ERC721OwnerOf(rpcURL, collectionAddress, tokenID)
+		if err != nil {
+			return err
+		}
+		uri, err := contract.ERC721TokenURI(rpcURL, collectionAddress, tokenID)
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Owner: %s", owner.Hex())
+		ux.Logger.PrintToUser("URI: %s", uri)
+	case contract.ERC1155:
+		account, err := getNFTAddressFlag(nftFlags.account, "get the balance for")
+		if err != nil {
+			return err
+		}
+		balance, err := contract.ERC1155BalanceOf(rpcURL, collectionAddress, account, tokenID)
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Balance of %s: %s", account.Hex(), balance)
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contractcmd
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/spf13/cobra"
+)
+
+type SafeFlags struct {
+	Network         networkoptions.NetworkFlags
+	PrivateKeyFlags contract.PrivateKeyFlags
+	chainFlags      contract.ChainFlags
+	to              string
+	value           string
+	data            string
+	method          string
+	delegateCall    bool
+	nonce           int64
+	file            string
+}
+
+var (
+	safeSupportedNetworkOptions = []networkoptions.NetworkOption{
+		networkoptions.Local,
+		networkoptions.Devnet,
+		networkoptions.Fuji,
+		networkoptions.Mainnet,
+	}
+	safeFlags SafeFlags
+)
+
+// avalanche contract safe
+func newSafeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "safe",
+		Short: "Propose, sign and execute Safe multisig txs",
+		Long: `The safe command suite provides a collection of tools for operating a Safe multisig
+wallet, as the ones deployed with avalanche contract deploy safe.
+
+A Safe tx is first proposed into a file, that is then passed along the Safe owners so
+each one adds its signature to it. Once the file has enough signatures, anyone can
+execute the tx.`,
+		RunE: cobrautils.CommandSuiteUsage,
+	}
+	// contract safe propose
+	cmd.AddCommand(newSafeProposeCmd())
+	// contract safe sign
+	cmd.AddCommand(newSafeSignCmd())
+	// contract safe execute
+	cmd.AddCommand(newSafeExecuteCmd())
+	return cmd
+}
+
+func addSafeCommonFlags(cmd *cobra.Command, signerGoal string) {
+	networkoptions.AddNetworkFlagsToCmd(cmd, &safeFlags.Network, true, safeSupportedNetworkOptions)
+	if signerGoal != "" {
+		contract.AddPrivateKeyFlagsToCmd(cmd, &safeFlags.PrivateKeyFlags, signerGoal)
+	}
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&safeFlags.chainFlags,
+		"operate on the Safe",
+		"",
+		"",
+	)
+}
+
+// avalanche contract safe propose
+func newSafeProposeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "propose [safeAddress] [methodArgs]",
+		Short: "Create a Safe tx proposal file",
+		Long: `Creates a file with a tx to be executed by the Safe, ready to be signed by its owners.
+
+The tx calldata can be given either raw with --data, or with --method, using the CLI ESP
+syntax (eg "setAdmin(address)"), and the method args as positional arguments.
+
+Eg, to make the Safe set an admin on the tx allow list precompile:
+  avalanche contract safe propose <safe> --to 0x0200000000000000000000000000000000000002 \
+    --method "setAdmin(address)" <newAdmin> --file setAdmin.json`,
+		RunE: safePropose,
+		Args: cobrautils.MinimumNArgs(1),
+	}
+	addSafeCommonFlags(cmd, "")
+	cmd.Flags().StringVar(&safeFlags.to, "to", "", "destination address of the Safe tx")
+	cmd.Flags().StringVar(&safeFlags.value, "value", "0", "amount of native tokens to send with the Safe tx, in wei")
+	cmd.Flags().StringVar(&safeFlags.data, "data", "", "hex encoded calldata of the Safe tx")
+	cmd.Flags().StringVar(&safeFlags.method, "method", "", "method to call with the Safe tx (eg \"setAdmin(address)\")")
+	cmd.Flags().BoolVar(&safeFlags.delegateCall, "delegate-call", false, "execute the Safe tx as a delegate call")
+	cmd.Flags().Int64Var(&safeFlags.nonce, "nonce", -1, "nonce of the Safe tx (defaults to the current Safe nonce)")
+	cmd.Flags().StringVar(&safeFlags.file, "file", "", "path of the proposal file to create")
+	return cmd
+}
+
+// avalanche contract safe sign
+func newSafeSignCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign [proposalFile]",
+		Short: "Add a Safe owner signature to a Safe tx proposal file",
+		Long:  `Signs the tx of a proposal file with the key of a Safe owner, and adds the signature to the file.`,
+		RunE:  safeSign,
+		Args:  cobrautils.ExactArgs(1),
+	}
+	addSafeCommonFlags(cmd, "as Safe owner")
+	return cmd
+}
+
+// avalanche contract safe execute
+func newSafeExecuteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "execute [proposalFile]",
+		Short: "Execute a signed Safe tx proposal",
+		Long: `Executes the tx of a proposal file, once it has been signed by the required number of
+Safe owners. Any key can be used to pay for the execution fees.`,
+		RunE: safeExecute,
+		Args: cobrautils.ExactArgs(1),
+	}
+	addSafeCommonFlags(cmd, "to pay for the execution fees")
+	return cmd
+}
+
+// gets the network, chain and rpc url to operate on the Safe
+func getSafeRPCURL() (models.Network, string, bool, error) {
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		safeFlags.Network,
+		true,
+		false,
+		safeSupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return models.UndefinedNetwork, "", false, err
+	}
+	cancel, err := promptChain(
+		network,
+		&safeFlags.chainFlags,
+		"Which blockchain is the Safe on?",
+	)
+	if err != nil || cancel {
+		return models.UndefinedNetwork, "", cancel, err
+	}
+	rpcURL, err := contract.GetRPCURL(
+		app,
+		network,
+		safeFlags.chainFlags.SubnetName,
+		safeFlags.chainFlags.CChain,
+	)
+	if err != nil {
+		return models.UndefinedNetwork, "", false, err
+	}
+	return network, rpcURL, false, nil
+}
+
+// loads the proposal at [path], checking it belongs to the blockchain at [rpcURL]
+func loadSafeProposal(rpcURL string, path string) (*contract.SafeTransactionProposal, error) {
+	proposal, err := contract.LoadSafeTransactionProposal(path)
+	if err != nil {
+		return nil, err
+	}
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	chainID, err := evm.GetChainID(client)
+	if err != nil {
+		return nil, err
+	}
+	if chainID.Cmp(proposal.ChainID) != 0 {
+		return nil, fmt.Errorf("proposal is for chain id %s, but the selected blockchain has chain id %s", proposal.ChainID, chainID)
+	}
+	return proposal, nil
+}
+
+func getSafeTxData(args []string) ([]byte, error) {
+	if safeFlags.data != "" && safeFlags.method != "" {
+		return nil, fmt.Errorf("--data and --method are mutually exclusive flags")
+	}
+	if safeFlags.method == "" {
+		if len(args) > 0 {
+			return nil, fmt.Errorf("method args can only be given together with --method")
+		}
+		return common.FromHex(safeFlags.data), nil
+	}
+	return getMethodCallData(safeFlags.method, args)
+}
+
+func safePropose(_ *cobra.Command, args []string) error {
+	if err := prompts.ValidateAddress(args[0]); err != nil {
+		return fmt.Errorf("failure validating address %s: %w", args[0], err)
+	}
+	safe := common.HexToAddress(args[0])
+	data, err := getSafeTxData(args[1:])
+	if err != nil {
+		return err
+	}
+	value, ok := new(big.Int).SetString(safeFlags.value, 0)
+	if !ok || value.Sign() < 0 {
+		return fmt.Errorf("invalid value %q", safeFlags.value)
+	}
+	if safeFlags.to == "" {
+		to, err := app.Prompt.CaptureAddress("Destination address of the Safe tx")
+		if err != nil {
+			return err
+		}
+		safeFlags.to = to.Hex()
+	} else if err := prompts.ValidateAddress(safeFlags.to); err != nil {
+		return fmt.Errorf("failure validating address %s: %w", safeFlags.to, err)
+	}
+	_, rpcURL, cancel, err := getSafeRPCURL()
+	if err != nil || cancel {
+		return err
+	}
+	tx := contract.SafeTransaction{
+		To:    common.HexToAddress(safeFlags.to),
+		Value: value,
+		Data:  data,
+	}
+	if safeFlags.delegateCall {
+		tx.Operation = contract.SafeDelegateCallOperation
+	}
+	if safeFlags.nonce >= 0 {
+		tx.Nonce = big.NewInt(safeFlags.nonce)
+	}
+	proposal, err := contract.NewSafeTransactionProposal(rpcURL, safe, tx)
+	if err != nil {
+		return err
+	}
+	if safeFlags.file == "" {
+		safeFlags.file = fmt.Sprintf("safe_tx_%s_%s.json", safe.Hex()[:10], proposal.Transaction.Nonce)
+	}
+	if utils.FileExists(safeFlags.file) {
+		return fmt.Errorf("proposal file %s already exists", safeFlags.file)
+	}
+	if err := proposal.Save(safeFlags.file); err != nil {
+		return err
+	}
+	threshold, err := contract.GetSafeThreshold(rpcURL, safe)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Safe tx %s proposed with nonce %s", proposal.Hash.Hex(), proposal.Transaction.Nonce)
+	ux.Logger.PrintToUser("Proposal saved to %s", safeFlags.file)
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("It needs %d owner signatures. Owners can add them with:", threshold)
+	ux.Logger.PrintToUser("  avalanche contract safe sign %s", safeFlags.file)
+	return nil
+}
+
+func safeSign(_ *cobra.Command, args []string) error {
+	path := args[0]
+	network, rpcURL, cancel, err := getSafeRPCURL()
+	if err != nil || cancel {
+		return err
+	}
+	proposal, err := loadSafeProposal(rpcURL, path)
+	if err != nil {
+		return err
+	}
+	privateKey, _, err := getSignerPrivateKey(
+		network,
+		safeFlags.chainFlags,
+		safeFlags.PrivateKeyFlags,
+		"sign the Safe tx",
+		"A private key of a Safe owner is needed to sign the Safe tx.",
+	)
+	if err != nil {
+		return err
+	}
+	sig, err := contract.SignSafeTransaction(privateKey, proposal.Hash)
+	if err != nil {
+		return err
+	}
+	owners, err := contract.GetSafeOwners(rpcURL, proposal.Safe)
+	if err != nil {
+		return err
+	}
+	if !utils.Belongs(owners, sig.Signer) {
+		return fmt.Errorf("%s is not an owner of Safe %s", sig.Signer.Hex(), proposal.Safe.Hex())
+	}
+	if err := proposal.AddSignature(sig); err != nil {
+		return err
+	}
+	if err := proposal.Save(path); err != nil {
+		return err
+	}
+	threshold, err := contract.GetSafeThreshold(rpcURL, proposal.Safe)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Safe tx %s signed by %s", proposal.Hash.Hex(), sig.Signer.Hex())
+	ux.Logger.PrintToUser("Signatures: %d of %d required", len(proposal.Signatures), threshold)
+	if uint64(len(proposal.Signatures)) >= threshold {
+		ux.Logger.PrintToUser("")
+		ux.Logger.PrintToUser("The Safe tx can now be executed with:")
+		ux.Logger.PrintToUser("  avalanche contract safe execute %s", path)
+	}
+	return nil
+}
+
+func safeExecute(_ *cobra.Command, args []string) error {
+	network, rpcURL, cancel, err := getSafeRPCURL()
+	if err != nil || cancel {
+		return err
+	}
+	proposal, err := loadSafeProposal(rpcURL, args[0])
+	if err != nil {
+		return err
+	}
+	owners, err := contract.GetSafeOwners(rpcURL, proposal.Safe)
+	if err != nil {
+		return err
+	}
+	for _, sig := range proposal.Signatures {
+		if !utils.Belongs(owners, sig.Signer) {
+			return fmt.Errorf("proposal is signed by %s, that is not an owner of Safe %s", sig.Signer.Hex(), proposal.Safe.Hex())
+		}
+	}
+	threshold, err := contract.GetSafeThreshold(rpcURL, proposal.Safe)
+	if err != nil {
+		return err
+	}
+	if uint64(len(proposal.Signatures)) < threshold {
+		return fmt.Errorf("proposal has %d signatures, but Safe %s requires %d", len(proposal.Signatures), proposal.Safe.Hex(), threshold)
+	}
+	nonce, err := contract.GetSafeNonce(rpcURL, proposal.Safe)
+	if err != nil {
+		return err
+	}
+	if nonce.Cmp(proposal.Transaction.Nonce) != 0 {
+		return fmt.Errorf("proposal has nonce %s, but Safe %s current nonce is %s", proposal.Transaction.Nonce, proposal.Safe.Hex(), nonce)
+	}
+	privateKey, _, err := getSignerPrivateKey(
+		network,
+		safeFlags.chainFlags,
+		safeFlags.PrivateKeyFlags,
+		"pay for the Safe tx execution",
+	)
+	if err != nil {
+		return err
+	}
+	tx, _, err := contract.ExecuteSafeTransaction(rpcURL, privateKey, proposal)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Safe tx %s executed on tx %s", proposal.Hash.Hex(), tx.Hash().Hex())
+	return nil
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contractcmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/spf13/cobra"
+)
+
+type UpgradeFlags struct {
+	Network           networkoptions.NetworkFlags
+	PrivateKeyFlags   contract.PrivateKeyFlags
+	chainFlags        contract.ChainFlags
+	constructorEsp    string
+	call              string
+	callArgs          []string
+	referenceArtifact string
+	skipStorageCheck  bool
+}
+
+var (
+	upgradeSupportedNetworkOptions = []networkoptions.NetworkOption{
+		networkoptions.Local,
+		networkoptions.Devnet,
+		networkoptions.Fuji,
+		networkoptions.Mainnet,
+	}
+	upgradeFlags UpgradeFlags
+)
+
+// avalanche contract upgrade
+func newUpgradeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade [proxyAddress] [newBytecodePath] [constructorArgs]",
+		Short: "Upgrade an ERC1967 proxy to a new implementation",
+		Long: `Deploys a new implementation contract, and upgrades the given ERC1967 proxy to it.
+
+Both transparent proxies (upgraded through their ProxyAdmin, so the key used must be its owner)
+and UUPS proxies (upgraded through the implementation, so the key used must be authorized by it)
+are supported, as the ones deployed with avalanche contract deploy bytecode --proxy.
+
+The new bytecode path can point either to an hex encoded bytecode file or to a Foundry artifact.
+Implementation constructor params, if any, are described with --constructor and given as
+positional arguments after the bytecode path. --call (eg "reinitialize(uint256)") and --call-args
+describe a method to be called on the proxy as part of the upgrade.
+
+If both the new artifact and --reference-artifact (the artifact of the current implementation)
+include the storage layout (forge build --extra-output storageLayout), the upgrade is only done
+if the new layout is compatible with the current one.`,
+		RunE: upgrade,
+		Args: cobrautils.MinimumNArgs(2),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &upgradeFlags.Network, true, upgradeSupportedNetworkOptions)
+	contract.AddPrivateKeyFlagsToCmd(cmd, &upgradeFlags.PrivateKeyFlags, "as upgrader")
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&upgradeFlags.chainFlags,
+		"upgrade the contract",
+		"",
+		"",
+	)
+	cmd.Flags().StringVar(&upgradeFlags.constructorEsp, "constructor", "", "new implementation constructor params types (eg \"(address, uint256)\")")
+	cmd.Flags().StringVar(&upgradeFlags.call, "call", "", "method to call on the proxy as part of the upgrade (eg \"reinitialize(uint256)\")")
+	cmd.Flags().StringArrayVar(&upgradeFlags.callArgs, "call-args", nil, "args for the --call method (one flag per arg)")
+	cmd.Flags().StringVar(&upgradeFlags.referenceArtifact, "reference-artifact", "", "foundry artifact of the current implementation, to check storage layout compatibility")
+	cmd.Flags().BoolVar(&upgradeFlags.skipStorageCheck, "unsafe-skip-storage-check", false, "upgrade even if the storage layouts are not compatible")
+	return cmd
+}
+
+// checks that the storage layout of [newArtifactPath] is compatible with the one
+// of --reference-artifact, when both are available
+func checkUpgradeStorageLayout(newArtifactPath string) error {
+	if upgradeFlags.referenceArtifact == "" {
+		ux.Logger.PrintToUser("No --reference-artifact given: skipping storage layout check")
+		return nil
+	}
+	current, err := contract.LoadFoundryArtifact(upgradeFlags.referenceArtifact)
+	if err != nil {
+		return err
+	}
+	next, err := contract.LoadFoundryArtifact(newArtifactPath)
+	if err != nil {
+		return err
+	}
+	if current.StorageLayout == nil || next.StorageLayout == nil {
+		ux.Logger.PrintToUser("Artifacts do not include storage layouts: skipping storage layout check")
+		return nil
+	}
+	problems, err := contract.CheckStorageLayoutUpgrade(current.StorageLayout, next.StorageLayout)
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		ux.Logger.PrintToUser("Storage layout check passed")
+		return nil
+	}
+	for _, problem := range problems {
+		ux.Logger.RedXToUser("%s", problem)
+	}
+	if upgradeFlags.skipStorageCheck {
+		ux.Logger.PrintToUser("Storage layouts are not compatible. Upgrading anyway because of --unsafe-skip-storage-check")
+		return nil
+	}
+	return fmt.Errorf("new implementation storage layout is not compatible with the current one")
+}
+
+func upgrade(_ *cobra.Command, args []string) error {
+	if err := prompts.ValidateAddress(args[0]); err != nil {
+		return fmt.Errorf("failure validating address %s: %w", args[0], err)
+	}
+	proxy := common.HexToAddress(args[0])
+	newBytecodePath := args[1]
+	binBytes, err := contract.LoadContractBytecode(newBytecodePath)
+	if err != nil {
+		return err
+	}
+	constructorEsp := upgradeFlags.constructorEsp
+	if constructorEsp == "" {
+		constructorEsp = "()"
+	}
+	params, err := contract.ParseEspValues(constructorEsp, args[2:])
+	if err != nil {
+		return err
+	}
+	callData, err := getMethodCallData(upgradeFlags.call, upgradeFlags.callArgs)
+	if err != nil {
+		return err
+	}
+	if err := checkUpgradeStorageLayout(newBytecodePath); err != nil {
+		return err
+	}
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		upgradeFlags.Network,
+		true,
+		false,
+		upgradeSupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	cancel, err := promptChain(
+		network,
+		&upgradeFlags.chainFlags,
+		"Which blockchain is the contract on?",
+	)
+	if err != nil {
+		return err
+	}
+	if cancel {
+		return nil
+	}
+	rpcURL, err := contract.GetRPCURL(
+		app,
+		network,
+		upgradeFlags.chainFlags.SubnetName,
+		upgradeFlags.chainFlags.CChain,
+	)
+	if err != nil {
+		return err
+	}
+	kind, current, err := contract.GetProxyInfo(rpcURL, proxy)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Proxy %s is a %s proxy with implementation %s", proxy.Hex(), kind, current.Implementation.Hex())
+	privateKey, _, err := getSignerPrivateKey(
+		network,
+		upgradeFlags.chainFlags,
+		upgradeFlags.PrivateKeyFlags,
+		"upgrade the contract",
+		"A private key is needed to deploy the new implementation and upgrade the proxy.",
+		"For transparent proxies it must be the ProxyAdmin owner.",
+	)
+	if err != nil {
+		return err
+	}
+	implementation, err := contract.DeployContract(
+		rpcURL,
+		privateKey,
+		binBytes,
+		constructorEsp,
+		params...,
+	)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("New Implementation Address: %s", implementation.Hex())
+	if err := contract.UpgradeProxy(rpcURL, privateKey, proxy, implementation, callData); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Contract Successfully Upgraded!")
+	return nil
+}
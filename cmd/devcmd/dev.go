@@ -0,0 +1,69 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package devcmd
+
+import (
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	app          *application.Avalanche
+	manifestPath string
+)
+
+// avalanche dev
+func NewCmd(injectedApp *application.Avalanche) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Bring up a local development environment from a workspace manifest",
+		Long: `The dev command suite manages a local development environment described by a
+workspace manifest (avalanche.yaml), that lists the blockchains to deploy into the
+local network, the keys to fund on them, the contracts to deploy, and the interchain
+token routes between them. Eg:
+
+  blockchains:
+    - name: alpha
+      chainId: 1001
+      tokenSymbol: ALP
+    - name: beta
+      genesis: ./genesis/beta.json
+      tokenSymbol: BET
+  keys:
+    - name: alice
+      balances:
+        alpha: 100
+        c-chain: 10
+  contracts:
+    - name: counter
+      blockchain: alpha
+      bytecode: ./out/Counter.sol/Counter.json
+      constructor: "(uint256)"
+      args: ["1"]
+  routes:
+    - home: alpha
+      remote: beta
+      token: native
+
+Blockchains are Subnet-EVM ones, created either with the test defaults and the given
+chainId, or from the given genesis. Teleporter is enabled unless teleporter: false is set.
+Balances are minimums, in native token units, on a manifest blockchain or c-chain.
+Contracts are deployed through the CREATE2 factory, so they get the same address on
+every run. Routes deploy a Token Transferrer for the home native token, or for the
+ERC20 token address given as token, optionally as a native token on the remote
+(remoteNative: true).`,
+		RunE: cobrautils.CommandSuiteUsage,
+		Args: cobrautils.ExactArgs(0),
+	}
+	app = injectedApp
+	cmd.PersistentFlags().StringVarP(&manifestPath, "file", "f", workspace.ManifestFileName, "workspace manifest path")
+	// dev up
+	cmd.AddCommand(newUpCmd())
+	// dev down
+	cmd.AddCommand(newDownCmd())
+	// dev status
+	cmd.AddCommand(newStatusCmd())
+	return cmd
+}
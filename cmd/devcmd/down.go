@@ -0,0 +1,64 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package devcmd
+
+import (
+	"os"
+
+	"github.com/ava-labs/avalanche-cli/cmd/blockchaincmd"
+	"github.com/ava-labs/avalanche-cli/cmd/networkcmd"
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var purge bool
+
+// avalanche dev down
+func newDownCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "down",
+		Short: "Tear down the local development environment",
+		Long: `The dev down command stops the local network and deletes its state, as network
+clean does, so the next avalanche dev up deploys everything again.
+
+Blockchain configurations and keys of the manifest are kept, unless --purge is given.`,
+		RunE: down,
+		Args: cobrautils.ExactArgs(0),
+	}
+	cmd.Flags().BoolVar(&purge, "purge", false, "also delete the blockchain configurations and keys of the manifest")
+	return cmd
+}
+
+func down(*cobra.Command, []string) error {
+	m, err := workspace.LoadManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	if err := networkcmd.CallClean(false); err != nil {
+		return err
+	}
+	if !purge {
+		return nil
+	}
+	for _, blockchain := range m.Blockchains {
+		if !app.BlockchainConfigExists(blockchain.Name) {
+			continue
+		}
+		if err := blockchaincmd.CallDelete(blockchain.Name); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Blockchain %s deleted", blockchain.Name)
+	}
+	for _, k := range m.Keys {
+		if !app.KeyExists(k.Name) {
+			continue
+		}
+		if err := os.Remove(app.GetKeyPath(k.Name)); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Key %s deleted", k.Name)
+	}
+	return nil
+}
@@ -0,0 +1,287 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package devcmd
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/ictt"
+	"github.com/ava-labs/avalanche-cli/pkg/key"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/workspace"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func getChainFlags(chain string) contract.ChainFlags {
+	if workspace.IsCChain(chain) {
+		return contract.ChainFlags{CChain: true}
+	}
+	return contract.ChainFlags{SubnetName: chain}
+}
+
+func getRPCURL(network models.Network, chain string) (string, error) {
+	chainFlags := getChainFlags(chain)
+	return contract.GetRPCURL(app, network, chainFlags.SubnetName, chainFlags.CChain)
+}
+
+func getBlockchainID(network models.Network, chain string) (ids.ID, error) {
+	if workspace.IsCChain(chain) {
+		return utils.GetChainID(network.Endpoint, "C")
+	}
+	sc, err := app.LoadSidecar(chain)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return sc.Networks[network.Name()].BlockchainID, nil
+}
+
+// returns the private key of the account prefunded at [chain] genesis, used to fund the
+// manifest keys and to deploy its contracts
+func getFunderPrivateKey(network models.Network, chain string) (string, error) {
+	if workspace.IsCChain(chain) {
+		k, err := key.LoadEwoq(network.ID)
+		if err != nil {
+			return "", err
+		}
+		return k.PrivKeyHex(), nil
+	}
+	_, privateKey, err := contract.GetEVMSubnetPrefundedKey(app, network, chain, false, "")
+	if err != nil {
+		return "", err
+	}
+	if privateKey == "" {
+		return "", fmt.Errorf("no prefunded key found at %s genesis", chain)
+	}
+	return privateKey, nil
+}
+
+// CREATE2 deploy params of a manifest contract
+type contractDeploy struct {
+	binBytes       []byte
+	constructorEsp string
+	params         []interface{}
+	salt           common.Hash
+	address        common.Address
+}
+
+func getContractDeploy(m *workspace.Manifest, spec workspace.ContractSpec) (contractDeploy, error) {
+	binBytes, err := contract.LoadContractBytecode(m.ResolvePath(spec.Bytecode))
+	if err != nil {
+		return contractDeploy{}, err
+	}
+	constructorEsp := spec.Constructor
+	if constructorEsp == "" {
+		constructorEsp = "()"
+	}
+	params, err := contract.ParseEspValues(constructorEsp, spec.Args)
+	if err != nil {
+		return contractDeploy{}, fmt.Errorf("contract %s: %w", spec.Name, err)
+	}
+	initCode, err := contract.GetCreate2InitCode(binBytes, constructorEsp, params...)
+	if err != nil {
+		return contractDeploy{}, fmt.Errorf("contract %s: %w", spec.Name, err)
+	}
+	salt := contract.GetCreate2Salt(spec.GetSalt())
+	return contractDeploy{
+		binBytes:       binBytes,
+		constructorEsp: constructorEsp,
+		params:         params,
+		salt:           salt,
+		address:        contract.GetCreate2Address(salt, initCode),
+	}, nil
+}
+
+func codeIsDeployed(rpcURL string, address string) (bool, error) {
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+	return evm.ContractAlreadyDeployed(client, address)
+}
+
+func getBalance(rpcURL string, address string) (*big.Int, error) {
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return evm.GetAddressBalance(client, address)
+}
+
+// gets the configuration [blockchainName] was created with
+func getBlockchainConfig(blockchainName string) (workspace.BlockchainConfig, error) {
+	sc, err := app.LoadSidecar(blockchainName)
+	if err != nil {
+		return workspace.BlockchainConfig{}, err
+	}
+	genesisBytes, err := app.LoadRawGenesis(blockchainName)
+	if err != nil {
+		return workspace.BlockchainConfig{}, err
+	}
+	genesis, err := app.LoadEvmGenesis(blockchainName)
+	if err != nil {
+		return workspace.BlockchainConfig{}, err
+	}
+	config := workspace.BlockchainConfig{
+		TokenSymbol: sc.TokenSymbol,
+		VMVersion:   sc.VMVersion,
+		Teleporter:  sc.TeleporterReady,
+		Genesis:     genesisBytes,
+	}
+	if genesis.Config != nil && genesis.Config.ChainID != nil {
+		config.ChainID = genesis.Config.ChainID.Uint64()
+	}
+	return config, nil
+}
+
+// tells if a Token Transferrer for [route] is registered and has its remote deployed
+func routeIsDeployed(network models.Network, deployments []models.ICTTDeployment, route workspace.RouteSpec) (bool, error) {
+	homeBlockchainID, err := getBlockchainID(network, route.Home)
+	if err != nil {
+		return false, err
+	}
+	remoteBlockchainID, err := getBlockchainID(network, route.Remote)
+	if err != nil {
+		return false, err
+	}
+	homeKind, remoteKind := ictt.ERC20TokenHome, ictt.ERC20TokenRemote
+	if route.IsNative() {
+		homeKind = ictt.NativeTokenHome
+	}
+	if route.RemoteNative {
+		remoteKind = ictt.NativeTokenRemote
+	}
+	for _, deployment := range deployments {
+		if deployment.Home.BlockchainID != homeBlockchainID || deployment.Home.Kind != homeKind.String() {
+			continue
+		}
+		if !route.IsNative() && common.HexToAddress(deployment.TokenAddress) != common.HexToAddress(route.Token) {
+			continue
+		}
+		remote, ok := deployment.GetEndpoint(remoteBlockchainID)
+		if !ok || remote.Kind != remoteKind.String() {
+			continue
+		}
+		// the registry outlives the local network, so check the remote is still there
+		rpcURL, err := getRPCURL(network, route.Remote)
+		if err != nil {
+			return false, err
+		}
+		return codeIsDeployed(rpcURL, remote.Address)
+	}
+	return false, nil
+}
+
+// observes the state of the resources of [m] on the local network
+func getState(m *workspace.Manifest) (workspace.State, error) {
+	state := workspace.State{
+		Blockchains: map[string]workspace.BlockchainState{},
+		Keys:        map[string]bool{},
+		Balances:    map[string]map[string]*big.Int{},
+		Contracts:   map[string]bool{},
+		Routes:      map[string]bool{},
+	}
+	network := models.NewLocalNetwork()
+	clusterInfo, err := localnet.GetClusterInfo()
+	state.NetworkRunning = err == nil && clusterInfo != nil
+	for _, blockchain := range m.Blockchains {
+		blockchainState := workspace.BlockchainState{
+			Created: app.BlockchainConfigExists(blockchain.Name),
+		}
+		if blockchainState.Created {
+			config, err := getBlockchainConfig(blockchain.Name)
+			if err != nil {
+				return state, err
+			}
+			blockchainState.ConfigDrift, err = m.GetConfigDrift(blockchain, config)
+			if err != nil {
+				return state, err
+			}
+		}
+		if blockchainState.Created && state.NetworkRunning {
+			blockchainID, err := getBlockchainID(network, blockchain.Name)
+			if err != nil {
+				return state, err
+			}
+			_, blockchainState.Deployed = clusterInfo.GetCustomChains()[blockchainID.String()]
+		}
+		state.Blockchains[blockchain.Name] = blockchainState
+	}
+	for _, k := range m.Keys {
+		state.Keys[k.Name] = app.KeyExists(k.Name)
+	}
+	if !state.NetworkRunning {
+		return state, nil
+	}
+	state.RelayerRunning, _, _, err = teleporter.RelayerIsUp(app.GetAWMRelayerRunPath())
+	if err != nil {
+		return state, err
+	}
+	// chains that can be queried
+	isAvailable := func(chain string) bool {
+		return workspace.IsCChain(chain) || state.Blockchains[chain].Deployed
+	}
+	for _, k := range m.Keys {
+		if !state.Keys[k.Name] {
+			continue
+		}
+		sk, err := app.GetKey(k.Name, network, false)
+		if err != nil {
+			return state, err
+		}
+		state.Balances[k.Name] = map[string]*big.Int{}
+		for chain := range k.Balances {
+			if !isAvailable(chain) {
+				continue
+			}
+			rpcURL, err := getRPCURL(network, chain)
+			if err != nil {
+				return state, err
+			}
+			balance, err := getBalance(rpcURL, sk.C())
+			if err != nil {
+				return state, err
+			}
+			state.Balances[k.Name][chain] = balance
+		}
+	}
+	for _, spec := range m.Contracts {
+		if !isAvailable(spec.Blockchain) {
+			continue
+		}
+		deploy, err := getContractDeploy(m, spec)
+		if err != nil {
+			return state, err
+		}
+		rpcURL, err := getRPCURL(network, spec.Blockchain)
+		if err != nil {
+			return state, err
+		}
+		state.Contracts[spec.Name], err = codeIsDeployed(rpcURL, deploy.address.Hex())
+		if err != nil {
+			return state, err
+		}
+	}
+	deployments, err := ictt.GetDeployments(app, network)
+	if err != nil {
+		return state, err
+	}
+	for _, route := range m.Routes {
+		if !isAvailable(route.Home) || !isAvailable(route.Remote) {
+			continue
+		}
+		state.Routes[route.GetName()], err = routeIsDeployed(network, deployments, route)
+		if err != nil {
+			return state, err
+		}
+	}
+	return state, nil
+}
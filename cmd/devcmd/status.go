@@ -0,0 +1,50 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package devcmd
+
+import (
+	"os"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/workspace"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// avalanche dev status
+func newStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the drift between the local network and the workspace manifest",
+		Long: `The dev status command compares the local network with the workspace manifest, and
+lists what avalanche dev up would need to create, deploy, fund or start.`,
+		RunE: status,
+		Args: cobrautils.ExactArgs(0),
+	}
+}
+
+func status(*cobra.Command, []string) error {
+	m, err := workspace.LoadManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	state, err := getState(m)
+	if err != nil {
+		return err
+	}
+	drift := m.GetDrift(state)
+	if len(drift) == 0 {
+		ux.Logger.GreenCheckmarkToUser("Local network is up to date with %s", manifestPath)
+		return nil
+	}
+	ux.Logger.PrintToUser("Local network differs from %s:", manifestPath)
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Resource", "Name", "Drift"})
+	for _, d := range drift {
+		table.Append([]string{d.Resource, d.Name, d.Issue})
+	}
+	table.Render()
+	ux.Logger.PrintToUser("Run avalanche dev up to reconcile it")
+	return nil
+}
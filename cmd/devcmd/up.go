@@ -0,0 +1,287 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package devcmd
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/cmd/blockchaincmd"
+	"github.com/ava-labs/avalanche-cli/cmd/interchaincmd/tokentransferrercmd"
+	"github.com/ava-labs/avalanche-cli/cmd/networkcmd"
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/ictt"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-cli/pkg/workspace"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+)
+
+var (
+	avagoVersion string
+	recreate     bool
+)
+
+// avalanche dev up
+func newUpCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "up",
+		Short: "Reconcile the local network with the workspace manifest",
+		Long: `The dev up command brings the local network to the state described by the
+workspace manifest. It starts the local network, creates and deploys the
+blockchains, along with Teleporter and the relayer, creates and funds the keys,
+deploys the contracts, and deploys the Token Transferrers of the routes.
+
+Only what is missing is done, so running it again after editing the manifest, or
+after the network was stopped, only applies the differences.
+
+If the configuration of an existing blockchain differs from the manifest, eg its
+chain ID or genesis was edited, dev up fails, unless --recreate is given. In that
+case the blockchain configuration is created again from the manifest and deployed
+as a new blockchain.`,
+		RunE: up,
+		Args: cobrautils.ExactArgs(0),
+	}
+	cmd.Flags().StringVar(&avagoVersion, "avalanchego-version", "latest", "use this version of avalanchego when starting the local network (ex: v1.17.12)")
+	cmd.Flags().BoolVar(&recreate, "recreate", false, "recreate the blockchains whose configuration differs from the manifest. run avalanche dev down first to also remove their previous deployments from the local network")
+	return cmd
+}
+
+func up(cmd *cobra.Command, _ []string) error {
+	m, err := workspace.LoadManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	network := models.NewLocalNetwork()
+	state, err := getState(m)
+	if err != nil {
+		return err
+	}
+	if len(m.GetDrift(state)) == 0 {
+		ux.Logger.PrintToUser("Local network is already up to date with %s", manifestPath)
+		return nil
+	}
+	if err := checkRouteArtifacts(m, state); err != nil {
+		return err
+	}
+	for _, blockchain := range m.Blockchains {
+		blockchainState := state.Blockchains[blockchain.Name]
+		if len(blockchainState.ConfigDrift) > 0 {
+			if !recreate {
+				return fmt.Errorf(
+					"configuration of blockchain %s differs from %s: %s. use --recreate to create it again",
+					blockchain.Name,
+					manifestPath,
+					strings.Join(blockchainState.ConfigDrift, ", "),
+				)
+			}
+			ux.Logger.PrintToUser("Deleting blockchain %s, whose configuration differs from the manifest", blockchain.Name)
+			if err := blockchaincmd.CallDelete(blockchain.Name); err != nil {
+				return err
+			}
+		} else if blockchainState.Created {
+			continue
+		}
+		ux.Logger.PrintToUser("Creating blockchain %s", blockchain.Name)
+		if err := blockchaincmd.CallCreateEvm(
+			cmd,
+			blockchain.Name,
+			false,
+			m.ResolvePath(blockchain.Genesis),
+			blockchain.VMVersion,
+			blockchain.ChainID,
+			blockchain.TokenSymbol,
+			blockchain.UseTeleporter(),
+		); err != nil {
+			return fmt.Errorf("failure creating blockchain %s: %w", blockchain.Name, err)
+		}
+	}
+	for _, k := range m.Keys {
+		if state.Keys[k.Name] {
+			continue
+		}
+		ux.Logger.PrintToUser("Creating key %s", k.Name)
+		if _, err := app.GetKey(k.Name, network, true); err != nil {
+			return err
+		}
+	}
+	if !state.NetworkRunning {
+		if err := networkcmd.CallStartNetwork(avagoVersion); err != nil {
+			return err
+		}
+	}
+	if state, err = getState(m); err != nil {
+		return err
+	}
+	for _, blockchain := range m.Blockchains {
+		if state.Blockchains[blockchain.Name].Deployed {
+			continue
+		}
+		if err := blockchaincmd.CallDeployLocal(cmd, blockchain.Name); err != nil {
+			return fmt.Errorf("failure deploying blockchain %s: %w", blockchain.Name, err)
+		}
+	}
+	if state, err = getState(m); err != nil {
+		return err
+	}
+	if m.UsesTeleporter() && !state.RelayerRunning {
+		if err := startRelayer(); err != nil {
+			return err
+		}
+	}
+	for _, k := range m.Keys {
+		if err := fundKey(network, k, state.Balances[k.Name]); err != nil {
+			return err
+		}
+	}
+	for _, spec := range m.Contracts {
+		if state.Contracts[spec.Name] {
+			continue
+		}
+		if err := deployContract(network, m, spec); err != nil {
+			return err
+		}
+	}
+	for _, route := range m.Routes {
+		if state.Routes[route.GetName()] {
+			continue
+		}
+		ux.Logger.PrintToUser("Deploying route %s from %s to %s", route.GetName(), route.Home, route.Remote)
+		erc20Address := ""
+		if !route.IsNative() {
+			erc20Address = route.Token
+		}
+		if err := tokentransferrercmd.CallDeployLocal(
+			route.GetName(),
+			getChainFlags(route.Home),
+			erc20Address,
+			getChainFlags(route.Remote),
+			route.RemoteNative,
+		); err != nil {
+			return fmt.Errorf("failure deploying route %s: %w", route.GetName(), err)
+		}
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.GreenCheckmarkToUser("Local network is up to date with %s", manifestPath)
+	return nil
+}
+
+// routes are deployed with the ICTT contracts prebuilt into CLI. if they are not
+// available, the routes are deployed by building the contracts with Foundry, so
+// warn about it before changing anything. invalid prebuilt contracts are an error
+func checkRouteArtifacts(m *workspace.Manifest, state workspace.State) error {
+	pendingRoutes := false
+	for _, route := range m.Routes {
+		if !state.Routes[route.GetName()] {
+			pendingRoutes = true
+		}
+	}
+	if !pendingRoutes {
+		return nil
+	}
+	_, err := ictt.GetEmbeddedArtifacts(constants.ICTTVersion)
+	if errors.Is(err, ictt.ErrArtifactsNotEmbedded) {
+		ux.Logger.PrintToUser("No prebuilt Avalanche InterChain Token Transfer contracts for %s. Routes will be deployed building them with Foundry", constants.ICTTVersion)
+		return nil
+	}
+	return err
+}
+
+func startRelayer() error {
+	found, relayerConfigPath, err := subnet.GetAWMRelayerConfigPath()
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("relayer configuration not found for the local network")
+	}
+	ux.Logger.PrintToUser("Starting relayer")
+	return teleporter.DeployRelayer(
+		app.GetAWMRelayerBinDir(),
+		relayerConfigPath,
+		app.GetAWMRelayerLogPath(),
+		app.GetAWMRelayerRunPath(),
+		app.GetAWMRelayerStorageDir(),
+	)
+}
+
+// tops up the balances of [k] that are below the manifest ones
+func fundKey(network models.Network, k workspace.KeySpec, balances map[string]*big.Int) error {
+	sk, err := app.GetKey(k.Name, network, false)
+	if err != nil {
+		return err
+	}
+	chains := maps.Keys(k.Balances)
+	sort.Strings(chains)
+	for _, chain := range chains {
+		expected := k.Balances[chain].Wei
+		balance := balances[chain]
+		if balance == nil {
+			balance = big.NewInt(0)
+		}
+		if balance.Cmp(expected) >= 0 {
+			continue
+		}
+		toFund := new(big.Int).Sub(expected, balance)
+		ux.Logger.PrintToUser("Funding key %s with %s on %s", k.Name, workspace.FormatWei(toFund), chain)
+		rpcURL, err := getRPCURL(network, chain)
+		if err != nil {
+			return err
+		}
+		privateKey, err := getFunderPrivateKey(network, chain)
+		if err != nil {
+			return err
+		}
+		client, err := evm.GetClient(rpcURL)
+		if err != nil {
+			return err
+		}
+		err = evm.FundAddress(client, privateKey, sk.C(), toFund)
+		client.Close()
+		if err != nil {
+			return fmt.Errorf("failure funding key %s on %s: %w", k.Name, chain, err)
+		}
+	}
+	return nil
+}
+
+func deployContract(network models.Network, m *workspace.Manifest, spec workspace.ContractSpec) error {
+	deploy, err := getContractDeploy(m, spec)
+	if err != nil {
+		return err
+	}
+	rpcURL, err := getRPCURL(network, spec.Blockchain)
+	if err != nil {
+		return err
+	}
+	privateKey, err := getFunderPrivateKey(network, spec.Blockchain)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Deploying contract %s on %s", spec.Name, spec.Blockchain)
+	if _, err := contract.DeployCreate2Factory(rpcURL, privateKey); err != nil {
+		return fmt.Errorf("failure deploying the CREATE2 factory on %s: %w", spec.Blockchain, err)
+	}
+	address, _, err := contract.DeployContractCreate2(
+		rpcURL,
+		privateKey,
+		deploy.salt,
+		deploy.binBytes,
+		deploy.constructorEsp,
+		deploy.params...,
+	)
+	if err != nil {
+		return fmt.Errorf("failure deploying contract %s: %w", spec.Name, err)
+	}
+	ux.Logger.PrintToUser("Contract %s deployed at %s", spec.Name, address.Hex())
+	return nil
+}
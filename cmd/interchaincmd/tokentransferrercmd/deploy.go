@@ -4,6 +4,7 @@ package tokentransferrercmd
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/contract"
 	"github.com/ava-labs/avalanche-cli/pkg/ictt"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
 	"github.com/ava-labs/avalanche-cli/pkg/precompiles"
 	"github.com/ava-labs/avalanche-cli/pkg/prompts"
@@ -38,10 +40,12 @@ type RemoteFlags struct {
 }
 
 type DeployFlags struct {
-	Network     networkoptions.NetworkFlags
-	homeFlags   HomeFlags
-	remoteFlags RemoteFlags
-	version     string
+	Network         networkoptions.NetworkFlags
+	homeFlags       HomeFlags
+	remoteFlags     RemoteFlags
+	version         string
+	name            string
+	buildFromSource bool
 }
 
 var (
@@ -78,11 +82,13 @@ func NewDeployCmd() *cobra.Command {
 		"c-chain-remote",
 	)
 	cmd.Flags().BoolVar(&deployFlags.homeFlags.native, "deploy-native-home", false, "deploy a Transferrer Home for the Chain's Native Token")
-	cmd.Flags().StringVar(&deployFlags.homeFlags.erc20Address, "deploy-erc20-home", "", "deploy a Transferrer Home for the given Chain's ERC20 Token")
+	cmd.Flags().StringVar(&deployFlags.homeFlags.erc20Address, "deploy-erc20-home", "", "deploy a Transferrer Home for the given Chain's ERC20 Token (address, or name of a CLI deployed token)")
 	cmd.Flags().StringVar(&deployFlags.homeFlags.homeAddress, "use-home", "", "use the given Transferrer's Home Address")
-	cmd.Flags().StringVar(&deployFlags.version, "version", "", "tag/branch/commit of Avalanche InterChain Token Transfer to be used (defaults to main branch)")
+	cmd.Flags().StringVar(&deployFlags.version, "version", "", "version of Avalanche InterChain Token Transfer to be used. prebuilt contracts are used for supported releases, otherwise it is built from the given tag/branch/commit")
 	cmd.Flags().BoolVar(&deployFlags.remoteFlags.native, "deploy-native-remote", false, "deploy a Transferrer Remote for the Chain's Native Token")
 	cmd.Flags().BoolVar(&deployFlags.remoteFlags.removeMinterAdmin, "remove-minter-admin", true, "remove the native minter precompile admin found on remote blockchain genesis")
+	cmd.Flags().BoolVar(&deployFlags.buildFromSource, "build-from-source", false, "download and compile the contracts with foundry, instead of using the prebuilt ones")
+	cmd.Flags().StringVar(&deployFlags.name, "name", "", "name to register the Transferrer with (defaults to the token symbol)")
 	return cmd
 }
 
@@ -90,12 +96,34 @@ func deploy(_ *cobra.Command, args []string) error {
 	return CallDeploy(args, deployFlags)
 }
 
+// CallDeployLocal deploys, without prompting, a Transferrer named [name] into the local
+// network, from [home] to [remote]. The home is deployed for [erc20Address] if given, or
+// for the home native token otherwise. The remote is deployed as a native token remote
+// if [remoteNative] is set, or as an ERC20 remote otherwise
+func CallDeployLocal(
+	name string,
+	home contract.ChainFlags,
+	erc20Address string,
+	remote contract.ChainFlags,
+	remoteNative bool,
+) error {
+	return CallDeploy(nil, DeployFlags{
+		Network: networkoptions.NetworkFlags{UseLocal: true},
+		homeFlags: HomeFlags{
+			chainFlags:   home,
+			native:       erc20Address == "",
+			erc20Address: erc20Address,
+		},
+		remoteFlags: RemoteFlags{
+			chainFlags:        remote,
+			native:            remoteNative,
+			removeMinterAdmin: true,
+		},
+		name: name,
+	})
+}
+
 func CallDeploy(_ []string, flags DeployFlags) error {
-	if !ictt.FoundryIsInstalled() {
-		if err := ictt.InstallFoundry(); err != nil {
-			return err
-		}
-	}
 	network, err := networkoptions.GetNetworkFromCmdLineFlags(
 		app,
 		"On what Network do you want to deploy the Transferrer?",
@@ -219,9 +247,7 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 				case nativeOption:
 					flags.homeFlags.native = true
 				case erc20Option:
-					erc20TokenAddr, err := app.Prompt.CaptureAddress(
-						"Enter the address of the ERC-20 Token",
-					)
+					erc20TokenAddr, err := promptERC20TokenAddress(network, flags.homeFlags.chainFlags)
 					if err != nil {
 						return err
 					}
@@ -287,9 +313,18 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 		}
 	}
 	if flags.homeFlags.erc20Address != "" {
-		if err := prompts.ValidateAddress(flags.homeFlags.erc20Address); err != nil {
+		// the token can also be referred to by the name of a CLI deployed ERC20
+		tokenAddress, err := contract.GetERC20TokenAddress(
+			app,
+			network,
+			flags.homeFlags.chainFlags.SubnetName,
+			flags.homeFlags.chainFlags.CChain,
+			flags.homeFlags.erc20Address,
+		)
+		if err != nil {
 			return fmt.Errorf("failure validating %s: %w", flags.homeFlags.erc20Address, err)
 		}
+		flags.homeFlags.erc20Address = tokenAddress.Hex()
 	}
 
 	// Remote Chain Prompts
@@ -319,27 +354,19 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 	}
 
 	// Setup Contracts
-	ux.Logger.PrintToUser("Downloading Avalanche InterChain Token Transfer Contracts")
 	version := constants.ICTTVersion
 	if flags.version != "" {
 		version = flags.version
 	}
-	if err := ictt.DownloadRepo(app, version); err != nil {
-		return err
-	}
-	ux.Logger.PrintToUser("Compiling Avalanche InterChain Token Transfer")
-	if err := ictt.BuildContracts(app); err != nil {
+	artifacts, err := getArtifacts(version, flags.buildFromSource)
+	if err != nil {
 		return err
 	}
-	ux.Logger.PrintToUser("")
 
 	// Home Deploy
-	icttSrcDir, err := ictt.RepoDir(app)
-	if err != nil {
-		return err
-	}
 	var (
 		homeAddress   common.Address
+		homeKind      ictt.EndpointKind
 		tokenSymbol   string
 		tokenName     string
 		tokenDecimals uint8
@@ -357,11 +384,11 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 	}
 	if flags.homeFlags.homeAddress != "" {
 		homeAddress = common.HexToAddress(flags.homeFlags.homeAddress)
-		endpointKind, err := ictt.GetEndpointKind(homeEndpoint, homeAddress)
+		homeKind, err = ictt.GetEndpointKind(homeEndpoint, homeAddress)
 		if err != nil {
 			return err
 		}
-		switch endpointKind {
+		switch homeKind {
 		case ictt.ERC20TokenHome:
 			tokenAddress, err = ictt.ERC20TokenHomeGetTokenAddress(homeEndpoint, homeAddress)
 			if err != nil {
@@ -373,7 +400,7 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 				return err
 			}
 		default:
-			return fmt.Errorf("unsupported ictt endpoint kind %d", endpointKind)
+			return fmt.Errorf("unsupported ictt endpoint kind %d", homeKind)
 		}
 		tokenSymbol, tokenName, tokenDecimals, err = ictt.GetTokenParams(
 			homeEndpoint,
@@ -392,8 +419,9 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 		if err != nil {
 			return err
 		}
+		homeKind = ictt.ERC20TokenHome
 		homeAddress, err = ictt.DeployERC20Home(
-			icttSrcDir,
+			artifacts,
 			homeEndpoint,
 			homeKey.PrivKeyHex(),
 			common.HexToAddress(homeRegistryAddress),
@@ -417,7 +445,7 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 			return err
 		}
 		wrappedNativeTokenAddress, err := ictt.DeployWrappedNativeToken(
-			icttSrcDir,
+			artifacts,
 			homeEndpoint,
 			homeKey.PrivKeyHex(),
 			nativeTokenSymbol,
@@ -425,6 +453,7 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 		if err != nil {
 			return err
 		}
+		tokenAddress = wrappedNativeTokenAddress
 		tokenSymbol, tokenName, tokenDecimals, err = ictt.GetTokenParams(
 			homeEndpoint,
 			wrappedNativeTokenAddress.Hex(),
@@ -435,8 +464,9 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 		ux.Logger.PrintToUser("Wrapped Native Token Deployed to %s", homeEndpoint)
 		ux.Logger.PrintToUser("%s Address: %s", tokenSymbol, wrappedNativeTokenAddress)
 		ux.Logger.PrintToUser("")
+		homeKind = ictt.NativeTokenHome
 		homeAddress, err = ictt.DeployNativeHome(
-			icttSrcDir,
+			artifacts,
 			homeEndpoint,
 			homeKey.PrivKeyHex(),
 			common.HexToAddress(homeRegistryAddress),
@@ -469,7 +499,7 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 
 	if !flags.remoteFlags.native {
 		remoteAddress, err = ictt.DeployERC20Remote(
-			icttSrcDir,
+			artifacts,
 			remoteEndpoint,
 			remoteKey.PrivKeyHex(),
 			common.HexToAddress(remoteRegistryAddress),
@@ -502,7 +532,7 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 			return err
 		}
 		remoteAddress, err = ictt.DeployNativeRemote(
-			icttSrcDir,
+			artifacts,
 			remoteEndpoint,
 			remoteKey.PrivKeyHex(),
 			common.HexToAddress(remoteRegistryAddress),
@@ -602,7 +632,7 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 			return err
 		}
 
-		err = ictt.Send(
+		_, err = ictt.Send(
 			homeEndpoint,
 			homeAddress,
 			homeKey.PrivKeyHex(),
@@ -610,6 +640,7 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 			remoteAddress,
 			common.HexToAddress(homeKey.C()),
 			big.NewInt(1),
+			ictt.SendOptions{},
 		)
 		if err != nil {
 			return err
@@ -649,5 +680,73 @@ func CallDeploy(_ []string, flags DeployFlags) error {
 	ux.Logger.PrintToUser("Remote Deployed to %s", remoteEndpoint)
 	ux.Logger.PrintToUser("Remote Address: %s", remoteAddress)
 
+	remoteKind := ictt.ERC20TokenRemote
+	if flags.remoteFlags.native {
+		remoteKind = ictt.NativeTokenRemote
+	}
+	deployment, err := ictt.RecordDeployment(app, network, models.ICTTDeployment{
+		Name:          flags.name,
+		Version:       version,
+		TokenAddress:  tokenAddress.Hex(),
+		TokenName:     tokenName,
+		TokenSymbol:   tokenSymbol,
+		TokenDecimals: tokenDecimals,
+		Home: models.ICTTEndpoint{
+			Blockchain:   getChainName(flags.homeFlags.chainFlags),
+			BlockchainID: homeBlockchainID,
+			Address:      homeAddress.Hex(),
+			Kind:         homeKind.String(),
+		},
+		Remotes: []models.ICTTEndpoint{
+			{
+				Blockchain:   getChainName(flags.remoteFlags.chainFlags),
+				BlockchainID: remoteBlockchainID,
+				Address:      remoteAddress.Hex(),
+				Kind:         remoteKind.String(),
+				// deploy returns only after the remote is registered and collateralized
+				Collateralized: true,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Transferrer registered as %s", deployment.Name)
+
 	return nil
 }
+
+// gets the ICTT contracts for [version], prebuilt into CLI if available, or else
+// built from source with foundry
+func getArtifacts(version string, buildFromSource bool) (ictt.Artifacts, error) {
+	if !buildFromSource {
+		artifacts, err := ictt.GetEmbeddedArtifacts(version)
+		if err == nil {
+			return artifacts, nil
+		}
+		if !errors.Is(err, ictt.ErrArtifactsNotEmbedded) {
+			return ictt.Artifacts{}, err
+		}
+		ux.Logger.PrintToUser("No prebuilt Avalanche InterChain Token Transfer contracts for %s. Building them from source", version)
+	}
+	if !ictt.FoundryIsInstalled() {
+		if err := ictt.InstallFoundry(); err != nil {
+			return ictt.Artifacts{}, err
+		}
+	}
+	ux.Logger.PrintToUser("Downloading Avalanche InterChain Token Transfer Contracts")
+	if err := ictt.DownloadRepo(app, version); err != nil {
+		return ictt.Artifacts{}, err
+	}
+	ux.Logger.PrintToUser("Compiling Avalanche InterChain Token Transfer")
+	if err := ictt.BuildContracts(app); err != nil {
+		return ictt.Artifacts{}, err
+	}
+	ux.Logger.PrintToUser("")
+	icttSrcDir, err := ictt.RepoDir(app)
+	if err != nil {
+		return ictt.Artifacts{}, err
+	}
+	return ictt.GetSourceArtifacts(icttSrcDir), nil
+}
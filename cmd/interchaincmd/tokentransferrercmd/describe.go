@@ -0,0 +1,216 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package tokentransferrercmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/ictt"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+type DescribeFlags struct {
+	Network        networkoptions.NetworkFlags
+	homeChainFlags contract.ChainFlags
+	homeAddress    string
+}
+
+var describeFlags DescribeFlags
+
+// avalanche interchain tokenTransferrer describe
+func NewDescribeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "describe [name]",
+		Short: "Shows the details and live status of a Token Transferrer",
+		Long: `Shows the details and live status of a Token Transferrer, given by the name it was registered
+with on deploy, or by its home chain and address.
+
+The remotes registered on the home are discovered from its events, and for each one it shows
+its registration, collateralization, decimals scaling, and the supply bridged to it compared
+with the balance transferred by the home. The home locked balance is checked to back all
+transfers. Inconsistencies found are flagged.`,
+		RunE: describe,
+		Args: cobrautils.MaximumNArgs(1),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &describeFlags.Network, true, deploySupportedNetworkOptions)
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&describeFlags.homeChainFlags,
+		"set the Transferrer's Home Chain",
+		"home-subnet",
+		"c-chain-home",
+	)
+	cmd.Flags().StringVar(&describeFlags.homeAddress, "home-address", "", "address of the Transferrer's Home")
+	return cmd
+}
+
+func describe(_ *cobra.Command, args []string) error {
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"On what Network is the Transferrer deployed?",
+		describeFlags.Network,
+		true,
+		false,
+		deploySupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	var (
+		homeChainFlags = describeFlags.homeChainFlags
+		homeAddress    common.Address
+	)
+	if len(args) == 1 {
+		if homeChainFlags.CChain || homeChainFlags.SubnetName != "" || describeFlags.homeAddress != "" {
+			return fmt.Errorf("the home can't be given together with the transferrer name")
+		}
+		deployment, err := ictt.GetDeployment(app, network, args[0])
+		if err != nil {
+			return err
+		}
+		printDeployment(deployment)
+		homeChainFlags.CChain = deployment.Home.Blockchain == cChainName
+		if !homeChainFlags.CChain {
+			homeChainFlags.SubnetName = deployment.Home.Blockchain
+		}
+		homeAddress = common.HexToAddress(deployment.Home.Address)
+	} else {
+		if !homeChainFlags.CChain && homeChainFlags.SubnetName == "" {
+			if cancel, err := promptChain("Where is the Transferrer Home?", network, false, "", &homeChainFlags); err != nil {
+				return err
+			} else if cancel {
+				return nil
+			}
+		}
+		if describeFlags.homeAddress == "" {
+			homeAddress, err = app.Prompt.CaptureAddress("Enter the address of the Transferrer Home")
+			if err != nil {
+				return err
+			}
+		} else {
+			if err := prompts.ValidateAddress(describeFlags.homeAddress); err != nil {
+				return err
+			}
+			homeAddress = common.HexToAddress(describeFlags.homeAddress)
+		}
+	}
+	homeRPCURL, _, _, _, _, _, _, err := teleporter.GetSubnetParams(
+		app,
+		network,
+		homeChainFlags.SubnetName,
+		homeChainFlags.CChain,
+	)
+	if err != nil {
+		return err
+	}
+	status, err := ictt.GetHomeStatus(homeRPCURL, homeAddress, func(blockchainID ids.ID) string {
+		return network.BlockchainEndpoint(blockchainID.String())
+	})
+	if err != nil {
+		return err
+	}
+	printHomeStatus(network, getChainName(homeChainFlags), status)
+	return nil
+}
+
+// prints the details of [deployment] as recorded on the registry
+func printDeployment(deployment models.ICTTDeployment) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Append([]string{"Name", deployment.Name})
+	table.Append([]string{"Network", deployment.Network})
+	table.Append([]string{"Version", deployment.Version})
+	table.Append([]string{"Token", deployment.TokenName + " (" + deployment.TokenSymbol + ")"})
+	table.Append([]string{"Token Address", deployment.TokenAddress})
+	table.Append([]string{"Token Decimals", strconv.Itoa(int(deployment.TokenDecimals))})
+	table.Render()
+	ux.Logger.PrintToUser("")
+}
+
+func printHomeStatus(network models.Network, homeChainName string, status *ictt.HomeStatus) {
+	chainNames := getChainNames(network)
+	chainName := func(blockchainID ids.ID) string {
+		if name, ok := chainNames[blockchainID]; ok {
+			return name
+		}
+		return blockchainID.String()
+	}
+	ux.Logger.PrintToUser("%s at %s, on %s", status.Kind, status.Address, homeChainName)
+	ux.Logger.PrintToUser("Token %s at %s, %d decimals", status.TokenSymbol, status.TokenAddress, status.TokenDecimals)
+	ux.Logger.PrintToUser("Locked Balance: %s %s", formatTokenAmount(status.LockedBalance, status.TokenDecimals), status.TokenSymbol)
+	ux.Logger.PrintToUser("")
+	if len(status.Remotes) == 0 {
+		ux.Logger.PrintToUser("There are no remotes registered on the home")
+	} else {
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{
+			"Remote",
+			"Kind",
+			"Address",
+			"Registered",
+			"Collateralized",
+			"Scaling",
+			"Bridged Supply",
+			"Transferred by Home",
+		})
+		for _, remote := range status.Remotes {
+			scaling := fmt.Sprintf("%d decimals", remote.TokenDecimals)
+			if remote.TokenMultiplier != nil && remote.TokenMultiplier.Cmp(common.Big1) != 0 {
+				operation := "x"
+				if !remote.MultiplyOnRemote {
+					operation = "/"
+				}
+				scaling += fmt.Sprintf(" (%s%s)", operation, remote.TokenMultiplier)
+			}
+			bridgedSupply := "-"
+			if remote.BridgedSupply != nil {
+				bridgedSupply = formatTokenAmount(remote.BridgedSupply, remote.TokenDecimals)
+			}
+			transferred := "-"
+			if remote.TransferredBalance != nil {
+				transferred = formatTokenAmount(remote.TransferredBalance, status.TokenDecimals)
+			}
+			collateralized := strconv.FormatBool(remote.Collateralized)
+			if remote.CollateralNeeded != nil && remote.CollateralNeeded.Sign() > 0 {
+				collateralized = fmt.Sprintf("false (needs %s)", formatTokenAmount(remote.CollateralNeeded, status.TokenDecimals))
+			}
+			table.Append([]string{
+				chainName(remote.BlockchainID),
+				remote.Kind.String(),
+				remote.Address.Hex(),
+				strconv.FormatBool(remote.Registered),
+				collateralized,
+				scaling,
+				bridgedSupply,
+				transferred,
+			})
+		}
+		table.Render()
+	}
+	issues := false
+	for _, issue := range status.Issues {
+		ux.Logger.RedXToUser("Home: %s", issue)
+		issues = true
+	}
+	for _, remote := range status.Remotes {
+		for _, issue := range remote.Issues {
+			ux.Logger.RedXToUser("%s remote: %s", chainName(remote.BlockchainID), issue)
+			issues = true
+		}
+	}
+	if !issues {
+		ux.Logger.GreenCheckmarkToUser("No inconsistencies found")
+	}
+}
@@ -5,13 +5,19 @@ package tokentransferrercmd
 import (
 	_ "embed"
 	"fmt"
+	"math/big"
+	"strings"
 
 	"github.com/ava-labs/avalanche-cli/pkg/contract"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
 )
 
+const cChainName = "c-chain"
+
 func validateSubnet(network models.Network, subnetName string) error {
 	sc, err := app.LoadSidecar(subnetName)
 	if err != nil {
@@ -50,6 +56,14 @@ func promptChain(
 	return cancel, err
 }
 
+// returns the name of the chain given by [chainFlags], as used on the registry
+func getChainName(chainFlags contract.ChainFlags) string {
+	if chainFlags.CChain {
+		return cChainName
+	}
+	return chainFlags.SubnetName
+}
+
 func getNativeTokenSymbol(subnetName string, isCChain bool) (string, error) {
 	nativeTokenSymbol := "AVAX"
 	if !isCChain {
@@ -61,3 +75,74 @@ func getNativeTokenSymbol(subnetName string, isCChain bool) (string, error) {
 	}
 	return nativeTokenSymbol, nil
 }
+
+// prompts for an ERC20 token address, offering the tokens deployed by CLI
+// into the chain, if any
+func promptERC20TokenAddress(
+	network models.Network,
+	chainFlags contract.ChainFlags,
+) (common.Address, error) {
+	tokens := []models.ERC20Token{}
+	if !chainFlags.CChain && chainFlags.SubnetName != "" {
+		var err error
+		tokens, err = contract.GetERC20Tokens(app, network, chainFlags.SubnetName)
+		if err != nil {
+			return common.Address{}, err
+		}
+	}
+	if len(tokens) > 0 {
+		otherOption := "Other token"
+		options := utils.Map(tokens, func(t models.ERC20Token) string {
+			return fmt.Sprintf("%s (%s) at %s", t.Name, t.Symbol, t.Address)
+		})
+		options = append(options, otherOption)
+		option, err := app.Prompt.CaptureList("Choose the ERC-20 Token", options)
+		if err != nil {
+			return common.Address{}, err
+		}
+		for i, desc := range options[:len(tokens)] {
+			if option == desc {
+				return common.HexToAddress(tokens[i].Address), nil
+			}
+		}
+	}
+	return app.Prompt.CaptureAddress(
+		"Enter the address of the ERC-20 Token",
+	)
+}
+
+// returns the names of the blockchains of [network] known by CLI, by blockchain ID
+func getChainNames(network models.Network) map[ids.ID]string {
+	names := map[ids.ID]string{}
+	if blockchainID, err := utils.GetChainID(network.Endpoint, "C"); err == nil {
+		names[blockchainID] = cChainName
+	}
+	subnetNames, err := app.GetSubnetNamesOnNetwork(network)
+	if err != nil {
+		return names
+	}
+	for _, subnetName := range subnetNames {
+		if sc, err := app.LoadSidecar(subnetName); err == nil && sc.Networks[network.Name()].BlockchainID != ids.Empty {
+			names[sc.Networks[network.Name()].BlockchainID] = subnetName
+		}
+	}
+	return names
+}
+
+// formats [amount] given in the smallest unit of a token with [decimals]
+func formatTokenAmount(amount *big.Int, decimals uint8) string {
+	denominator := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	s := new(big.Rat).SetFrac(amount, denominator).FloatString(int(decimals))
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// converts [amount] given in units of a token with [decimals] to its smallest unit
+func tokenAmountToInt(amount float64, decimals uint8) *big.Int {
+	amountFlt := new(big.Float).SetFloat64(amount)
+	amountFlt = amountFlt.Mul(amountFlt, new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	amountInt, _ := amountFlt.Int(nil)
+	return amountInt
+}
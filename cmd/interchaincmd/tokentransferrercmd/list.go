@@ -0,0 +1,71 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package tokentransferrercmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/ictt"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var listNetworkFlags networkoptions.NetworkFlags
+
+// avalanche interchain tokenTransferrer list
+func NewListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the Token Transferrers deployed by CLI into a given Network",
+		Long:  "Lists the Token Transferrers deployed by CLI into a given Network, by the name they were registered with",
+		RunE:  list,
+		Args:  cobrautils.ExactArgs(0),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &listNetworkFlags, true, deploySupportedNetworkOptions)
+	return cmd
+}
+
+func list(_ *cobra.Command, _ []string) error {
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"On what Network are the Transferrers deployed?",
+		listNetworkFlags,
+		true,
+		false,
+		deploySupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	deployments, err := ictt.GetDeployments(app, network)
+	if err != nil {
+		return err
+	}
+	if len(deployments) == 0 {
+		ux.Logger.PrintToUser("There are no Token Transferrers deployed by CLI on %s", network.Name())
+		return nil
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Token", "Home", "Remotes", "Version"})
+	for _, deployment := range deployments {
+		remotes := utils.Map(deployment.Remotes, func(r models.ICTTEndpoint) string {
+			return r.Blockchain
+		})
+		table.Append([]string{
+			deployment.Name,
+			deployment.TokenSymbol,
+			deployment.Home.Blockchain + " (" + deployment.Home.Kind + ")",
+			strings.Join(remotes, ", "),
+			deployment.Version,
+		})
+	}
+	table.Render()
+	return nil
+}
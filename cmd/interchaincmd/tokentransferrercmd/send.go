@@ -0,0 +1,438 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package tokentransferrercmd
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/ictt"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultRecipientGasLimit = 100000
+	transferTimeout          = 5 * time.Minute
+)
+
+type CallFlags struct {
+	recipientContract string
+	payloadEsp        string
+	payloadArgs       []string
+	fallbackRecipient string
+	recipientGasLimit uint64
+}
+
+type SendFlags struct {
+	Network                       networkoptions.NetworkFlags
+	originFlags                   contract.ChainFlags
+	destinationFlags              contract.ChainFlags
+	transferrerName               string
+	originTransferrerAddress      string
+	destinationTransferrerAddress string
+	privateKeyFlags               contract.PrivateKeyFlags
+	recipient                     string
+	amount                        float64
+	messageFeeFlags               teleporter.MessageFeeFlags
+	secondaryFeeAmount            string
+	multiHopFallback              string
+	callFlags                     CallFlags
+}
+
+var sendFlags SendFlags
+
+// avalanche interchain tokenTransferrer send
+func NewSendCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "send",
+		Short: "Transfers tokens through a Token Transferrer",
+		Long: `Transfers tokens from the Transferrer Home or Remote on the origin chain to the Home or Remote
+on the destination chain, and follows the transfer until the tokens arrive.
+
+Transfers between two remotes are routed through the home (multi-hop). --secondary-fee-amount sets
+the relayer fee for the hop from the home, and --multi-hop-fallback the address that receives the
+tokens on the home chain if that hop fails (defaults to the sender). --required-gas-limit applies
+to the hop into the final chain.
+
+With --recipient-contract, the tokens are sent with sendAndCall: the contract receives them together
+with a call with the payload given by --payload-esp and --payload-args (eg --payload-esp "(address, uint256)"
+--payload-args 0x... --payload-args 10). If the call fails, the tokens go to --fallback-recipient.`,
+		RunE: send,
+		Args: cobrautils.ExactArgs(0),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &sendFlags.Network, true, deploySupportedNetworkOptions)
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&sendFlags.originFlags,
+		"send the tokens from",
+		"origin-subnet",
+		"c-chain-origin",
+	)
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&sendFlags.destinationFlags,
+		"send the tokens to",
+		"destination-subnet",
+		"c-chain-destination",
+	)
+	cmd.Flags().StringVar(&sendFlags.transferrerName, "transferrer", "", "name of a CLI deployed Transferrer, to be used instead of its addresses")
+	cmd.Flags().StringVar(&sendFlags.originTransferrerAddress, "origin-transferrer-address", "", "Transferrer address at the origin chain")
+	cmd.Flags().StringVar(&sendFlags.destinationTransferrerAddress, "destination-transferrer-address", "", "Transferrer address at the destination chain")
+	contract.AddPrivateKeyFlagsToCmd(cmd, &sendFlags.privateKeyFlags, "to send the tokens from")
+	cmd.Flags().StringVar(&sendFlags.recipient, "recipient", "", "address to receive the tokens at the destination")
+	cmd.Flags().Float64Var(&sendFlags.amount, "amount", 0, "amount to send, in origin token units")
+	teleporter.AddMessageFeeFlagsToCmd(cmd, &sendFlags.messageFeeFlags, false)
+	cmd.Flags().StringVar(&sendFlags.secondaryFeeAmount, "secondary-fee-amount", "", "relayer fee for the hop from the home on multi-hop transfers, in the transferred token smallest unit")
+	cmd.Flags().StringVar(&sendFlags.multiHopFallback, "multi-hop-fallback", "", "address to receive the tokens on the home chain if a multi-hop transfer fails (defaults to the sender)")
+	cmd.Flags().StringVar(&sendFlags.callFlags.recipientContract, "recipient-contract", "", "contract to receive the tokens together with a call (sendAndCall)")
+	cmd.Flags().StringVar(&sendFlags.callFlags.payloadEsp, "payload-esp", "", "types of the payload for the recipient contract (eg \"(address, uint256)\")")
+	cmd.Flags().StringArrayVar(&sendFlags.callFlags.payloadArgs, "payload-args", nil, "values of the payload for the recipient contract (one flag per value)")
+	cmd.Flags().StringVar(&sendFlags.callFlags.fallbackRecipient, "fallback-recipient", "", "address to receive the tokens if the recipient contract call fails (defaults to the sender)")
+	cmd.Flags().Uint64Var(&sendFlags.callFlags.recipientGasLimit, "recipient-gas-limit", defaultRecipientGasLimit, "gas limit for the recipient contract call")
+	return cmd
+}
+
+func send(_ *cobra.Command, _ []string) error {
+	return CallSend(sendFlags)
+}
+
+// parses an optional address flag, returning [defaultAddress] if it is not set
+func getAddressFlag(flagName string, value string, defaultAddress common.Address) (common.Address, error) {
+	if value == "" {
+		return defaultAddress, nil
+	}
+	if !common.IsHexAddress(value) {
+		return common.Address{}, fmt.Errorf("invalid --%s address %q", flagName, value)
+	}
+	return common.HexToAddress(value), nil
+}
+
+// returns the decimals of the token transferred by the Home or Remote at [address]
+func getEndpointTokenDecimals(rpcURL string, address common.Address) (ictt.EndpointKind, uint8, error) {
+	kind, err := ictt.GetEndpointKind(rpcURL, address)
+	if err != nil {
+		return ictt.Undefined, 0, err
+	}
+	// ERC20 remotes are the token themselves
+	tokenAddress := address
+	switch kind {
+	case ictt.ERC20TokenHome:
+		tokenAddress, err = ictt.ERC20TokenHomeGetTokenAddress(rpcURL, address)
+	case ictt.NativeTokenHome:
+		tokenAddress, err = ictt.NativeTokenHomeGetTokenAddress(rpcURL, address)
+	case ictt.NativeTokenRemote:
+		return kind, 18, nil
+	}
+	if err != nil {
+		return ictt.Undefined, 0, err
+	}
+	_, _, decimals, err := ictt.GetTokenParams(rpcURL, tokenAddress.Hex())
+	return kind, decimals, err
+}
+
+func CallSend(flags SendFlags) error {
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"On what Network is the Transferrer deployed?",
+		flags.Network,
+		true,
+		false,
+		deploySupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	if flags.transferrerName != "" && (flags.originTransferrerAddress != "" || flags.destinationTransferrerAddress != "") {
+		return fmt.Errorf("--transferrer can't be used together with --origin-transferrer-address or --destination-transferrer-address")
+	}
+	callFlags := flags.callFlags
+	sendAndCall := callFlags.recipientContract != ""
+	if sendAndCall && flags.recipient != "" {
+		return fmt.Errorf("--recipient and --recipient-contract are mutually exclusive flags")
+	}
+	if !sendAndCall && (callFlags.payloadEsp != "" || len(callFlags.payloadArgs) > 0 || callFlags.fallbackRecipient != "") {
+		return fmt.Errorf("--payload-esp, --payload-args and --fallback-recipient can only be used together with --recipient-contract")
+	}
+
+	// Chains
+	if !flags.originFlags.CChain && flags.originFlags.SubnetName == "" {
+		if cancel, err := promptChain("Where are the tokens sent from?", network, false, "", &flags.originFlags); err != nil {
+			return err
+		} else if cancel {
+			return nil
+		}
+	}
+	if !flags.destinationFlags.CChain && flags.destinationFlags.SubnetName == "" {
+		if cancel, err := promptChain("Where should the tokens arrive?", network, flags.originFlags.CChain, flags.originFlags.SubnetName, &flags.destinationFlags); err != nil {
+			return err
+		} else if cancel {
+			return nil
+		}
+	}
+	originName, destinationName := getChainName(flags.originFlags), getChainName(flags.destinationFlags)
+	if originName == destinationName {
+		return fmt.Errorf("origin and destination chains are the same")
+	}
+	originRPCURL, _, _, originBlockchainID, _, _, _, err := teleporter.GetSubnetParams(
+		app,
+		network,
+		flags.originFlags.SubnetName,
+		flags.originFlags.CChain,
+	)
+	if err != nil {
+		return err
+	}
+	destinationRPCURL, _, _, destinationBlockchainID, _, _, _, err := teleporter.GetSubnetParams(
+		app,
+		network,
+		flags.destinationFlags.SubnetName,
+		flags.destinationFlags.CChain,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Transferrer addresses
+	var originAddress, destinationAddress common.Address
+	if flags.transferrerName != "" {
+		if originAddress, err = ictt.GetDeploymentEndpointAddress(app, network, flags.transferrerName, originBlockchainID); err != nil {
+			return err
+		}
+		if destinationAddress, err = ictt.GetDeploymentEndpointAddress(app, network, flags.transferrerName, destinationBlockchainID); err != nil {
+			return err
+		}
+	} else {
+		for _, endpoint := range []struct {
+			flagValue string
+			chainName string
+			address   *common.Address
+		}{
+			{flags.originTransferrerAddress, originName, &originAddress},
+			{flags.destinationTransferrerAddress, destinationName, &destinationAddress},
+		} {
+			if endpoint.flagValue == "" {
+				*endpoint.address, err = app.Prompt.CaptureAddress(
+					fmt.Sprintf("Enter the address of the Token Transferrer on %s", endpoint.chainName),
+				)
+				if err != nil {
+					return err
+				}
+			} else {
+				if err := prompts.ValidateAddress(endpoint.flagValue); err != nil {
+					return err
+				}
+				*endpoint.address = common.HexToAddress(endpoint.flagValue)
+			}
+		}
+	}
+	originKind, originDecimals, err := getEndpointTokenDecimals(originRPCURL, originAddress)
+	if err != nil {
+		return fmt.Errorf("failure checking origin transferrer %s: %w", originAddress, err)
+	}
+	destinationKind, err := ictt.GetEndpointKind(destinationRPCURL, destinationAddress)
+	if err != nil {
+		return fmt.Errorf("failure checking destination transferrer %s: %w", destinationAddress, err)
+	}
+	isRemote := func(kind ictt.EndpointKind) bool {
+		return kind == ictt.ERC20TokenRemote || kind == ictt.NativeTokenRemote
+	}
+	multiHop := isRemote(originKind) && isRemote(destinationKind)
+	if !multiHop && (flags.secondaryFeeAmount != "" || flags.multiHopFallback != "") {
+		return fmt.Errorf("--secondary-fee-amount and --multi-hop-fallback only apply to transfers between remotes")
+	}
+	if !isRemote(originKind) && !isRemote(destinationKind) {
+		return fmt.Errorf("transfers are only possible between a home and its remotes, or between remotes")
+	}
+
+	// Sender
+	genesisAddress, genesisPrivateKey, err := contract.GetEVMSubnetPrefundedKey(
+		app,
+		network,
+		flags.originFlags.SubnetName,
+		flags.originFlags.CChain,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	privateKey, err := contract.GetPrivateKeyFromFlags(
+		app,
+		flags.privateKeyFlags,
+		genesisPrivateKey,
+	)
+	if err != nil {
+		return err
+	}
+	if privateKey == "" {
+		privateKey, err = prompts.PromptPrivateKey(
+			app.Prompt,
+			"send the tokens",
+			app.GetKeyDir(),
+			app.GetKey,
+			genesisAddress,
+			genesisPrivateKey,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	pk, err := crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
+	if err != nil {
+		return err
+	}
+	sender := crypto.PubkeyToAddress(pk.PublicKey)
+
+	// Transfer params
+	amount := flags.amount
+	if amount == 0 {
+		amount, err = app.Prompt.CaptureFloat("Amount to send (TOKEN units)", func(v float64) error {
+			if v <= 0 {
+				return fmt.Errorf("value %f must be greater than zero", v)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	amountInt := tokenAmountToInt(amount, originDecimals)
+	messageOptions, err := flags.messageFeeFlags.GetMessageOptions(
+		app,
+		network,
+		flags.originFlags.SubnetName,
+		flags.originFlags.CChain,
+	)
+	if err != nil {
+		return err
+	}
+	sendOptions := ictt.SendOptions{
+		PrimaryFeeTokenAddress: messageOptions.FeeTokenAddress,
+		PrimaryFee:             messageOptions.FeeAmount,
+		RequiredGasLimit:       messageOptions.RequiredGasLimit,
+	}
+	hops := []ids.ID{destinationBlockchainID}
+	if multiHop {
+		homeBlockchainID, err := ictt.TokenRemoteGetTokenHomeBlockchainID(originRPCURL, originAddress)
+		if err != nil {
+			return err
+		}
+		hops = append(hops, homeBlockchainID)
+		if flags.secondaryFeeAmount != "" {
+			secondaryFee, b := new(big.Int).SetString(flags.secondaryFeeAmount, 10)
+			if !b || secondaryFee.Sign() < 0 {
+				return fmt.Errorf("invalid secondary fee amount %q: expected a non negative integer", flags.secondaryFeeAmount)
+			}
+			sendOptions.SecondaryFee = secondaryFee
+		}
+		if sendOptions.MultiHopFallback, err = getAddressFlag("multi-hop-fallback", flags.multiHopFallback, sender); err != nil {
+			return err
+		}
+	}
+	var callOptions ictt.CallOptions
+	recipient := common.Address{}
+	if sendAndCall {
+		if callOptions.RecipientContract, err = getAddressFlag("recipient-contract", callFlags.recipientContract, common.Address{}); err != nil {
+			return err
+		}
+		if callOptions.FallbackRecipient, err = getAddressFlag("fallback-recipient", callFlags.fallbackRecipient, sender); err != nil {
+			return err
+		}
+		if callFlags.payloadEsp != "" {
+			callOptions.RecipientPayload, err = contract.PackEspValues(callFlags.payloadEsp, callFlags.payloadArgs)
+			if err != nil {
+				return fmt.Errorf("invalid payload: %w", err)
+			}
+		} else if len(callFlags.payloadArgs) > 0 {
+			return fmt.Errorf("--payload-args given without --payload-esp")
+		}
+		callOptions.RecipientGasLimit = new(big.Int).SetUint64(callFlags.recipientGasLimit)
+	} else {
+		if flags.recipient == "" {
+			recipient, err = app.Prompt.CaptureAddress("Enter the address to receive the tokens")
+			if err != nil {
+				return err
+			}
+		} else if recipient, err = getAddressFlag("recipient", flags.recipient, common.Address{}); err != nil {
+			return err
+		}
+	}
+
+	// Send
+	chainNames := getChainNames(network)
+	chainName := func(blockchainID ids.ID) string {
+		if name, ok := chainNames[blockchainID]; ok {
+			return name
+		}
+		return blockchainID.String()
+	}
+	tracker, err := ictt.NewTransferTracker(func(blockchainID ids.ID) string {
+		return network.BlockchainEndpoint(blockchainID.String())
+	}, hops...)
+	if err != nil {
+		return err
+	}
+	var receipt *types.Receipt
+	if sendAndCall {
+		receipt, err = ictt.SendAndCall(
+			originRPCURL,
+			originAddress,
+			privateKey,
+			destinationBlockchainID,
+			destinationAddress,
+			amountInt,
+			callOptions,
+			sendOptions,
+		)
+	} else {
+		receipt, err = ictt.Send(
+			originRPCURL,
+			originAddress,
+			privateKey,
+			destinationBlockchainID,
+			destinationAddress,
+			recipient,
+			amountInt,
+			sendOptions,
+		)
+	}
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Transfer of %g tokens sent from %s on tx %s", amount, originName, receipt.TxHash)
+	if multiHop {
+		ux.Logger.PrintToUser("Routed to %s through the home on %s", destinationName, chainName(hops[1]))
+	}
+	if err := tracker.Wait(receipt, destinationBlockchainID, transferTimeout, func(hop ictt.TransferHop) {
+		printTransferHop(hop, chainName)
+	}); err != nil {
+		return err
+	}
+	ux.Logger.GreenCheckmarkToUser("Tokens arrived at %s", destinationName)
+	return nil
+}
+
+func printTransferHop(hop ictt.TransferHop, chainName func(ids.ID) string) {
+	switch {
+	case hop.Delivery.Executed:
+		ux.Logger.PrintToUser("  message %s executed on %s", hop.MessageID, chainName(hop.DestinationBlockchainID))
+	case hop.Delivery.ExecutionFailed:
+		ux.Logger.RedXToUser("  message %s execution failed on %s", hop.MessageID, chainName(hop.DestinationBlockchainID))
+	case hop.Delivery.Received:
+		ux.Logger.PrintToUser("  message %s delivered to %s", hop.MessageID, chainName(hop.DestinationBlockchainID))
+	default:
+		ux.Logger.PrintToUser("  message %s waiting for delivery to %s", hop.MessageID, chainName(hop.DestinationBlockchainID))
+	}
+}
@@ -21,5 +21,11 @@ func NewCmd(injectedApp *application.Avalanche) *cobra.Command {
 	app = injectedApp
 	// tokenTransferrer deploy
 	cmd.AddCommand(NewDeployCmd())
+	// tokenTransferrer list
+	cmd.AddCommand(NewListCmd())
+	// tokenTransferrer describe
+	cmd.AddCommand(NewDescribeCmd())
+	// tokenTransferrer send
+	cmd.AddCommand(NewSendCmd())
 	return cmd
 }
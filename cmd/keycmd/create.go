@@ -63,7 +63,7 @@ func createKey(_ *cobra.Command, args []string) error {
 			if err != nil {
 				return err
 			}
-			addrInfos, err := getStoredKeyInfo(clients, networks, keyName)
+			addrInfos, err := getStoredKeyInfo(clients, networks, keyName, evmBalancesPrefetch{})
 			if err != nil {
 				return err
 			}
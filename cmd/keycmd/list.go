@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"strings"
 
 	"github.com/ava-labs/avalanche-cli/cmd/blockchaincmd"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
 	"github.com/ava-labs/avalanche-cli/pkg/key"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
@@ -148,6 +150,8 @@ type Clients struct {
 	cGeth   map[models.Network]*goethereumethclient.Client
 	evm     map[models.Network]map[string]ethclient.Client
 	evmGeth map[models.Network]map[string]*goethereumethclient.Client
+	// rpc endpoints of the evm chains, by network and chain name
+	evmEndpoints map[models.Network]map[string]string
 }
 
 func getClients(networks []models.Network, pchain bool, cchain bool, xchain bool, subnets []string) (
@@ -161,7 +165,9 @@ func getClients(networks []models.Network, pchain bool, cchain bool, xchain bool
 	cGethClients := map[models.Network]*goethereumethclient.Client{}
 	evmClients := map[models.Network]map[string]ethclient.Client{}
 	evmGethClients := map[models.Network]map[string]*goethereumethclient.Client{}
+	evmEndpoints := map[models.Network]map[string]string{}
 	for _, network := range networks {
+		evmEndpoints[network] = map[string]string{}
 		if pchain {
 			pClients[network] = platformvm.NewClient(network.Endpoint)
 		}
@@ -173,6 +179,7 @@ func getClients(networks []models.Network, pchain bool, cchain bool, xchain bool
 			if err != nil {
 				return nil, err
 			}
+			evmEndpoints[network]["C-Chain"] = network.CChainEndpoint()
 			if len(tokenAddresses) != 0 {
 				cGethClients[network], err = goethereumethclient.Dial(network.CChainEndpoint())
 				if err != nil {
@@ -206,6 +213,7 @@ func getClients(networks []models.Network, pchain bool, cchain bool, xchain bool
 						if err != nil {
 							return nil, err
 						}
+						evmEndpoints[network][subnetName] = network.BlockchainEndpoint(chainID.String())
 						if len(tokenAddresses) != 0 {
 							_, b := evmGethClients[network]
 							if !b {
@@ -222,12 +230,13 @@ func getClients(networks []models.Network, pchain bool, cchain bool, xchain bool
 		}
 	}
 	return &Clients{
-		p:       pClients,
-		x:       xClients,
-		c:       cClients,
-		evm:     evmClients,
-		cGeth:   cGethClients,
-		evmGeth: evmGethClients,
+		p:            pClients,
+		x:            xClients,
+		c:            cClients,
+		evm:          evmClients,
+		cGeth:        cGethClients,
+		evmGeth:      evmGethClients,
+		evmEndpoints: evmEndpoints,
 	}, nil
 }
 
@@ -331,9 +340,10 @@ func getStoredKeysInfo(
 	if len(keys) != 0 {
 		keyNames = utils.Filter(keyNames, func(keyName string) bool { return utils.Belongs(keys, keyName) })
 	}
+	prefetch := prefetchEvmBalances(clients, networks, keyNames)
 	addrInfos := []addressInfo{}
 	for _, keyName := range keyNames {
-		keyAddrInfos, err := getStoredKeyInfo(clients, networks, keyName)
+		keyAddrInfos, err := getStoredKeyInfo(clients, networks, keyName, prefetch)
 		if err != nil {
 			return nil, err
 		}
@@ -342,10 +352,118 @@ func getStoredKeysInfo(
 	return addrInfos, nil
 }
 
+// evm balances prefetched with one multicall per chain. Balances are indexed by
+// endpoint, token and address, with native balances stored under an empty token,
+// and token symbols by endpoint and token. The zero value has nothing prefetched
+type evmBalancesPrefetch struct {
+	balances     map[string]*big.Int
+	tokenSymbols map[string]string
+}
+
+func prefetchKey(elems ...string) string {
+	return strings.Join(elems, "|")
+}
+
+func (p evmBalancesPrefetch) getBalance(endpoint string, tokenAddress string, address string) (*big.Int, bool) {
+	balance, ok := p.balances[prefetchKey(endpoint, tokenAddress, common.HexToAddress(address).Hex())]
+	return balance, ok
+}
+
+func (p evmBalancesPrefetch) getTokenSymbol(endpoint string, tokenAddress string) (string, bool) {
+	symbol, ok := p.tokenSymbols[prefetchKey(endpoint, tokenAddress)]
+	return symbol, ok
+}
+
+// fetches the native and ERC20 balances of all the [keyNames] addresses on every
+// evm chain with one request, if the chain has Multicall3. Balances not prefetched
+// are later obtained one by one
+func prefetchEvmBalances(
+	clients *Clients,
+	networks []models.Network,
+	keyNames []string,
+) evmBalancesPrefetch {
+	prefetch := evmBalancesPrefetch{
+		balances:     map[string]*big.Int{},
+		tokenSymbols: map[string]string{},
+	}
+	for _, network := range networks {
+		if len(clients.evmEndpoints[network]) == 0 {
+			continue
+		}
+		addresses := []common.Address{}
+		for _, keyName := range keyNames {
+			sk, err := app.GetKey(keyName, network, false)
+			if err != nil {
+				continue
+			}
+			addresses = append(addresses, common.HexToAddress(sk.C()))
+		}
+		if len(addresses) < 2 {
+			continue
+		}
+		for _, endpoint := range clients.evmEndpoints[network] {
+			multicallAddress, err := contract.GetMulticallAddress(endpoint)
+			if err != nil {
+				continue
+			}
+			calls := []contract.Call{}
+			keys := []string{}
+			if showNativeToken {
+				for _, address := range addresses {
+					calls = append(calls, contract.Call{
+						Target:    multicallAddress,
+						MethodEsp: "getEthBalance(address)->(uint256)",
+						Params:    []interface{}{address},
+					})
+					keys = append(keys, prefetchKey(endpoint, "", address.Hex()))
+				}
+			}
+			for _, tokenAddress := range tokenAddresses {
+				token := common.HexToAddress(tokenAddress)
+				calls = append(calls, contract.Call{
+					Target:       token,
+					MethodEsp:    "symbol()->(string)",
+					AllowFailure: true,
+				})
+				keys = append(keys, prefetchKey(endpoint, tokenAddress))
+				for _, address := range addresses {
+					calls = append(calls, contract.Call{
+						Target:       token,
+						MethodEsp:    "balanceOf(address)->(uint256)",
+						Params:       []interface{}{address},
+						AllowFailure: true,
+					})
+					keys = append(keys, prefetchKey(endpoint, tokenAddress, address.Hex()))
+				}
+			}
+			if len(calls) == 0 {
+				continue
+			}
+			results, err := contract.MulticallRead(endpoint, calls)
+			if err != nil {
+				continue
+			}
+			for i, result := range results {
+				if !result.Success || len(result.Values) == 0 {
+					continue
+				}
+				switch v := result.Values[0].(type) {
+				case *big.Int:
+					prefetch.balances[keys[i]] = v
+				case string:
+					prefetch.tokenSymbols[keys[i]] = v
+				}
+			}
+		}
+	}
+	return prefetch
+}
+
 func getStoredKeyInfo(
 	clients *Clients,
 	networks []models.Network,
 	keyName string,
+	prefetch evmBalancesPrefetch,
 ) ([]addressInfo, error) {
 	addrInfos := []addressInfo{}
 	for _, network := range networks {
@@ -361,10 +479,12 @@ func getStoredKeyInfo(
 					subnetToken,
 					clients.evm[network][subnetName],
 					clients.evmGeth[network][subnetName],
+					clients.evmEndpoints[network][subnetName],
 					network,
 					evmAddr,
 					"stored",
 					keyName,
+					prefetch,
 				)
 				if err != nil {
 					return nil, err
@@ -374,7 +494,7 @@ func getStoredKeyInfo(
 		}
 		if _, ok := clients.c[network]; ok {
 			cChainAddr := sk.C()
-			addrInfo, err := getEvmBasedChainAddrInfo("C-Chain", "AVAX", clients.c[network], clients.cGeth[network], network, cChainAddr, "stored", keyName)
+			addrInfo, err := getEvmBasedChainAddrInfo("C-Chain", "AVAX", clients.c[network], clients.cGeth[network], clients.evmEndpoints[network]["C-Chain"], network, cChainAddr, "stored", keyName, prefetch)
 			if err != nil {
 				return nil, err
 			}
@@ -514,14 +634,24 @@ func getEvmBasedChainAddrInfo(
 	chainToken string,
 	cClient ethclient.Client,
 	cGethClient *goethereumethclient.Client,
+	endpoint string,
 	network models.Network,
 	cChainAddr string,
 	kind string,
 	name string,
+	prefetch evmBalancesPrefetch,
 ) ([]addressInfo, error) {
 	addressInfos := []addressInfo{}
 	if showNativeToken {
-		cChainBalance, err := getCChainBalanceStr(cClient, cChainAddr)
+		var (
+			cChainBalance string
+			err           error
+		)
+		if balance, ok := prefetch.getBalance(endpoint, "", cChainAddr); ok {
+			cChainBalance, err = formatCChainBalance(new(big.Int).Set(balance))
+		} else {
+			cChainBalance, err = getCChainBalanceStr(cClient, cChainAddr)
+		}
 		if err != nil {
 			// just ignore local network errors
 			if network.Kind != models.Local {
@@ -545,11 +675,29 @@ func getEvmBasedChainAddrInfo(
 	}
 	if cGethClient != nil {
 		for _, tokenAddress := range tokenAddresses {
+			tokenSymbol, symbolPrefetched := prefetch.getTokenSymbol(endpoint, tokenAddress)
+			balance, balancePrefetched := prefetch.getBalance(endpoint, tokenAddress, cChainAddr)
+			if symbolPrefetched && balancePrefetched {
+				formattedBalance, err := formatCChainBalance(new(big.Int).Set(balance))
+				if err != nil {
+					return addressInfos, err
+				}
+				addressInfos = append(addressInfos, addressInfo{
+					kind:    kind,
+					name:    name,
+					chain:   chainName,
+					token:   fmt.Sprintf("%s (%s.)", tokenSymbol, tokenAddress[:6]),
+					address: cChainAddr,
+					balance: formattedBalance,
+					network: network.Name(),
+				})
+				continue
+			}
 			token, err := erc20.NewGGToken(common.HexToAddress(tokenAddress), cGethClient)
 			if err != nil {
 				return addressInfos, err
 			}
-			tokenSymbol, err := token.Symbol(nil)
+			tokenSymbol, err = token.Symbol(nil)
 			if err == nil {
 				// just ignore contract address access errors as those may depend on network
 				balance, err := token.BalanceOf(nil, common.HexToAddress(cChainAddr))
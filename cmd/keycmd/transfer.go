@@ -6,15 +6,19 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
 	"github.com/ava-labs/avalanche-cli/pkg/ictt"
 	"github.com/ava-labs/avalanche-cli/pkg/key"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
 	"github.com/ava-labs/avalanche-cli/pkg/prompts"
 	"github.com/ava-labs/avalanche-cli/pkg/subnet"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanchego/ids"
@@ -59,6 +63,7 @@ var (
 	ledgerIndex         uint32
 	force               bool
 	destinationAddrStr  string
+	amountStr           string
 	amountFlt           float64
 	receiveRecoveryStep uint64
 	PToX                bool
@@ -68,7 +73,10 @@ var (
 	destinationSubnet             string
 	originTransferrerAddress      string
 	destinationTransferrerAddress string
+	transferrerName               string
 	destinationKeyName            string
+	tokenRef                      string
+	messageFeeFlags               teleporter.MessageFeeFlags
 )
 
 func newTransferCmd() *cobra.Command {
@@ -146,11 +154,11 @@ func newTransferCmd() *cobra.Command {
 		"",
 		"key associated to a destination address",
 	)
-	cmd.Flags().Float64VarP(
-		&amountFlt,
+	cmd.Flags().StringVarP(
+		&amountStr,
 		amountFlag,
 		"o",
-		0,
+		"",
 		"amount to send or receive (AVAX or TOKEN units)",
 	)
 	cmd.Flags().StringVar(
@@ -177,6 +185,19 @@ func newTransferCmd() *cobra.Command {
 		"",
 		"token transferrer address at the destination subnet (token transferrer experimental)",
 	)
+	cmd.Flags().StringVar(
+		&transferrerName,
+		"transferrer",
+		"",
+		"name of a CLI deployed token transferrer, to be used instead of its addresses (token transferrer experimental)",
+	)
+	cmd.Flags().StringVar(
+		&tokenRef,
+		"token",
+		"",
+		"transfer the given ERC20 token (address, or name of a CLI deployed token) inside the origin subnet",
+	)
+	teleporter.AddMessageFeeFlagsToCmd(cmd, &messageFeeFlags, false)
 	return cmd
 }
 
@@ -189,6 +210,20 @@ func transferF(*cobra.Command, []string) error {
 		return fmt.Errorf("only one between a keyname or a ledger index must be given")
 	}
 
+	if tokenRef != "" && (PToX || PToP) {
+		return fmt.Errorf("--token can only be used on EVM chains")
+	}
+
+	// ERC20 transfers parse [amountStr] exactly, with the token decimals
+	amountFlt = 0
+	if amountStr != "" && tokenRef == "" {
+		var err error
+		amountFlt, err = strconv.ParseFloat(amountStr, 64)
+		if err != nil {
+			return fmt.Errorf("invalid amount %q", amountStr)
+		}
+	}
+
 	network, err := networkoptions.GetNetworkFromCmdLineFlags(
 		app,
 		"On what Network do you want to execute the transfer?",
@@ -225,6 +260,9 @@ func transferF(*cobra.Command, []string) error {
 		case cancel:
 			return nil
 		case pChainChoosen:
+			if tokenRef != "" {
+				return fmt.Errorf("--token can only be used on EVM chains")
+			}
 			option, err := app.Prompt.CaptureList(
 				"Destination Chain",
 				[]string{"P-Chain", "X-Chain"},
@@ -246,7 +284,7 @@ func transferF(*cobra.Command, []string) error {
 
 	// token transferrer experimental
 	if originSubnet != "" {
-		if destinationSubnet == "" {
+		if destinationSubnet == "" && tokenRef == "" {
 			prompt := "Where are the funds going to?"
 			avoidSubnet := originSubnet
 			if originSubnet == cChain {
@@ -273,7 +311,11 @@ func transferF(*cobra.Command, []string) error {
 				destinationSubnet = subnetName
 			}
 		}
+		if transferrerName != "" && (originTransferrerAddress != "" || destinationTransferrerAddress != "" || tokenRef != "") {
+			return fmt.Errorf("--transferrer can't be used together with --origin-transferrer-address, --destination-transferrer-address or --token")
+		}
 		originURL := network.CChainEndpoint()
+		var originBlockchainID ids.ID
 		if strings.ToLower(originSubnet) != cChain {
 			sc, err := app.LoadSidecar(originSubnet)
 			if err != nil {
@@ -284,14 +326,23 @@ func transferF(*cobra.Command, []string) error {
 				return fmt.Errorf("subnet %s is not deployed to %s", originSubnet, network.Name())
 			}
 			originURL = network.BlockchainEndpoint(blockchainID.String())
+			originBlockchainID = blockchainID
+		} else if transferrerName != "" {
+			originBlockchainID, err = utils.GetChainID(network.Endpoint, "C")
+			if err != nil {
+				return err
+			}
 		}
 		var destinationBlockchainID ids.ID
-		if strings.ToLower(destinationSubnet) == cChain {
+		switch {
+		case tokenRef != "":
+			// erc20 transfer inside origin subnet
+		case strings.ToLower(destinationSubnet) == cChain:
 			destinationBlockchainID, err = utils.GetChainID(network.Endpoint, "C")
 			if err != nil {
 				return err
 			}
-		} else {
+		default:
 			sc, err := app.LoadSidecar(destinationSubnet)
 			if err != nil {
 				return err
@@ -302,7 +353,19 @@ func transferF(*cobra.Command, []string) error {
 			}
 			destinationBlockchainID = blockchainID
 		}
-		if originTransferrerAddress == "" {
+		if transferrerName != "" {
+			originAddress, err := ictt.GetDeploymentEndpointAddress(app, network, transferrerName, originBlockchainID)
+			if err != nil {
+				return err
+			}
+			destinationAddress, err := ictt.GetDeploymentEndpointAddress(app, network, transferrerName, destinationBlockchainID)
+			if err != nil {
+				return err
+			}
+			originTransferrerAddress = originAddress.Hex()
+			destinationTransferrerAddress = destinationAddress.Hex()
+		}
+		if originTransferrerAddress == "" && tokenRef == "" {
 			addr, err := app.Prompt.CaptureAddress(
 				fmt.Sprintf("Enter the address of the Token Transferrer on %s", originSubnet),
 			)
@@ -315,7 +378,7 @@ func transferF(*cobra.Command, []string) error {
 				return err
 			}
 		}
-		if destinationTransferrerAddress == "" {
+		if destinationTransferrerAddress == "" && tokenRef == "" {
 			addr, err := app.Prompt.CaptureAddress(
 				fmt.Sprintf("Enter the address of the Token Transferrer on %s", destinationSubnet),
 			)
@@ -380,6 +443,9 @@ func transferF(*cobra.Command, []string) error {
 		default:
 			return fmt.Errorf("you should set the destination address or destination key")
 		}
+		if tokenRef != "" {
+			return transferERC20(network, originURL, privateKey, destinationAddr)
+		}
 		if amountFlt == 0 {
 			amountFlt, err = captureAmount(true, "TOKEN units")
 			if err != nil {
@@ -390,7 +456,16 @@ func transferF(*cobra.Command, []string) error {
 		amount = amount.Mul(amount, new(big.Float).SetFloat64(float64(units.Avax)))
 		amount = amount.Mul(amount, new(big.Float).SetFloat64(float64(units.Avax)))
 		amountInt, _ := amount.Int(nil)
-		return ictt.Send(
+		messageOptions, err := messageFeeFlags.GetMessageOptions(
+			app,
+			network,
+			originSubnet,
+			strings.ToLower(originSubnet) == cChain,
+		)
+		if err != nil {
+			return err
+		}
+		_, err = ictt.Send(
 			originURL,
 			goethereumcommon.HexToAddress(originTransferrerAddress),
 			privateKey,
@@ -398,7 +473,13 @@ func transferF(*cobra.Command, []string) error {
 			goethereumcommon.HexToAddress(destinationTransferrerAddress),
 			destinationAddr,
 			amountInt,
+			ictt.SendOptions{
+				PrimaryFeeTokenAddress: messageOptions.FeeTokenAddress,
+				PrimaryFee:             messageOptions.FeeAmount,
+				RequiredGasLimit:       messageOptions.RequiredGasLimit,
+			},
 		)
+		return err
 	}
 
 	if !send && !receive {
@@ -700,6 +781,75 @@ func transferF(*cobra.Command, []string) error {
 	return nil
 }
 
+// transfers [amountStr] units of the ERC20 token given by [tokenRef] on
+// the origin subnet
+func transferERC20(
+	network models.Network,
+	rpcURL string,
+	privateKey string,
+	destinationAddr goethereumcommon.Address,
+) error {
+	tokenAddress, err := contract.GetERC20TokenAddress(
+		app,
+		network,
+		originSubnet,
+		strings.ToLower(originSubnet) == cChain,
+		tokenRef,
+	)
+	if err != nil {
+		return err
+	}
+	out, err := contract.CallToMethod(rpcURL, tokenAddress, "decimals()->(uint8)")
+	if err != nil {
+		return err
+	}
+	decimals, ok := out[0].(uint8)
+	if !ok {
+		return fmt.Errorf("error at decimals call, expected uint8, got %T", out[0])
+	}
+	if amountStr == "" {
+		amountStr, err = app.Prompt.CaptureValidatedString(
+			fmt.Sprintf("Amount to send (%s units)", tokenRef),
+			func(s string) error {
+				_, err := parseTokenAmount(s, decimals)
+				return err
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+	amountInt, err := parseTokenAmount(amountStr, decimals)
+	if err != nil {
+		return err
+	}
+	if _, _, err := contract.TxToMethod(
+		rpcURL,
+		privateKey,
+		tokenAddress,
+		nil,
+		"transfer(address, uint256)->(bool)",
+		destinationAddr,
+		amountInt,
+	); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("%s %s transferred to %s", strings.TrimSpace(amountStr), tokenRef, destinationAddr.Hex())
+	return nil
+}
+
+// parses [amount], in token units, into the token base units given by [decimals]
+func parseTokenAmount(amount string, decimals uint8) (*big.Int, error) {
+	amountInt, err := utils.ParseUnits(amount, decimals)
+	if err != nil {
+		return nil, err
+	}
+	if amountInt.Sign() == 0 {
+		return nil, fmt.Errorf("value %s must be greater than zero", amount)
+	}
+	return amountInt, nil
+}
+
 func captureAmount(sending bool, tokenDesc string) (float64, error) {
 	var promptStr string
 	if sending {
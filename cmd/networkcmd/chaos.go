@@ -0,0 +1,131 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package networkcmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-network-runner/server"
+	"github.com/spf13/cobra"
+)
+
+const chaosTimeFormat = "15:04:05"
+
+type chaosFlags struct {
+	blockchainName string
+	nodes          []string
+	faults         []string
+	interval       time.Duration
+	downtime       time.Duration
+	maxDown        int
+	duration       time.Duration
+	seed           int64
+}
+
+var chaosCmdFlags chaosFlags
+
+func newChaosCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chaos",
+		Short: "Randomly injects faults into the local network nodes",
+		Long: `The network chaos command randomly restarts, stops or pauses the nodes of the
+running local network, one each --interval, to test how blockchains and relayers
+behave when validators drop.
+
+Stopped and paused nodes are brought back after --downtime, and at most --max-down
+nodes are down at the same time. Use --blockchain to only target the validators of
+a blockchain. The command runs until --duration elapses or it is interrupted, and
+then resumes any node still down.
+
+Keep --max-down under a third of the validators to avoid the network halting.`,
+		RunE: chaos,
+		Args: cobrautils.ExactArgs(0),
+	}
+	cmd.Flags().StringVar(&chaosCmdFlags.blockchainName, "blockchain", "", "only inject faults into the validators of this blockchain")
+	cmd.Flags().StringSliceVar(&chaosCmdFlags.nodes, "nodes", nil, "only inject faults into these nodes")
+	cmd.Flags().StringSliceVar(&chaosCmdFlags.faults, "faults", []string{string(localnet.ChaosRestart)}, "faults to randomly pick from (restart, stop, pause)")
+	cmd.Flags().DurationVar(&chaosCmdFlags.interval, "interval", 30*time.Second, "time between faults")
+	cmd.Flags().DurationVar(&chaosCmdFlags.downtime, "downtime", 20*time.Second, "time a stopped or paused node is kept down")
+	cmd.Flags().IntVar(&chaosCmdFlags.maxDown, "max-down", 1, "max number of nodes down at the same time")
+	cmd.Flags().DurationVar(&chaosCmdFlags.duration, "duration", 0, "stop injecting faults after this time (default: until interrupted)")
+	cmd.Flags().Int64Var(&chaosCmdFlags.seed, "seed", 0, "random seed, to repeat a previous run (default: random)")
+	return cmd
+}
+
+func chaos(*cobra.Command, []string) error {
+	nodes, err := localnet.NewNodes()
+	if err != nil {
+		return err
+	}
+	defer nodes.Close()
+	ctx, cancel := utils.GetANRContext()
+	defer cancel()
+	nodeNames, err := nodes.Validators(ctx, chaosCmdFlags.blockchainName)
+	if err != nil {
+		if server.IsServerError(err, server.ErrNotBootstrapped) {
+			return fmt.Errorf("no local network running. start it with avalanche network start")
+		}
+		return err
+	}
+	if len(chaosCmdFlags.nodes) > 0 {
+		for _, nodeName := range chaosCmdFlags.nodes {
+			if !slices.Contains(nodeNames, nodeName) {
+				return fmt.Errorf("node %s not found. available nodes: %s", nodeName, strings.Join(nodeNames, ", "))
+			}
+		}
+		nodeNames = chaosCmdFlags.nodes
+	}
+	faults := []localnet.ChaosFault{}
+	for _, fault := range chaosCmdFlags.faults {
+		faults = append(faults, localnet.ChaosFault(fault))
+	}
+	seed := chaosCmdFlags.seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	ux.Logger.PrintToUser("Injecting faults into nodes %s every %s (seed %d)", strings.Join(nodeNames, ", "), chaosCmdFlags.interval, seed)
+	ux.Logger.PrintToUser("Press Ctrl+C to stop")
+	chaosCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := localnet.RunChaos(
+		chaosCtx,
+		nodes,
+		localnet.ChaosOptions{
+			Nodes:    nodeNames,
+			Faults:   faults,
+			Interval: chaosCmdFlags.interval,
+			Downtime: chaosCmdFlags.downtime,
+			MaxDown:  chaosCmdFlags.maxDown,
+			Duration: chaosCmdFlags.duration,
+			Seed:     seed,
+		},
+		printChaosEvent,
+	); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Chaos finished. All nodes are back")
+	return nil
+}
+
+func printChaosEvent(event localnet.ChaosEvent) {
+	action := string(event.Fault)
+	if event.Recovered {
+		action = "resume"
+	}
+	if event.Err != nil {
+		ux.Logger.PrintToUser("[%s] %s %s failed: %s", event.Time.Format(chaosTimeFormat), action, event.Node, event.Err)
+		return
+	}
+	ux.Logger.PrintToUser("[%s] %s %s", event.Time.Format(chaosTimeFormat), action, event.Node)
+}
@@ -27,7 +27,10 @@ func newCleanCmd() *cobra.Command {
 		Short: "Stop the running local network and delete state",
 		Long: `The network clean command shuts down your local, multi-node network. All deployed Subnets
 shutdown and delete their state. You can restart the network by deploying a new Subnet
-configuration.`,
+configuration.
+
+When operating on a named local network, all its data is removed, and its name and
+ports are freed.`,
 		RunE: clean,
 		Args: cobrautils.ExactArgs(0),
 	}
@@ -42,13 +45,22 @@ configuration.`,
 	return cmd
 }
 
+// CallClean stops the local network and deletes its state, as network clean does
+func CallClean(hardParam bool) error {
+	hard = hardParam
+	return clean(nil, nil)
+}
+
 func clean(*cobra.Command, []string) error {
 	app.Log.Info("killing gRPC server process...")
 
-	configSingleNodeEnabled := app.Conf.GetConfigBoolValue(constants.ConfigSingleNodeEnabledKey)
+	instance := models.GetLocalNetworkInstance()
 
-	if _, err := subnet.SetDefaultSnapshot(app.GetSnapshotsDir(), true, "", configSingleNodeEnabled); err != nil {
-		app.Log.Warn("failed resetting default snapshot", zap.Error(err))
+	if instance.IsDefault() {
+		configSingleNodeEnabled := app.Conf.GetConfigBoolValue(constants.ConfigSingleNodeEnabledKey)
+		if _, err := subnet.SetDefaultSnapshot(app.GetSnapshotsDir(), true, "", configSingleNodeEnabled); err != nil {
+			app.Log.Warn("failed resetting default snapshot", zap.Error(err))
+		}
 	}
 
 	if err := binutils.KillgRPCServerProcess(app); err != nil {
@@ -71,6 +83,15 @@ func clean(*cobra.Command, []string) error {
 		_ = killAllBackendsByName()
 	}
 
+	if !instance.IsDefault() {
+		// named local networks keep all their state under their own dir
+		if err := app.RemoveLocalNetwork(instance.Name); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Local network %s removed.", instance.Name)
+		return nil
+	}
+
 	if err := app.ResetPluginsDir(); err != nil {
 		return err
 	}
@@ -0,0 +1,57 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package networkcmd
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+func newListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists the local networks",
+		Long: `The network list command lists the default local network and the named local
+networks created with avalanche network start --name <name>, with their ports
+and whether they are running.`,
+		RunE: listNetworks,
+		Args: cobrautils.ExactArgs(0),
+	}
+}
+
+func listNetworks(*cobra.Command, []string) error {
+	instances, err := app.GetLocalNetworkInstances()
+	if err != nil {
+		return err
+	}
+	instances = append([]models.LocalNetworkInstance{{}}, instances...)
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Endpoint", "Backend Port", "Status"})
+	for _, instance := range instances {
+		table.Append([]string{
+			instance.DisplayName(),
+			instance.APIEndpoint(),
+			strconv.Itoa(instance.GRPCServerPort()),
+			getLocalNetworkStatus(instance),
+		})
+	}
+	table.Render()
+	return nil
+}
+
+func getLocalNetworkStatus(instance models.LocalNetworkInstance) string {
+	current := models.GetLocalNetworkInstance()
+	models.SetLocalNetworkInstance(instance)
+	defer models.SetLocalNetworkInstance(current)
+	clusterInfo, err := localnet.GetClusterInfo()
+	if err != nil || clusterInfo == nil {
+		return "stopped"
+	}
+	return "running"
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package networkcmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-network-runner/server"
+	"github.com/spf13/cobra"
+)
+
+const logsTimeFormat = "2006-01-02 15:04:05.000"
+
+type logsFlags struct {
+	nodes          []string
+	blockchainName string
+	level          string
+	follow         bool
+	since          string
+	grep           string
+	jsonOutput     bool
+}
+
+var logsCmdFlags logsFlags
+
+func newLogsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Shows the logs of the local network nodes and chains",
+		Long: `The network logs command merges the logs of all the local network nodes and their
+chains by time, prefixing each line with its node and chain.
+
+Use --node and --blockchain to select the logs to show, where --blockchain accepts a
+deployed blockchain name, P, X, C, or main for the node logs. Use --level, --since and
+--grep to filter them, and --follow to keep showing new entries until interrupted.`,
+		RunE: logs,
+		Args: cobrautils.ExactArgs(0),
+	}
+	cmd.Flags().StringSliceVar(&logsCmdFlags.nodes, "node", nil, "only show the logs of these nodes")
+	cmd.Flags().StringVar(&logsCmdFlags.blockchainName, "blockchain", "", "only show the logs of this blockchain (name, P, X, C or main)")
+	cmd.Flags().StringVar(&logsCmdFlags.level, "level", "", "only show entries at this level or above (verbo, debug, trace, info, warn, error, fatal)")
+	cmd.Flags().BoolVarP(&logsCmdFlags.follow, "follow", "f", false, "keep showing new entries")
+	cmd.Flags().StringVar(&logsCmdFlags.since, "since", "", "only show entries after this duration ago (ex: 10m) or time (RFC3339)")
+	cmd.Flags().StringVar(&logsCmdFlags.grep, "grep", "", "only show entries matching this regular expression")
+	cmd.Flags().BoolVar(&logsCmdFlags.jsonOutput, "json", false, "print one JSON object per entry")
+	return cmd
+}
+
+func logs(*cobra.Command, []string) error {
+	filter, err := getLogFilter()
+	if err != nil {
+		return err
+	}
+	clusterInfo, err := localnet.GetClusterInfo()
+	if err != nil {
+		if server.IsServerError(err, server.ErrNotBootstrapped) {
+			return fmt.Errorf("no local network running. start it with avalanche network start")
+		}
+		return err
+	}
+	sources, err := localnet.GetLogSources(clusterInfo, logsCmdFlags.nodes, logsCmdFlags.blockchainName)
+	if err != nil {
+		return err
+	}
+	if len(sources) == 0 {
+		ux.Logger.PrintToUser("No logs found")
+		return nil
+	}
+	if logsCmdFlags.follow {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		var printErr error
+		if err := localnet.FollowLogs(ctx, sources, filter, func(entry localnet.LogEntry) {
+			if printErr == nil {
+				printErr = printLogEntry(entry)
+			}
+		}); err != nil {
+			return err
+		}
+		return printErr
+	}
+	entries, err := localnet.ReadLogs(sources, filter)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := printLogEntry(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getLogFilter() (localnet.LogFilter, error) {
+	filter := localnet.LogFilter{
+		Level: logsCmdFlags.level,
+	}
+	if logsCmdFlags.since != "" {
+		if d, err := time.ParseDuration(logsCmdFlags.since); err == nil {
+			filter.Since = time.Now().Add(-d)
+		} else if t, err := time.Parse(time.RFC3339, logsCmdFlags.since); err == nil {
+			filter.Since = t
+		} else {
+			return localnet.LogFilter{}, fmt.Errorf("invalid --since %q. expected a duration (ex: 10m) or a RFC3339 time", logsCmdFlags.since)
+		}
+	}
+	if logsCmdFlags.grep != "" {
+		var err error
+		filter.Grep, err = regexp.Compile(logsCmdFlags.grep)
+		if err != nil {
+			return localnet.LogFilter{}, fmt.Errorf("invalid --grep expression: %w", err)
+		}
+	}
+	return filter, nil
+}
+
+func printLogEntry(entry localnet.LogEntry) error {
+	if logsCmdFlags.jsonOutput {
+		bs, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(bs))
+		return nil
+	}
+	fmt.Printf("[%s %s] %s %s %s\n", entry.Node, entry.Chain, entry.Time.Format(logsTimeFormat), entry.Level, entry.Message)
+	return nil
+}
@@ -3,6 +3,8 @@
 package networkcmd
 
 import (
+	"github.com/ava-labs/avalanche-cli/cmd/networkcmd/nodecmd"
+	"github.com/ava-labs/avalanche-cli/cmd/networkcmd/snapshotcmd"
 	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/spf13/cobra"
@@ -22,7 +24,11 @@ When you deploy a Subnet locally, it runs on a local, multi-node Avalanche netwo
 subnet deploy command starts this network in the background. This command suite allows you
 to shutdown, restart, and clear that network.
 
-This network currently supports multiple, concurrently deployed Subnets.`,
+This network currently supports multiple, concurrently deployed Subnets.
+
+Several isolated local networks can run side by side by giving them a name with
+--name (or --local-network on any other command). Each named network has its own
+backend, ports, snapshots and plugins.`,
 		RunE: cobrautils.CommandSuiteUsage,
 		Args: cobrautils.ExactArgs(0),
 	}
@@ -34,5 +40,15 @@ This network currently supports multiple, concurrently deployed Subnets.`,
 	cmd.AddCommand(newCleanCmd())
 	// network status
 	cmd.AddCommand(newStatusCmd())
+	// network list
+	cmd.AddCommand(newListCmd())
+	// network logs
+	cmd.AddCommand(newLogsCmd())
+	// network snapshot
+	cmd.AddCommand(snapshotcmd.NewCmd(app))
+	// network node
+	cmd.AddCommand(nodecmd.NewCmd(app))
+	// network chaos
+	cmd.AddCommand(newChaosCmd())
 	return cmd
 }
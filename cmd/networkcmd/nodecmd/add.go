@@ -0,0 +1,41 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+// avalanche network node add
+func newAddCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "add [nodeName]",
+		Short: "Adds a node to the local network",
+		Long: `Adds a new node to the local network, using the same avalanchego binary as the
+other nodes, and tracking all the network subnets. The new node is not a validator.
+
+If no node name is given, the next free node<i> name is used.`,
+		RunE: addNode,
+		Args: cobrautils.MaximumNArgs(1),
+	}
+}
+
+func addNode(_ *cobra.Command, args []string) error {
+	nodeName := ""
+	if len(args) > 0 {
+		nodeName = args[0]
+	}
+	return withNodes(func(ctx context.Context, nodes *localnet.Nodes) error {
+		nodeName, err := nodes.Add(ctx, nodeName, app.GetPluginsDir())
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Node %s added", nodeName)
+		return nil
+	})
+}
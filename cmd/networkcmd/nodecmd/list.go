@@ -0,0 +1,45 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// avalanche network node list
+func newListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists the nodes of the local network",
+		Long:  "Lists the nodes of the local network, with their node ID, endpoint and status.",
+		RunE:  list,
+		Args:  cobrautils.ExactArgs(0),
+	}
+}
+
+func list(*cobra.Command, []string) error {
+	return withNodes(func(ctx context.Context, nodes *localnet.Nodes) error {
+		clusterInfo, err := nodes.ClusterInfo(ctx)
+		if err != nil {
+			return err
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Name", "Node ID", "Endpoint", "Status"})
+		for _, nodeName := range clusterInfo.GetNodeNames() {
+			nodeInfo := clusterInfo.GetNodeInfos()[nodeName]
+			status, err := nodes.Status(ctx, nodeName)
+			if err != nil {
+				status = "unknown"
+			}
+			table.Append([]string{nodeName, nodeInfo.GetId(), nodeInfo.GetUri(), status})
+		}
+		table.Render()
+		return nil
+	})
+}
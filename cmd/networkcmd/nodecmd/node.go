@@ -0,0 +1,60 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-network-runner/server"
+	"github.com/spf13/cobra"
+)
+
+var app *application.Avalanche
+
+// avalanche network node
+func NewCmd(injectedApp *application.Avalanche) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "node",
+		Short: "Manage the nodes of the local network",
+		Long: `The node command suite provides a collection of tools for managing the
+nodes of the running local network.
+
+Nodes can be stopped, paused, resumed, restarted, added and removed, to test
+how blockchains and relayers behave when validators drop, without needing
+cloud machines.`,
+		RunE: cobrautils.CommandSuiteUsage,
+		Args: cobrautils.ExactArgs(0),
+	}
+	app = injectedApp
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newStopCmd())
+	cmd.AddCommand(newPauseCmd())
+	cmd.AddCommand(newResumeCmd())
+	cmd.AddCommand(newRestartCmd())
+	cmd.AddCommand(newAddCmd())
+	cmd.AddCommand(newRemoveCmd())
+	return cmd
+}
+
+// calls [f] with the nodes of the running local network
+func withNodes(f func(ctx context.Context, nodes *localnet.Nodes) error) error {
+	nodes, err := localnet.NewNodes()
+	if err != nil {
+		return err
+	}
+	defer nodes.Close()
+	ctx, cancel := utils.GetANRContext()
+	defer cancel()
+	if _, err := nodes.ClusterInfo(ctx); err != nil {
+		if server.IsServerError(err, server.ErrNotBootstrapped) {
+			return fmt.Errorf("no local network running. start it with avalanche network start")
+		}
+		return err
+	}
+	return f(ctx, nodes)
+}
@@ -0,0 +1,36 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+// avalanche network node pause
+func newPauseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pause [nodeName]",
+		Short: "Freezes a local network node",
+		Long: `Freezes the process of a local network node. Unlike a stopped node, its peers
+keep their connections to it open, as with a hung or unreachable node. Bring it
+back with avalanche network node resume.`,
+		RunE: pauseNode,
+		Args: cobrautils.ExactArgs(1),
+	}
+}
+
+func pauseNode(_ *cobra.Command, args []string) error {
+	nodeName := args[0]
+	return withNodes(func(ctx context.Context, nodes *localnet.Nodes) error {
+		if err := nodes.Pause(ctx, nodeName); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Node %s paused", nodeName)
+		return nil
+	})
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var force bool
+
+// avalanche network node remove
+func newRemoveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove [nodeName]",
+		Short: "Removes a node from the local network",
+		Long: `Stops a local network node and removes it from the network. If the node is a
+validator, it keeps being part of the validator set, as down.`,
+		RunE: removeNode,
+		Args: cobrautils.ExactArgs(1),
+	}
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "remove without asking for confirmation")
+	return cmd
+}
+
+func removeNode(_ *cobra.Command, args []string) error {
+	nodeName := args[0]
+	if !force {
+		yes, err := app.Prompt.CaptureYesNo(fmt.Sprintf("Are you sure you want to remove node %s?", nodeName))
+		if err != nil {
+			return err
+		}
+		if !yes {
+			return nil
+		}
+	}
+	return withNodes(func(ctx context.Context, nodes *localnet.Nodes) error {
+		if err := nodes.Remove(ctx, nodeName); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Node %s removed", nodeName)
+		return nil
+	})
+}
@@ -0,0 +1,35 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+// avalanche network node restart
+func newRestartCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restart [nodeName]",
+		Short: "Restarts a local network node",
+		Long: `Restarts a local network node with its current config. Stopped or paused nodes
+are resumed.`,
+		RunE: restartNode,
+		Args: cobrautils.ExactArgs(1),
+	}
+}
+
+func restartNode(_ *cobra.Command, args []string) error {
+	nodeName := args[0]
+	return withNodes(func(ctx context.Context, nodes *localnet.Nodes) error {
+		if err := nodes.Restart(ctx, nodeName); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Node %s restarted", nodeName)
+		return nil
+	})
+}
@@ -0,0 +1,34 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+// avalanche network node resume
+func newResumeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume [nodeName]",
+		Short: "Resumes a stopped or paused local network node",
+		Long:  `Resumes a local network node previously stopped or paused.`,
+		RunE:  resumeNode,
+		Args:  cobrautils.ExactArgs(1),
+	}
+}
+
+func resumeNode(_ *cobra.Command, args []string) error {
+	nodeName := args[0]
+	return withNodes(func(ctx context.Context, nodes *localnet.Nodes) error {
+		if err := nodes.Resume(ctx, nodeName); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Node %s resumed", nodeName)
+		return nil
+	})
+}
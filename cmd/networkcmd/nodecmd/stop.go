@@ -0,0 +1,35 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package nodecmd
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+// avalanche network node stop
+func newStopCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop [nodeName]",
+		Short: "Gracefully stops a local network node",
+		Long: `Gracefully stops a local network node. The node is kept on the network, with its
+database, so it can be brought back with avalanche network node resume.`,
+		RunE: stopNode,
+		Args: cobrautils.ExactArgs(1),
+	}
+}
+
+func stopNode(_ *cobra.Command, args []string) error {
+	nodeName := args[0]
+	return withNodes(func(ctx context.Context, nodes *localnet.Nodes) error {
+		if err := nodes.Stop(ctx, nodeName); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Node %s stopped", nodeName)
+		return nil
+	})
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package snapshotcmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var force bool
+
+// avalanche network snapshot delete
+func newDeleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete [snapshotName]",
+		Short: "Deletes a local network snapshot",
+		Long:  "Deletes a local network snapshot. Snapshots in use by the running network can't be deleted.",
+		RunE:  deleteSnapshot,
+		Args:  cobrautils.ExactArgs(1),
+	}
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "delete without asking for confirmation")
+	return cmd
+}
+
+func deleteSnapshot(_ *cobra.Command, args []string) error {
+	snapshotName := args[0]
+	if _, err := localnet.GetSnapshot(app, app.GetSnapshotsDir(), snapshotName); err != nil {
+		return err
+	}
+	if err := checkSnapshotNotInUse(snapshotName); err != nil {
+		return err
+	}
+	if !force {
+		yes, err := app.Prompt.CaptureYesNo(fmt.Sprintf("Are you sure you want to delete snapshot %s?", snapshotName))
+		if err != nil {
+			return err
+		}
+		if !yes {
+			return nil
+		}
+	}
+	if err := localnet.DeleteSnapshot(app.GetSnapshotsDir(), snapshotName); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Snapshot %s deleted", snapshotName)
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package snapshotcmd
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+const timeFormat = "2006-01-02 15:04:05"
+
+// avalanche network snapshot describe
+func newDescribeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe [snapshotName]",
+		Short: "Shows the details of a local network snapshot",
+		Long: `Shows the details of a local network snapshot: its creation time, the avalanchego
+version it was saved with, and the blockchains deployed into it.`,
+		RunE: describe,
+		Args: cobrautils.ExactArgs(1),
+	}
+}
+
+func describe(_ *cobra.Command, args []string) error {
+	snapshot, err := localnet.GetSnapshot(app, app.GetSnapshotsDir(), args[0])
+	if err != nil {
+		return err
+	}
+	printSnapshot(snapshot)
+	return nil
+}
+
+func printSnapshot(snapshot localnet.Snapshot) {
+	avalancheGoVersion := snapshot.AvalancheGoVersion
+	if avalancheGoVersion == "" {
+		avalancheGoVersion = "unknown"
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Append([]string{"Name", snapshotDisplayName(snapshot.Name)})
+	table.Append([]string{"Created", snapshot.CreatedAt.Format(timeFormat)})
+	table.Append([]string{"AvalancheGo Version", avalancheGoVersion})
+	table.Append([]string{"Nodes", strconv.Itoa(snapshot.NumNodes)})
+	table.Append([]string{"Size", formatSize(snapshot.Size)})
+	if snapshot.CChainTeleporterMessengerAddress != "" {
+		table.Append([]string{"C-Chain Teleporter Messenger", snapshot.CChainTeleporterMessengerAddress})
+	}
+	table.Append([]string{"Path", snapshot.Path})
+	table.Render()
+	ux.Logger.PrintToUser("")
+	if len(snapshot.Blockchains) == 0 {
+		ux.Logger.PrintToUser("No blockchains deployed")
+		return
+	}
+	table = tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Blockchain", "Blockchain ID", "Teleporter Messenger"})
+	for _, blockchain := range snapshot.Blockchains {
+		teleporterMessenger := blockchain.TeleporterMessengerAddress
+		if teleporterMessenger == "" {
+			teleporterMessenger = "-"
+		}
+		table.Append([]string{blockchain.Name, blockchain.BlockchainID, teleporterMessenger})
+	}
+	table.Render()
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package snapshotcmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var exportOutput string
+
+// avalanche network snapshot export
+func newExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [snapshotName]",
+		Short: "Exports a local network snapshot into a portable archive",
+		Long: `Exports a local network snapshot into a tar.gz archive, together with the CLI configs
+and VM binaries of the blockchains deployed into it, so it can be imported on another
+machine with avalanche network snapshot import.
+
+A <archive>.sha256 checksum file is written next to the archive, and must be shared with it.`,
+		RunE: export,
+		Args: cobrautils.ExactArgs(1),
+	}
+	cmd.Flags().StringVarP(&exportOutput, "output", "o", "", "archive path (defaults to <snapshotName>.tar.gz)")
+	return cmd
+}
+
+func export(_ *cobra.Command, args []string) error {
+	snapshotName := args[0]
+	if err := checkSnapshotNotInUse(snapshotName); err != nil {
+		return err
+	}
+	archivePath := exportOutput
+	if archivePath == "" {
+		archivePath = snapshotName + localnet.SnapshotArchiveExtension
+	}
+	archivePath = utils.ExpandHome(archivePath)
+	if utils.FileExists(archivePath) {
+		return fmt.Errorf("file %s already exists", archivePath)
+	}
+	ux.Logger.PrintToUser("Exporting snapshot %s...", snapshotName)
+	checksum, err := localnet.ExportSnapshot(app, snapshotName, archivePath)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Snapshot exported to %s", archivePath)
+	ux.Logger.PrintToUser("SHA256: %s (saved to %s)", checksum, archivePath+localnet.SnapshotChecksumExtension)
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package snapshotcmd
+
+import (
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importName  string
+	importForce bool
+)
+
+// avalanche network snapshot import
+func newImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [archivePath]",
+		Short: "Imports a local network snapshot from an archive",
+		Long: `Imports a local network snapshot from an archive created by avalanche network snapshot export.
+
+The archive is verified against the <archive>.sha256 checksum file next to it. The configs and
+VM binaries of the blockchains it includes are installed, unless a blockchain with the same
+name already exists. The imported snapshot can be started with
+avalanche network start --snapshot-name <snapshotName>.`,
+		RunE: importSnapshot,
+		Args: cobrautils.ExactArgs(1),
+	}
+	cmd.Flags().StringVar(&importName, "snapshot-name", "", "name to save the snapshot as (defaults to its exported name)")
+	cmd.Flags().BoolVar(&importForce, "force", false, "overwrite an existing snapshot with the same name")
+	return cmd
+}
+
+func importSnapshot(_ *cobra.Command, args []string) error {
+	archivePath := utils.ExpandHome(args[0])
+	if importName != "" {
+		if err := checkSnapshotNotInUse(importName); err != nil {
+			return err
+		}
+	}
+	ux.Logger.PrintToUser("Importing snapshot from %s...", archivePath)
+	result, err := localnet.ImportSnapshot(app, archivePath, importName, importForce)
+	if err != nil {
+		return err
+	}
+	for _, blockchainName := range result.ImportedBlockchains {
+		ux.Logger.PrintToUser("Blockchain %s config imported", blockchainName)
+	}
+	for _, blockchainName := range result.SkippedBlockchains {
+		ux.Logger.PrintToUser("Blockchain %s already exists, keeping the current config", blockchainName)
+	}
+	ux.Logger.PrintToUser("Snapshot %s imported", result.Snapshot.Name)
+	ux.Logger.PrintToUser("")
+	printSnapshot(result.Snapshot)
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Start it with: avalanche network start --snapshot-name %s", result.Snapshot.Name)
+	return nil
+}
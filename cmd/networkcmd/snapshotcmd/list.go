@@ -0,0 +1,55 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package snapshotcmd
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// avalanche network snapshot list
+func newListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists the local network snapshots",
+		Long:  "Lists the local network snapshots, with their creation time, size and number of blockchains.",
+		RunE:  list,
+		Args:  cobrautils.ExactArgs(0),
+	}
+}
+
+func list(*cobra.Command, []string) error {
+	snapshotNames, err := localnet.GetSnapshotNames(app.GetSnapshotsDir())
+	if err != nil {
+		return err
+	}
+	if len(snapshotNames) == 0 {
+		ux.Logger.PrintToUser("No snapshots found")
+		return nil
+	}
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Name", "Created", "AvalancheGo", "Nodes", "Blockchains", "Size"})
+	for _, snapshotName := range snapshotNames {
+		snapshot, err := localnet.GetSnapshot(app, app.GetSnapshotsDir(), snapshotName)
+		if err != nil {
+			table.Append([]string{snapshotDisplayName(snapshotName), "invalid snapshot", "", "", "", ""})
+			continue
+		}
+		table.Append([]string{
+			snapshotDisplayName(snapshotName),
+			snapshot.CreatedAt.Format(timeFormat),
+			snapshot.AvalancheGoVersion,
+			strconv.Itoa(snapshot.NumNodes),
+			strconv.Itoa(len(snapshot.Blockchains)),
+			formatSize(snapshot.Size),
+		})
+	}
+	table.Render()
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package snapshotcmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/spf13/cobra"
+)
+
+var app *application.Avalanche
+
+// avalanche network snapshot
+func NewCmd(injectedApp *application.Avalanche) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Manage local network snapshots",
+		Long: `The snapshot command suite provides a collection of tools for managing the
+snapshots saved by network stop, and loaded by network start.
+
+Snapshots can be exported into a portable archive, and imported on another
+machine, to share a ready to use local network with its blockchains deployed.`,
+		RunE: cobrautils.CommandSuiteUsage,
+		Args: cobrautils.ExactArgs(0),
+	}
+	app = injectedApp
+	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newDescribeCmd())
+	cmd.AddCommand(newDeleteCmd())
+	cmd.AddCommand(newExportCmd())
+	cmd.AddCommand(newImportCmd())
+	return cmd
+}
+
+// fails if the running local network is saving its state into [snapshotName]
+func checkSnapshotNotInUse(snapshotName string) error {
+	clusterInfo, err := localnet.GetClusterInfo()
+	if err != nil || clusterInfo == nil {
+		// no network running
+		return nil
+	}
+	snapshotPath := localnet.GetSnapshotPath(app.GetSnapshotsDir(), snapshotName)
+	if filepath.Clean(clusterInfo.GetRootDataDir()) == filepath.Clean(snapshotPath) {
+		return fmt.Errorf("snapshot %s is in use by the running network. stop it first with avalanche network stop", snapshotName)
+	}
+	return nil
+}
+
+func snapshotDisplayName(snapshotName string) string {
+	if snapshotName == constants.DefaultSnapshotName {
+		return snapshotName + " (default)"
+	}
+	return snapshotName
+}
+
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanche-network-runner/client"
 	"github.com/ava-labs/avalanche-network-runner/server"
 	anrutils "github.com/ava-labs/avalanche-network-runner/utils"
+	anrconstants "github.com/ava-labs/avalanche-network-runner/utils/constants"
 	"github.com/spf13/cobra"
 )
 
@@ -27,6 +28,7 @@ var (
 	userProvidedAvagoVersion string
 	snapshotName             string
 	avagoBinaryPath          string
+	topologyOptions          localnet.TopologyOptions
 )
 
 const (
@@ -42,7 +44,15 @@ func newStartCmd() *cobra.Command {
 
 By default, the command loads the default snapshot. If you provide the --snapshot-name
 flag, the network loads that snapshot instead. The command fails if the local network is
-already running.`,
+already running.
+
+If you provide any of --num-nodes, --node-config, --per-node-config or --staking-keys, a new
+network is created with the given nodes, all of them validators, and saved into the snapshot
+given by --snapshot-name. Starting that snapshot again keeps the same topology, and local
+blockchain deploys create a new subnet validated by all its nodes.
+
+Use --name to start a named local network, isolated from the default one, with its own
+backend, ports, snapshots and plugins. Other commands target it with --local-network.`,
 
 		RunE: StartNetwork,
 		Args: cobrautils.ExactArgs(0),
@@ -51,10 +61,24 @@ already running.`,
 	cmd.Flags().StringVar(&userProvidedAvagoVersion, "avalanchego-version", latest, "use this version of avalanchego (ex: v1.17.12)")
 	cmd.Flags().StringVar(&avagoBinaryPath, "avalanchego-path", "", "use this avalanchego binary path")
 	cmd.Flags().StringVar(&snapshotName, "snapshot-name", constants.DefaultSnapshotName, "name of snapshot to use to start the network from")
+	cmd.Flags().Uint32Var(&topologyOptions.NumNodes, "num-nodes", 0, "create a new network with this number of nodes")
+	cmd.Flags().StringVar(&topologyOptions.NodeConfig, "node-config", "", "create a new network with this avalanchego config for all nodes (file path or JSON content)")
+	cmd.Flags().StringVar(&topologyOptions.PerNodeConfigDir, "per-node-config", "", "create a new network with the avalanchego configs found at this dir as <nodeName>.json (ex: node3.json)")
+	cmd.Flags().StringVar(&topologyOptions.StakingKeysDir, "staking-keys", "", "create a new network with the staking keys found at this dir as <nodeName>/{staker.key,staker.crt,signer.key}")
 
 	return cmd
 }
 
+// CallStartNetwork starts the local network from the default snapshot, using the given
+// avalanchego version
+func CallStartNetwork(avagoVersion string) error {
+	userProvidedAvagoVersion = avagoVersion
+	avagoBinaryPath = ""
+	snapshotName = constants.DefaultSnapshotName
+	topologyOptions = localnet.TopologyOptions{}
+	return StartNetwork(nil, nil)
+}
+
 func StartNetwork(*cobra.Command, []string) error {
 	var (
 		err          error
@@ -90,6 +114,10 @@ func StartNetwork(*cobra.Command, []string) error {
 		return err
 	}
 
+	if bootstrapped && topologyOptions.IsSet() {
+		return fmt.Errorf("the local network is already running. stop it with avalanche network stop before creating a new one")
+	}
+
 	if bootstrapped {
 		if !needsRestart {
 			ux.Logger.PrintToUser("Network has already been booted.")
@@ -104,11 +132,23 @@ func StartNetwork(*cobra.Command, []string) error {
 	}
 
 	var startMsg string
-	if snapshotName == constants.DefaultSnapshotName {
+	if topologyOptions.IsSet() {
+		numNodes, err := createTopologySnapshot(avalancheGoBinPath)
+		if err != nil {
+			return err
+		}
+		if numNodes == 0 {
+			return nil
+		}
+		startMsg = fmt.Sprintf("Starting a new local network with %d nodes...", numNodes)
+	} else if snapshotName == constants.DefaultSnapshotName {
 		startMsg = "Starting previously deployed and stopped snapshot"
 	} else {
 		startMsg = fmt.Sprintf("Starting previously deployed and stopped snapshot %s...", snapshotName)
 	}
+	if instance := models.GetLocalNetworkInstance(); !instance.IsDefault() {
+		startMsg = fmt.Sprintf("[%s] %s", instance.Name, startMsg)
+	}
 	ux.Logger.PrintToUser(startMsg)
 
 	autoSave := app.Conf.GetConfigBoolValue(constants.ConfigSnapshotsAutoSaveKey)
@@ -145,6 +185,10 @@ func StartNetwork(*cobra.Command, []string) error {
 		loadSnapshotOpts = append(loadSnapshotOpts, client.WithGlobalNodeConfig(configStr))
 	}
 
+	if err := localnet.SetSnapshotNodesPorts(app.GetSnapshotsDir(), snapshotName); err != nil {
+		return err
+	}
+
 	ux.Logger.PrintToUser("Booting Network. Wait until healthy...")
 	resp, err := cli.LoadSnapshot(
 		ctx,
@@ -183,6 +227,37 @@ func StartNetwork(*cobra.Command, []string) error {
 	return nil
 }
 
+// creates a snapshot with the network topology given by the user, returning its number of nodes,
+// or zero if the user cancels replacing an existing snapshot
+func createTopologySnapshot(avalancheGoBinPath string) (int, error) {
+	var defaultNumNodes uint32
+	if app.Conf.GetConfigBoolValue(constants.ConfigSingleNodeEnabledKey) {
+		defaultNumNodes = 1
+	} else {
+		defaultNumNodes = anrconstants.DefaultNumNodes
+	}
+	cfg, topology, err := localnet.NewTopologyNetworkConfig(avalancheGoBinPath, topologyOptions, defaultNumNodes)
+	if err != nil {
+		return 0, err
+	}
+	if utils.DirectoryExists(localnet.GetSnapshotPath(app.GetSnapshotsDir(), snapshotName)) {
+		yes, err := app.Prompt.CaptureYesNo(fmt.Sprintf(
+			"Snapshot %s already exists. Do you want to replace it with the new network, losing its state?",
+			snapshotName,
+		))
+		if err != nil {
+			return 0, err
+		}
+		if !yes {
+			return 0, nil
+		}
+	}
+	if err := localnet.CreateTopologySnapshot(app.GetSnapshotsDir(), snapshotName, cfg, topology); err != nil {
+		return 0, err
+	}
+	return topology.NumNodes, nil
+}
+
 func determineAvagoVersion(userProvidedAvagoVersion string) (string, error) {
 	// a specific user provided version should override this calculation, so just return
 	if userProvidedAvagoVersion != latest {
@@ -211,15 +286,15 @@ func determineAvagoVersion(userProvidedAvagoVersion string) (string, error) {
 
 		// if you have a custom vm, you must provide the version explicitly
 		// if you upgrade from subnet-evm to a custom vm, the RPC version will be 0
-		if sc.VM == models.CustomVM || sc.Networks[models.Local.String()].RPCVersion == 0 {
+		if sc.VM == models.CustomVM || sc.Networks[models.NewLocalNetwork().Name()].RPCVersion == 0 {
 			continue
 		}
 
 		if currentRPCVersion == -1 {
-			currentRPCVersion = sc.Networks[models.Local.String()].RPCVersion
+			currentRPCVersion = sc.Networks[models.NewLocalNetwork().Name()].RPCVersion
 		}
 
-		if sc.Networks[models.Local.String()].RPCVersion != currentRPCVersion {
+		if sc.Networks[models.NewLocalNetwork().Name()].RPCVersion != currentRPCVersion {
 			return "", fmt.Errorf(
 				"RPC version mismatch. Expected %d, got %d for Subnet %s. Upgrade all subnets to the same RPC version to launch the network",
 				currentRPCVersion,
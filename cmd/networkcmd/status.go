@@ -5,6 +5,7 @@ package networkcmd
 import (
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanche-network-runner/server"
 	"github.com/spf13/cobra"
@@ -33,6 +34,9 @@ func networkStatus(*cobra.Command, []string) error {
 	}
 	if clusterInfo != nil {
 		ux.Logger.PrintToUser("Network is Up:")
+		if instance := models.GetLocalNetworkInstance(); !instance.IsDefault() {
+			ux.Logger.PrintToUser("  Name: %s", instance.Name)
+		}
 		ux.Logger.PrintToUser("  Number of Nodes: %d", len(clusterInfo.NodeNames))
 		ux.Logger.PrintToUser("  Number of Custom VMs: %d", len(clusterInfo.CustomChains))
 		ux.Logger.PrintToUser("  Network Healthy: %t", clusterInfo.Healthy)
@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanche-cli/cmd/blockchaincmd"
 	"github.com/ava-labs/avalanche-cli/cmd/configcmd"
 	"github.com/ava-labs/avalanche-cli/cmd/contractcmd"
+	"github.com/ava-labs/avalanche-cli/cmd/devcmd"
 	"github.com/ava-labs/avalanche-cli/cmd/interchaincmd"
 	"github.com/ava-labs/avalanche-cli/cmd/interchaincmd/tokentransferrercmd"
 	"github.com/ava-labs/avalanche-cli/cmd/keycmd"
@@ -46,6 +47,8 @@ var (
 	Version   = ""
 	cfgFile   string
 	skipCheck bool
+	// named local network to operate on
+	localNetworkName string
 )
 
 func NewRootCmd() *cobra.Command {
@@ -74,11 +77,16 @@ in with avalanche subnet create myNewSubnet.`,
 		StringVar(&logLevel, "log-level", "ERROR", "log level for the application")
 	rootCmd.PersistentFlags().
 		BoolVar(&skipCheck, constants.SkipUpdateFlag, false, "skip check for new versions")
+	rootCmd.PersistentFlags().
+		StringVar(&localNetworkName, constants.LocalNetworkFlag, "", fmt.Sprintf("operate on the given named local network (default is $%s, or the default local network)", constants.LocalNetworkEnvVar))
 
 	// add sub commands
 	rootCmd.AddCommand(blockchaincmd.NewCmd(app))
 	rootCmd.AddCommand(primarycmd.NewCmd(app))
-	rootCmd.AddCommand(networkcmd.NewCmd(app))
+	networkCmd := networkcmd.NewCmd(app)
+	networkCmd.PersistentFlags().
+		StringVar(&localNetworkName, "name", "", "operate on the given named local network (alias of --"+constants.LocalNetworkFlag+")")
+	rootCmd.AddCommand(networkCmd)
 	rootCmd.AddCommand(keycmd.NewCmd(app))
 
 	// add hidden backend command
@@ -127,6 +135,9 @@ Deprecation notice: use 'avalanche blockchain'`
 	// add contract command
 	rootCmd.AddCommand(contractcmd.NewCmd(app))
 
+	// add dev command
+	rootCmd.AddCommand(devcmd.NewCmd(app))
+
 	cobrautils.ConfigureRootCmd(rootCmd)
 
 	return rootCmd
@@ -148,6 +159,15 @@ func createApp(cmd *cobra.Command, _ []string) error {
 
 	initConfig()
 
+	if localNetworkName == "" {
+		localNetworkName = os.Getenv(constants.LocalNetworkEnvVar)
+	}
+	if localNetworkName != "" {
+		if err := app.SetLocalNetwork(localNetworkName); err != nil {
+			return err
+		}
+	}
+
 	if err := migrations.RunMigrations(app); err != nil {
 		return err
 	}
@@ -0,0 +1,111 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package messagecmd
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	addFeeToken  string
+	addFeeAmount string
+)
+
+// avalanche teleporter message add-fee
+func newAddFeeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-fee [messageID]",
+		Short: "Adds to the relayer fee of a teleporter message",
+		Long: `Increases the fee offered to relayers for delivering a teleporter message not yet
+delivered, so as to incentivize its delivery.
+
+The fee is paid in the ERC20 token given by --fee-token (defaults to the message fee token),
+that must be the message fee token if the message already has a fee. The messenger is
+approved to spend --amount of it before adding it.`,
+		RunE: addFee,
+		Args: cobrautils.ExactArgs(1),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &messageFlags.Network, true, messageSupportedNetworkOptions)
+	contract.AddPrivateKeyFlagsToCmd(cmd, &messageFlags.PrivateKeyFlags, "to pay the fee")
+	addSourceFlagsToCmd(cmd)
+	addFromBlockFlagToCmd(cmd)
+	cmd.Flags().StringVar(&addFeeToken, "fee-token", "", "ERC20 token address to pay the fee with")
+	cmd.Flags().StringVar(&addFeeAmount, "amount", "", "fee amount to add, in the token smallest unit")
+	return cmd
+}
+
+func addFee(cmd *cobra.Command, args []string) error {
+	fromBlock := getFromBlock(cmd)
+	messageID, err := parseMessageID(args[0])
+	if err != nil {
+		return err
+	}
+	network, err := getNetwork()
+	if err != nil {
+		return err
+	}
+	source, event, _, err := findMessageSource(network, messageID, fromBlock)
+	if err != nil {
+		return err
+	}
+	messageHash, err := teleporter.GetMessageHash(source.endpoint, source.messenger, messageID)
+	if err != nil {
+		return err
+	}
+	if messageHash == (common.Hash{}) {
+		return fmt.Errorf("message %s receipt was already received on %s: fees can no longer be added", common.Hash(messageID).Hex(), source.name)
+	}
+	feeToken := event.FeeInfo.FeeTokenAddress
+	if addFeeToken != "" {
+		if err := prompts.ValidateAddress(addFeeToken); err != nil {
+			return fmt.Errorf("failure validating fee token address %s: %w", addFeeToken, err)
+		}
+		feeToken = common.HexToAddress(addFeeToken)
+	}
+	if feeToken == (common.Address{}) {
+		feeToken, err = app.Prompt.CaptureAddress("Which ERC20 token do you want to pay the fee with?")
+		if err != nil {
+			return err
+		}
+	}
+	if event.FeeInfo.Amount.Sign() > 0 && feeToken != event.FeeInfo.FeeTokenAddress {
+		return fmt.Errorf("message fee is paid in token %s: can't add fee in token %s", event.FeeInfo.FeeTokenAddress.Hex(), feeToken.Hex())
+	}
+	if addFeeAmount == "" {
+		addFeeAmount, err = app.Prompt.CaptureString("Fee amount to add (in the token smallest unit)")
+		if err != nil {
+			return err
+		}
+	}
+	amount, b := new(big.Int).SetString(addFeeAmount, 10)
+	if !b || amount.Sign() <= 0 {
+		return fmt.Errorf("invalid fee amount %q: expected a positive integer", addFeeAmount)
+	}
+	privateKey, err := getPrivateKey(network, source, "add the fee")
+	if err != nil {
+		return err
+	}
+	if _, _, err := teleporter.AddFeeAmount(
+		source.endpoint,
+		source.messenger,
+		privateKey,
+		messageID,
+		feeToken,
+		amount,
+	); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Fee of %s of token %s successfully added to message %s", amount, feeToken.Hex(), common.Hash(messageID).Hex())
+	return nil
+}
@@ -0,0 +1,204 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package messagecmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// teleporter enabled blockchain known by CLI
+type messengerChain struct {
+	name         string
+	cChain       bool
+	blockchainID ids.ID
+	endpoint     string
+	messenger    common.Address
+}
+
+func getNetwork() (models.Network, error) {
+	subnetName := ""
+	for _, chainFlags := range []contract.ChainFlags{messageFlags.sourceFlags, messageFlags.destinationFlags} {
+		if chainFlags.SubnetName != "" {
+			subnetName = chainFlags.SubnetName
+		}
+	}
+	return networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		messageFlags.Network,
+		true,
+		false,
+		messageSupportedNetworkOptions,
+		subnetName,
+	)
+}
+
+// parses a message id given either hex encoded or in CB58 format
+func parseMessageID(messageIDStr string) (ids.ID, error) {
+	if strings.HasPrefix(messageIDStr, "0x") {
+		bs := common.FromHex(messageIDStr)
+		if len(bs) != common.HashLength {
+			return ids.Empty, fmt.Errorf("invalid message ID %s: expected %d bytes", messageIDStr, common.HashLength)
+		}
+		return ids.ID(bs), nil
+	}
+	messageID, err := ids.FromString(messageIDStr)
+	if err != nil {
+		return ids.Empty, fmt.Errorf("invalid message ID %s: %w", messageIDStr, err)
+	}
+	return messageID, nil
+}
+
+func getMessengerChain(
+	network models.Network,
+	chainFlags contract.ChainFlags,
+) (messengerChain, error) {
+	endpoint, name, _, blockchainID, messengerAddress, _, _, err := teleporter.GetSubnetParams(
+		app,
+		network,
+		chainFlags.SubnetName,
+		chainFlags.CChain,
+	)
+	if err != nil {
+		return messengerChain{}, err
+	}
+	return messengerChain{
+		name:         name,
+		cChain:       chainFlags.CChain,
+		blockchainID: blockchainID,
+		endpoint:     endpoint,
+		messenger:    common.HexToAddress(messengerAddress),
+	}, nil
+}
+
+// returns all teleporter enabled blockchains of [network] known by CLI
+func getMessengerChains(network models.Network) ([]messengerChain, error) {
+	chains := []messengerChain{}
+	if chain, err := getMessengerChain(network, contract.ChainFlags{CChain: true}); err == nil {
+		chains = append(chains, chain)
+	}
+	subnetNames, err := app.GetSubnetNamesOnNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	for _, subnetName := range subnetNames {
+		if chain, err := getMessengerChain(network, contract.ChainFlags{SubnetName: subnetName}); err == nil {
+			chains = append(chains, chain)
+		}
+	}
+	return chains, nil
+}
+
+// returns the blockchain given by [chainFlags], or the teleporter enabled one
+// of [network] with [blockchainID] if no flags are given
+func getChain(
+	network models.Network,
+	chainFlags contract.ChainFlags,
+	blockchainID ids.ID,
+) (messengerChain, error) {
+	if chainFlags.SubnetName != "" || chainFlags.CChain {
+		return getMessengerChain(network, chainFlags)
+	}
+	chains, err := getMessengerChains(network)
+	if err != nil {
+		return messengerChain{}, err
+	}
+	for _, chain := range chains {
+		if chain.blockchainID == blockchainID {
+			return chain, nil
+		}
+	}
+	return messengerChain{}, fmt.Errorf("blockchain %s is not a teleporter enabled blockchain known by CLI on %s", blockchainID, network.Name())
+}
+
+// finds the source blockchain of [messageID], and the event emitted when it was sent,
+// looking for it starting at [fromBlock]. If the source is not given by flags, all
+// teleporter enabled blockchains are checked
+func findMessageSource(
+	network models.Network,
+	messageID ids.ID,
+	fromBlock uint64,
+) (messengerChain, *teleporter.TeleporterMessengerSendCrossChainMessage, types.Log, error) {
+	chains := []messengerChain{}
+	if messageFlags.sourceFlags.SubnetName != "" || messageFlags.sourceFlags.CChain {
+		chain, err := getMessengerChain(network, messageFlags.sourceFlags)
+		if err != nil {
+			return messengerChain{}, nil, types.Log{}, err
+		}
+		chains = append(chains, chain)
+	} else {
+		var err error
+		chains, err = getMessengerChains(network)
+		if err != nil {
+			return messengerChain{}, nil, types.Log{}, err
+		}
+	}
+	for _, chain := range chains {
+		event, log, err := teleporter.GetMessageSend(chain.endpoint, chain.messenger, messageID, fromBlock)
+		if errors.Is(err, teleporter.ErrMessageNotFound) {
+			continue
+		}
+		if err != nil {
+			return messengerChain{}, nil, types.Log{}, err
+		}
+		return chain, event, log, nil
+	}
+	return messengerChain{}, nil, types.Log{}, fmt.Errorf("message %s not found on %s: %w", common.Hash(messageID).Hex(), network.Name(), teleporter.ErrMessageNotFound)
+}
+
+// gets the key to sign txs on [chain], from flags or prompting the user
+func getPrivateKey(
+	network models.Network,
+	chain messengerChain,
+	goal string,
+) (string, error) {
+	subnetName := ""
+	if !chain.cChain {
+		subnetName = chain.name
+	}
+	genesisAddress, genesisPrivateKey, err := contract.GetEVMSubnetPrefundedKey(
+		app,
+		network,
+		subnetName,
+		chain.cChain,
+		"",
+	)
+	if err != nil {
+		return "", err
+	}
+	privateKey, err := contract.GetPrivateKeyFromFlags(
+		app,
+		messageFlags.PrivateKeyFlags,
+		genesisPrivateKey,
+	)
+	if err != nil {
+		return "", err
+	}
+	if privateKey == "" {
+		ux.Logger.PrintToUser("A private key is needed to %s on %s", goal, chain.name)
+		privateKey, err = prompts.PromptPrivateKey(
+			app.Prompt,
+			goal,
+			app.GetKeyDir(),
+			app.GetKey,
+			genesisAddress,
+			genesisPrivateKey,
+		)
+		if err != nil {
+			return "", err
+		}
+	}
+	return privateKey, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package messagecmd
+
+import (
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/spf13/cobra"
+)
+
+type MessageFlags struct {
+	Network          networkoptions.NetworkFlags
+	PrivateKeyFlags  contract.PrivateKeyFlags
+	sourceFlags      contract.ChainFlags
+	destinationFlags contract.ChainFlags
+	fromBlock        uint64
+}
+
+var (
+	app                            *application.Avalanche
+	messageSupportedNetworkOptions = []networkoptions.NetworkOption{
+		networkoptions.Local,
+		networkoptions.Devnet,
+		networkoptions.Fuji,
+		networkoptions.Mainnet,
+	}
+	messageFlags MessageFlags
+)
+
+// avalanche teleporter message
+func NewCmd(injectedApp *application.Avalanche) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "message",
+		Short: "Inspect and manage sent teleporter messages",
+		Long: `The message command suite provides a collection of tools for tracking teleporter
+messages already sent, and for acting on them when their delivery stalls.
+
+Messages are identified by the message ID found on the SendCrossChainMessage event
+of the source blockchain, either hex encoded or in CB58 format.`,
+		RunE: cobrautils.CommandSuiteUsage,
+	}
+	app = injectedApp
+	// teleporter message status
+	cmd.AddCommand(newStatusCmd())
+	// teleporter message retry
+	cmd.AddCommand(newRetryCmd())
+	// teleporter message receipts
+	cmd.AddCommand(newReceiptsCmd())
+	// teleporter message add-fee
+	cmd.AddCommand(newAddFeeCmd())
+	return cmd
+}
+
+func addSourceFlagsToCmd(cmd *cobra.Command) {
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&messageFlags.sourceFlags,
+		"set the message source",
+		"source-subnet",
+		"c-chain-source",
+	)
+}
+
+func addDestinationFlagsToCmd(cmd *cobra.Command) {
+	contract.AddChainFlagsToCmd(
+		cmd,
+		&messageFlags.destinationFlags,
+		"set the message destination",
+		"destination-subnet",
+		"c-chain-destination",
+	)
+}
+
+func addFromBlockFlagToCmd(cmd *cobra.Command) {
+	cmd.Flags().Uint64Var(&messageFlags.fromBlock, "from-block", 0, "look for message events starting at the given block (default: the last 100000 blocks)")
+}
+
+// returns the block to start looking for message events at: --from-block if given,
+// or else the start of the recent blocks window of each blockchain
+func getFromBlock(cmd *cobra.Command) uint64 {
+	if cmd.Flags().Changed("from-block") {
+		return messageFlags.fromBlock
+	}
+	return evm.FromRecentBlocks
+}
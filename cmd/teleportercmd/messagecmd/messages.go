@@ -0,0 +1,165 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package messagecmd
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/spf13/cobra"
+)
+
+const cChainName = "c-chain"
+
+var (
+	sourceChainName      string
+	destinationChainName string
+)
+
+// avalanche teleporter messages
+func NewMessagesCmd(injectedApp *application.Avalanche) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "messages",
+		Short: "Lists the teleporter messages sent between blockchains",
+		Long: `Lists the teleporter messages sent between the teleporter enabled blockchains known
+by CLI, pairing each send with its delivery at the destination.
+
+Messages are shown as Pending (not yet delivered), Delivered, or Failed (delivered but
+its execution at the destination address failed), together with the time elapsed
+between the send and the delivery.
+
+--source and --dest restrict the listing to the given blockchains, given by subnet name,
+or as c-chain for the C-Chain. Message events are looked for starting at --from-block
+on every blockchain, or on their last 100000 blocks if not given.`,
+		RunE: messages,
+		Args: cobrautils.ExactArgs(0),
+	}
+	app = injectedApp
+	networkoptions.AddNetworkFlagsToCmd(cmd, &messageFlags.Network, true, messageSupportedNetworkOptions)
+	cmd.Flags().StringVar(&sourceChainName, "source", "", "only list messages sent from the given blockchain")
+	cmd.Flags().StringVar(&destinationChainName, "dest", "", "only list messages sent to the given blockchain")
+	addFromBlockFlagToCmd(cmd)
+	return cmd
+}
+
+// converts a --source/--dest blockchain name into chain flags
+func chainNameToFlags(chainName string) contract.ChainFlags {
+	if strings.EqualFold(chainName, cChainName) {
+		return contract.ChainFlags{CChain: true}
+	}
+	return contract.ChainFlags{SubnetName: chainName}
+}
+
+// returns the blockchain given by [chainName], or all teleporter enabled
+// blockchains if it is empty
+func getExploredChains(
+	network models.Network,
+	chainName string,
+) ([]messengerChain, error) {
+	if chainName == "" {
+		return getMessengerChains(network)
+	}
+	chain, err := getMessengerChain(network, chainNameToFlags(chainName))
+	if err != nil {
+		return nil, err
+	}
+	return []messengerChain{chain}, nil
+}
+
+func toMessengers(chains []messengerChain) []teleporter.Messenger {
+	messengers := []teleporter.Messenger{}
+	for _, chain := range chains {
+		messengers = append(messengers, teleporter.Messenger{
+			BlockchainID:     chain.blockchainID,
+			RPCURL:           chain.endpoint,
+			MessengerAddress: chain.messenger,
+		})
+	}
+	return messengers
+}
+
+func messages(cmd *cobra.Command, _ []string) error {
+	if sourceChainName != "" {
+		messageFlags.sourceFlags = chainNameToFlags(sourceChainName)
+	}
+	if destinationChainName != "" {
+		messageFlags.destinationFlags = chainNameToFlags(destinationChainName)
+	}
+	network, err := getNetwork()
+	if err != nil {
+		return err
+	}
+	allChains, err := getMessengerChains(network)
+	if err != nil {
+		return err
+	}
+	sources, err := getExploredChains(network, sourceChainName)
+	if err != nil {
+		return err
+	}
+	destinations, err := getExploredChains(network, destinationChainName)
+	if err != nil {
+		return err
+	}
+	if len(sources) == 0 || len(destinations) == 0 {
+		ux.Logger.PrintToUser("No teleporter enabled blockchains found on %s", network.Name())
+		return nil
+	}
+	traces, err := teleporter.ExploreMessages(toMessengers(sources), toMessengers(destinations), getFromBlock(cmd))
+	if err != nil {
+		return err
+	}
+	if len(traces) == 0 {
+		ux.Logger.PrintToUser("No teleporter messages found on %s", network.Name())
+		return nil
+	}
+	names := map[ids.ID]string{}
+	for _, chain := range allChains {
+		names[chain.blockchainID] = chain.name
+	}
+	pending, delivered, failed := 0, 0, 0
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Message ID", "Source", "Destination", "Nonce", "Status", "Sent", "Latency", "Send Tx"})
+	table.SetRowLine(true)
+	for _, trace := range traces {
+		latency := "-"
+		switch trace.Status {
+		case teleporter.MessagePending:
+			pending++
+		case teleporter.MessageDelivered:
+			delivered++
+			latency = trace.Latency().String()
+		case teleporter.MessageFailed:
+			failed++
+			latency = trace.Latency().String()
+		}
+		table.Append([]string{
+			common.Hash(trace.MessageID).Hex(),
+			names[trace.SourceBlockchainID],
+			names[trace.DestinationBlockchainID],
+			trace.Nonce.String(),
+			string(trace.Status),
+			trace.SendTime.Local().Format(time.DateTime),
+			latency,
+			trace.SendTx.Hex(),
+		})
+	}
+	table.Render()
+	ux.Logger.PrintToUser("%d messages: %d delivered, %d pending, %d failed", len(traces), delivered, pending, failed)
+	if failed > 0 {
+		ux.Logger.PrintToUser("Failed executions can be retried with avalanche teleporter message retry <messageID>")
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package messagecmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/spf13/cobra"
+)
+
+var sendReceipts bool
+
+// avalanche teleporter message receipts
+func newReceiptsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "receipts [messageID...]",
+		Short: "Lists and sends back the receipts of delivered teleporter messages",
+		Long: `Lists the receipts kept by the destination messenger for the messages delivered from
+the source, that are still waiting to be sent back to it. Receipts are usually sent back
+attached to the next messages sent from the destination to the source, and are needed by
+relayers to redeem their rewards on the source.
+
+With --send, the receipts of the given messages (all the listed ones if none is given) are
+sent back to the source in a new message.`,
+		RunE: receipts,
+		Args: cobrautils.MinimumNArgs(0),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &messageFlags.Network, true, messageSupportedNetworkOptions)
+	contract.AddPrivateKeyFlagsToCmd(cmd, &messageFlags.PrivateKeyFlags, "to pay destination blockchain fees (--send)")
+	addSourceFlagsToCmd(cmd)
+	addDestinationFlagsToCmd(cmd)
+	cmd.Flags().BoolVar(&sendReceipts, "send", false, "send the receipts back to the source")
+	return cmd
+}
+
+func receipts(_ *cobra.Command, args []string) error {
+	if len(args) > 0 && !sendReceipts {
+		return fmt.Errorf("message IDs can only be given together with --send")
+	}
+	messageIDs := []ids.ID{}
+	for _, arg := range args {
+		messageID, err := parseMessageID(arg)
+		if err != nil {
+			return err
+		}
+		messageIDs = append(messageIDs, messageID)
+	}
+	network, err := getNetwork()
+	if err != nil {
+		return err
+	}
+	if messageFlags.sourceFlags.SubnetName == "" && !messageFlags.sourceFlags.CChain {
+		return fmt.Errorf("the message source must be given with --source-subnet or --c-chain-source")
+	}
+	if messageFlags.destinationFlags.SubnetName == "" && !messageFlags.destinationFlags.CChain {
+		return fmt.Errorf("the message destination must be given with --destination-subnet or --c-chain-destination")
+	}
+	source, err := getMessengerChain(network, messageFlags.sourceFlags)
+	if err != nil {
+		return err
+	}
+	destination, err := getMessengerChain(network, messageFlags.destinationFlags)
+	if err != nil {
+		return err
+	}
+	queue, err := teleporter.GetReceiptQueue(destination.endpoint, destination.messenger, source.blockchainID)
+	if err != nil {
+		return err
+	}
+	queuedIDs := []ids.ID{}
+	if len(queue) == 0 {
+		ux.Logger.PrintToUser("No receipts from %s waiting to be sent back to %s", destination.name, source.name)
+	} else {
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Nonce", "Message ID", "Relayer Reward Address"})
+		for _, receipt := range queue {
+			messageID, err := teleporter.CalculateMessageID(
+				destination.endpoint,
+				destination.messenger,
+				source.blockchainID,
+				destination.blockchainID,
+				receipt.ReceivedMessageNonce,
+			)
+			if err != nil {
+				return err
+			}
+			queuedIDs = append(queuedIDs, messageID)
+			table.Append([]string{
+				receipt.ReceivedMessageNonce.String(),
+				common.Hash(messageID).Hex(),
+				receipt.RelayerRewardAddress.Hex(),
+			})
+		}
+		table.Render()
+	}
+	if !sendReceipts {
+		return nil
+	}
+	if len(messageIDs) == 0 {
+		messageIDs = queuedIDs
+	}
+	if len(messageIDs) == 0 {
+		return nil
+	}
+	privateKey, err := getPrivateKey(network, destination, "send the receipts")
+	if err != nil {
+		return err
+	}
+	tx, _, err := teleporter.SendSpecifiedReceipts(
+		destination.endpoint,
+		destination.messenger,
+		privateKey,
+		source.blockchainID,
+		messageIDs,
+	)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("%d receipts sent back to %s at tx %s", len(messageIDs), source.name, tx.Hash().Hex())
+	return nil
+}
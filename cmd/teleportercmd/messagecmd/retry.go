@@ -0,0 +1,85 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package messagecmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/spf13/cobra"
+)
+
+// avalanche teleporter message retry
+func newRetryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "retry [messageID]",
+		Short: "Retries the execution of a delivered teleporter message",
+		Long: `Retries, on the destination, the execution of a teleporter message that was delivered
+but whose execution failed (eg because the destination contract reverted, or because it
+was not given enough gas).
+
+The message contents are obtained from its send event on the source. If the source is not
+given, all teleporter enabled blockchains known by CLI are checked.`,
+		RunE: retry,
+		Args: cobrautils.ExactArgs(1),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &messageFlags.Network, true, messageSupportedNetworkOptions)
+	contract.AddPrivateKeyFlagsToCmd(cmd, &messageFlags.PrivateKeyFlags, "to pay destination blockchain fees")
+	addSourceFlagsToCmd(cmd)
+	addFromBlockFlagToCmd(cmd)
+	return cmd
+}
+
+func retry(cmd *cobra.Command, args []string) error {
+	fromBlock := getFromBlock(cmd)
+	messageID, err := parseMessageID(args[0])
+	if err != nil {
+		return err
+	}
+	network, err := getNetwork()
+	if err != nil {
+		return err
+	}
+	source, event, _, err := findMessageSource(network, messageID, fromBlock)
+	if err != nil {
+		return err
+	}
+	destination, err := getChain(network, contract.ChainFlags{}, ids.ID(event.DestinationBlockchainID))
+	if err != nil {
+		return err
+	}
+	delivery, err := teleporter.GetMessageDelivery(destination.endpoint, destination.messenger, messageID, fromBlock)
+	if err != nil {
+		return err
+	}
+	if !delivery.Received {
+		return fmt.Errorf("message %s has not been delivered to %s yet", common.Hash(messageID).Hex(), destination.name)
+	}
+	if delivery.Executed {
+		return fmt.Errorf("message %s was already successfully executed on %s", common.Hash(messageID).Hex(), destination.name)
+	}
+	privateKey, err := getPrivateKey(network, destination, "retry the message execution")
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Retrying execution of message %s on %s", common.Hash(messageID).Hex(), destination.name)
+	tx, _, err := teleporter.RetryMessageExecution(
+		destination.endpoint,
+		destination.messenger,
+		privateKey,
+		source.blockchainID,
+		event.Message,
+	)
+	if err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Message successfully executed at tx %s", tx.Hash().Hex())
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package messagecmd
+
+import (
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/spf13/cobra"
+)
+
+// avalanche teleporter message status
+func newStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status [messageID]",
+		Short: "Shows the delivery status of a teleporter message",
+		Long: `Shows the delivery status of a teleporter message: the tx that sent it, the tx that
+delivered it to the destination, the relayer that delivered it, and whether its execution
+succeeded.
+
+If the source is not given, all teleporter enabled blockchains known by CLI are checked.
+Message events are looked for starting at --from-block, or on the last 100000 blocks
+if not given.`,
+		RunE: status,
+		Args: cobrautils.ExactArgs(1),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &messageFlags.Network, true, messageSupportedNetworkOptions)
+	addSourceFlagsToCmd(cmd)
+	addFromBlockFlagToCmd(cmd)
+	return cmd
+}
+
+func status(cmd *cobra.Command, args []string) error {
+	fromBlock := getFromBlock(cmd)
+	messageID, err := parseMessageID(args[0])
+	if err != nil {
+		return err
+	}
+	network, err := getNetwork()
+	if err != nil {
+		return err
+	}
+	source, event, log, err := findMessageSource(network, messageID, fromBlock)
+	if err != nil {
+		return err
+	}
+	destinationBlockchainID := ids.ID(event.DestinationBlockchainID)
+	ux.Logger.PrintToUser("Message ID: %s", common.Hash(messageID).Hex())
+	ux.Logger.PrintToUser("Source: %s (%s)", source.name, source.blockchainID)
+	ux.Logger.PrintToUser("  Send Tx: %s (block %d)", log.TxHash.Hex(), log.BlockNumber)
+	ux.Logger.PrintToUser("  Sender: %s", event.Message.OriginSenderAddress.Hex())
+	ux.Logger.PrintToUser("  Nonce: %s", event.Message.MessageNonce)
+	ux.Logger.PrintToUser("  Fee: %s of token %s", event.FeeInfo.Amount, event.FeeInfo.FeeTokenAddress.Hex())
+	ux.Logger.PrintToUser("  Required Gas Limit: %s", event.Message.RequiredGasLimit)
+	if len(event.Message.AllowedRelayerAddresses) > 0 {
+		ux.Logger.PrintToUser("  Allowed Relayers: %s", contract.FormatValue(event.Message.AllowedRelayerAddresses))
+	}
+	destination, err := getChain(network, contract.ChainFlags{}, destinationBlockchainID)
+	if err != nil {
+		ux.Logger.PrintToUser("Destination: %s", destinationBlockchainID)
+		ux.Logger.PrintToUser("  %s", err)
+		return nil
+	}
+	ux.Logger.PrintToUser("Destination: %s (%s)", destination.name, destination.blockchainID)
+	ux.Logger.PrintToUser("  Destination Address: %s", event.Message.DestinationAddress.Hex())
+	delivery, err := teleporter.GetMessageDelivery(destination.endpoint, destination.messenger, messageID, fromBlock)
+	if err != nil {
+		return err
+	}
+	if !delivery.Received {
+		ux.Logger.PrintToUser("  Status: Not Delivered")
+		ux.Logger.PrintToUser("")
+		ux.Logger.PrintToUser("Check the relayer is running, or increase the message fee with avalanche teleporter message add-fee")
+		return nil
+	}
+	ux.Logger.PrintToUser("  Status: Delivered")
+	if delivery.DeliveryTx != (common.Hash{}) {
+		ux.Logger.PrintToUser("  Delivery Tx: %s (block %d)", delivery.DeliveryTx.Hex(), delivery.DeliveryBlock)
+		ux.Logger.PrintToUser("  Relayer: %s", delivery.Deliverer.Hex())
+		ux.Logger.PrintToUser("  Relayer Reward Address: %s", delivery.RewardRedeemer.Hex())
+	} else {
+		rewardAddress, err := teleporter.GetRelayerRewardAddress(destination.endpoint, destination.messenger, messageID)
+		if err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("  Relayer Reward Address: %s", rewardAddress.Hex())
+	}
+	switch {
+	case delivery.Executed:
+		ux.Logger.PrintToUser("  Execution: Succeeded")
+	case delivery.ExecutionFailed:
+		ux.Logger.PrintToUser("  Execution: Failed")
+		ux.Logger.PrintToUser("")
+		ux.Logger.PrintToUser("The message execution can be retried with avalanche teleporter message retry %s", common.Hash(messageID).Hex())
+	default:
+		ux.Logger.PrintToUser("  Execution: Unknown")
+	}
+	return nil
+}
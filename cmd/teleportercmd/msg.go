@@ -3,6 +3,7 @@
 package teleportercmd
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -25,6 +26,7 @@ type MsgFlags struct {
 	DestinationAddress string
 	HexEncodedMessage  bool
 	PrivateKeyFlags    contract.PrivateKeyFlags
+	MessageFeeFlags    teleporter.MessageFeeFlags
 }
 
 var (
@@ -41,14 +43,19 @@ func newMsgCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "msg [sourceSubnetName] [destinationSubnetName] [messageContent]",
 		Short: "Verifies exchange of teleporter message between two subnets",
-		Long:  `Sends and wait reception for a teleporter msg between two subnets (Currently only for local network).`,
-		RunE:  msg,
-		Args:  cobrautils.ExactArgs(3),
+		Long: `Sends and wait reception for a teleporter msg between two subnets (Currently only for local network).
+
+By default the message offers no fee to relayers. --fee-token and --fee-amount set an incentive
+for relayers to deliver it (the messenger is approved to take the fee from the sender), and
+--allowed-relayers restricts which relayers can deliver it.`,
+		RunE: msg,
+		Args: cobrautils.ExactArgs(3),
 	}
 	networkoptions.AddNetworkFlagsToCmd(cmd, &msgFlags.Network, true, msgSupportedNetworkOptions)
 	contract.AddPrivateKeyFlagsToCmd(cmd, &msgFlags.PrivateKeyFlags, "as message originator and to pay source blockchain fees")
 	cmd.Flags().BoolVar(&msgFlags.HexEncodedMessage, "hex-encoded", false, "given message is hex encoded")
 	cmd.Flags().StringVar(&msgFlags.DestinationAddress, "destination-address", "", "deliver the message to the given contract destination address")
+	teleporter.AddMessageFeeFlagsToCmd(cmd, &msgFlags.MessageFeeFlags, true)
 	return cmd
 }
 
@@ -143,6 +150,15 @@ func msg(_ *cobra.Command, args []string) error {
 		}
 		destAddr = common.HexToAddress(msgFlags.DestinationAddress)
 	}
+	messageOptions, err := msgFlags.MessageFeeFlags.GetMessageOptions(
+		app,
+		network,
+		sourceSubnetName,
+		isCChain(sourceSubnetName),
+	)
+	if err != nil {
+		return err
+	}
 	// send tx to the teleporter contract at the source
 	ux.Logger.PrintToUser("Delivering message %q from source subnet %q (%s)", message, sourceSubnetName, sourceBlockchainID)
 	tx, receipt, err := teleporter.SendCrossChainMessage(
@@ -152,23 +168,13 @@ func msg(_ *cobra.Command, args []string) error {
 		destBlockchainID,
 		destAddr,
 		encodedMessage,
+		messageOptions,
 	)
 	if err != nil {
-		return err
-	}
-	if err == contract.ErrFailedReceiptStatus {
-		txHash := tx.Hash().String()
-		ux.Logger.PrintToUser("error: source receipt status for tx %s is not ReceiptStatusSuccessful", txHash)
-		trace, err := evm.GetTrace(network.BlockchainEndpoint(sourceBlockchainID.String()), txHash)
-		if err != nil {
-			ux.Logger.PrintToUser("error obtaining tx trace: %s", err)
-			ux.Logger.PrintToUser("")
-		} else {
-			ux.Logger.PrintToUser("")
-			ux.Logger.PrintToUser("trace: %#v", trace)
-			ux.Logger.PrintToUser("")
+		if errors.Is(err, contract.ErrFailedReceiptStatus) {
+			ux.Logger.PrintToUser("Use 'avalanche blockchain trace %s' to inspect the failed tx", tx.Hash())
 		}
-		return fmt.Errorf("source receipt status for tx %s is not ReceiptStatusSuccessful", txHash)
+		return err
 	}
 
 	event, err := evm.GetEventFromLogs(receipt.Logs, teleporter.ParseSendCrossChainMessage)
@@ -183,6 +189,8 @@ func msg(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid message content at source event, expected %s, got %s", message, string(event.Message.Message))
 	}
 
+	ux.Logger.PrintToUser("Message ID: %s", common.Hash(event.MessageID).Hex())
+
 	// receive and process head from destination
 	ux.Logger.PrintToUser("Waiting for message to be delivered to destination subnet %q (%s)", destSubnetName, destBlockchainID)
 
@@ -201,6 +209,7 @@ func msg(_ *cobra.Command, args []string) error {
 		}
 		elapsed := time.Since(t0)
 		if elapsed > arrivalCheckTimeout {
+			ux.Logger.PrintToUser("Use 'avalanche teleporter message status %s' to inspect the message delivery", common.Hash(event.MessageID).Hex())
 			return fmt.Errorf("timeout waiting for message to be teleported")
 		}
 		time.Sleep(arrivalCheckInterval)
@@ -0,0 +1,260 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package relayercmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/cmd/flags"
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/spf13/cobra"
+)
+
+type AutofundFlags struct {
+	FundingKeyName    string
+	FundingPrivateKey string
+	Threshold         string
+	Target            string
+	MaxTopUp          string
+	MaxTotal          string
+	DryRun            bool
+}
+
+// decimals of AVAX and of the native tokens of the relayer destinations
+const nativeTokenDecimals = 18
+
+var (
+	autofundNetworkOptions = []networkoptions.NetworkOption{networkoptions.Local, networkoptions.Cluster}
+	autofundFlags          AutofundFlags
+	autofundWatch          bool
+	autofundInterval       time.Duration
+)
+
+// avalanche teleporter relayer autofund
+func newAutofundCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "autofund",
+		Short: "tops up the AWM relayer keys",
+		Long: `Checks the balance of the relayer key on every destination blockchain of the AWM relayer
+on the specified network (Currently only for local network, cluster), and tops it up from
+the given funding key when it is below the threshold.
+
+Each top up takes the balance to the target amount, limited to --max-top-up. The amount
+sent over all the top ups is limited to --max-total. Use --dry-run to only show the top
+ups that would be made.
+
+By default the balances are checked once. With --watch, they are checked again every
+--interval for as long as the relayer keeps running, so its keys stay funded. --max-total
+covers all the checks, so a relayer key that keeps being drained can't drain the funding
+key: the command stops once it is reached.`,
+		RunE: autofund,
+		Args: cobrautils.ExactArgs(0),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &globalNetworkFlags, true, autofundNetworkOptions)
+	addAutofundFlags(cmd, &autofundFlags)
+	cmd.Flags().BoolVar(&autofundWatch, "watch", false, "keep checking the balances while the relayer is running")
+	cmd.Flags().DurationVar(&autofundInterval, "interval", 5*time.Minute, "time between balance checks on --watch")
+	return cmd
+}
+
+func addAutofundFlags(cmd *cobra.Command, autofundFlags *AutofundFlags) {
+	cmd.Flags().StringVar(&autofundFlags.FundingKeyName, "funding-key", "", "CLI stored key to fund the relayer keys with")
+	cmd.Flags().StringVar(&autofundFlags.FundingPrivateKey, "funding-private-key", "", "private key to fund the relayer keys with")
+	cmd.Flags().StringVar(&autofundFlags.Threshold, "threshold", "100", "top up relayer keys with a balance below this amount (in AVAX or native token units)")
+	cmd.Flags().StringVar(&autofundFlags.Target, "target", "500", "top up relayer keys to this balance")
+	cmd.Flags().StringVar(&autofundFlags.MaxTopUp, "max-top-up", "500", "max amount to send on a single top up (0 for no cap)")
+	cmd.Flags().StringVar(&autofundFlags.MaxTotal, "max-total", "5000", "max amount to send over all the top ups, including every --watch check (0 for no cap)")
+	cmd.Flags().BoolVar(&autofundFlags.DryRun, "dry-run", false, "only show the top ups, without funding")
+}
+
+func autofund(_ *cobra.Command, _ []string) error {
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		globalNetworkFlags,
+		false,
+		false,
+		autofundNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	access, err := teleporter.GetRelayerAccess(app, network)
+	if err != nil {
+		return err
+	}
+	if autofundWatch {
+		if autofundInterval <= 0 {
+			return fmt.Errorf("--interval should be positive")
+		}
+		if !relayerIsRunning(access) {
+			return fmt.Errorf("there is no CLI-managed AWM relayer running on %s", network.Name())
+		}
+	}
+	relayerConfig, err := access.LoadRunningConfig()
+	if err != nil {
+		return err
+	}
+	// amount sent over all the checks
+	sent := big.NewInt(0)
+	if err := topUpRelayerKeys(network, relayerConfig, autofundFlags, sent); err != nil || !autofundWatch {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(autofundInterval):
+		}
+		if !relayerIsRunning(access) {
+			ux.Logger.PrintToUser("AWM relayer stopped on %s, no longer checking its keys", network.Name())
+			return nil
+		}
+		// the config is reloaded on every check, as the relayer may have been reconfigured
+		relayerConfig, err := access.LoadRunningConfig()
+		if err == nil {
+			err = topUpRelayerKeys(network, relayerConfig, autofundFlags, sent)
+		}
+		if errors.Is(err, errMaxTotalReached) {
+			return err
+		}
+		if err != nil {
+			ux.Logger.RedXToUser("relayer keys top up failed: %s", err)
+		}
+	}
+}
+
+// tells if the relayer reached by [access] is running
+func relayerIsRunning(access teleporter.RelayerAccess) bool {
+	if !access.IsCloud() {
+		isUp, _, _, err := teleporter.RelayerIsUp(app.GetAWMRelayerRunPath())
+		return err == nil && isUp
+	}
+	relayerConfig, err := access.LoadRunningConfig()
+	if err != nil {
+		return false
+	}
+	_, err = access.Get(teleporter.GetRelayerAPIPort(relayerConfig), "/health")
+	return err == nil
+}
+
+var errMaxTotalReached = errors.New("relayer keys top ups reached --max-total")
+
+// runs the relayer keys balance guard given by [autofundFlags] over the destinations of
+// [relayerConfig]. [sent] holds the amount sent on previous runs, limited by --max-total,
+// and is increased with the amount sent on this one
+func topUpRelayerKeys(
+	network models.Network,
+	relayerConfig *config.Config,
+	autofundFlags AutofundFlags,
+	sent *big.Int,
+) error {
+	if !flags.EnsureMutuallyExclusive([]bool{autofundFlags.FundingKeyName != "", autofundFlags.FundingPrivateKey != ""}) {
+		return fmt.Errorf("--funding-key and --funding-private-key are mutually exclusive flags")
+	}
+	fundingPrivateKey := autofundFlags.FundingPrivateKey
+	if autofundFlags.FundingKeyName != "" {
+		k, err := app.GetKey(autofundFlags.FundingKeyName, network, false)
+		if err != nil {
+			return err
+		}
+		fundingPrivateKey = k.PrivKeyHex()
+	}
+	if fundingPrivateKey == "" && !autofundFlags.DryRun {
+		return fmt.Errorf("a funding key is needed to top up the relayer keys: use --funding-key or --funding-private-key")
+	}
+	threshold, err := utils.ParseUnits(autofundFlags.Threshold, nativeTokenDecimals)
+	if err != nil {
+		return fmt.Errorf("invalid --threshold: %w", err)
+	}
+	target, err := utils.ParseUnits(autofundFlags.Target, nativeTokenDecimals)
+	if err != nil {
+		return fmt.Errorf("invalid --target: %w", err)
+	}
+	maxTopUp, err := utils.ParseUnits(autofundFlags.MaxTopUp, nativeTokenDecimals)
+	if err != nil {
+		return fmt.Errorf("invalid --max-top-up: %w", err)
+	}
+	policy := teleporter.RelayerFundingPolicy{
+		Threshold: threshold,
+		Target:    target,
+		DryRun:    autofundFlags.DryRun,
+	}
+	if maxTopUp.Sign() > 0 {
+		policy.MaxTopUp = maxTopUp
+	}
+	maxTotal, err := utils.ParseUnits(autofundFlags.MaxTotal, nativeTokenDecimals)
+	if err != nil {
+		return fmt.Errorf("invalid --max-total: %w", err)
+	}
+	if maxTotal.Sign() > 0 {
+		policy.MaxTotal = new(big.Int).Sub(maxTotal, sent)
+		if policy.MaxTotal.Sign() <= 0 {
+			return fmt.Errorf("%w: %s sent", errMaxTotalReached, formatAvax(sent))
+		}
+	}
+	topUps, topUpErr := teleporter.TopUpRelayerKeys(network, relayerConfig, fundingPrivateKey, policy)
+	if !autofundFlags.DryRun {
+		for _, topUp := range topUps {
+			sent.Add(sent, topUp.Amount)
+		}
+	}
+	if len(topUps) > 0 {
+		blockchainIDToSubnetName, err := getBlockchainIDToSubnetNameMap(network)
+		if err != nil {
+			return err
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.SetHeader([]string{"Destination", "Relayer Address", "Balance", "Top Up"})
+		for _, topUp := range topUps {
+			destination := topUp.BlockchainID
+			if name, ok := blockchainIDToSubnetName[destination]; ok {
+				destination = name
+			}
+			if topUp.Address == "" {
+				table.Append([]string{destination, "KMS key", "-", "skipped"})
+				continue
+			}
+			amount := "-"
+			if topUp.Amount.Sign() == 0 && topUp.Capped {
+				amount = "skipped (--max-total reached)"
+			}
+			if topUp.Amount.Sign() > 0 {
+				amount = formatAvax(topUp.Amount)
+				if topUp.Capped {
+					amount += " (capped)"
+				}
+				if autofundFlags.DryRun {
+					amount += " (dry run)"
+				} else {
+					ux.Logger.PrintToUser("Relayer key %s on %s topped up with %s", topUp.Address, destination, formatAvax(topUp.Amount))
+				}
+			}
+			table.Append([]string{destination, topUp.Address, formatAvax(topUp.Balance), amount})
+		}
+		table.Render()
+	}
+	return topUpErr
+}
+
+func formatAvax(amount *big.Int) string {
+	return utils.FormatUnits(amount, nativeTokenDecimals)
+}
@@ -0,0 +1,99 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package configcmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/cmd/flags"
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+
+	"github.com/spf13/cobra"
+)
+
+type AddDestinationFlags struct {
+	KeyName      string
+	PrivateKey   string
+	KMSKeyID     string
+	KMSAWSRegion string
+}
+
+var addDestinationFlags AddDestinationFlags
+
+// avalanche teleporter relayer config add-destination
+func newAddDestinationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-destination [blockchainName]",
+		Short: "Adds or updates a destination blockchain of the relayer",
+		Long: `Adds a blockchain as a destination of the relayer, or updates the key used to
+deliver messages on it if it is already one.
+
+By default the CLI relayer key is used. A stored key, a private key, or an AWS KMS
+key can be used instead.`,
+		RunE: addDestination,
+		Args: cobrautils.ExactArgs(1),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &networkFlags, true, configSupportedNetworkOptions)
+	cmd.Flags().StringVar(&addDestinationFlags.KeyName, "key", "", "deliver messages with the given CLI stored key")
+	cmd.Flags().StringVar(&addDestinationFlags.PrivateKey, "private-key", "", "deliver messages with the given private key")
+	cmd.Flags().StringVar(&addDestinationFlags.KMSKeyID, "kms-key-id", "", "deliver messages with the given AWS KMS key")
+	cmd.Flags().StringVar(&addDestinationFlags.KMSAWSRegion, "kms-aws-region", "", "AWS region of the KMS key")
+	return cmd
+}
+
+func addDestination(_ *cobra.Command, args []string) error {
+	if !flags.EnsureMutuallyExclusive([]bool{addDestinationFlags.KeyName != "", addDestinationFlags.PrivateKey != "", addDestinationFlags.KMSKeyID != ""}) {
+		return fmt.Errorf("--key, --private-key and --kms-key-id are mutually exclusive")
+	}
+	if (addDestinationFlags.KMSKeyID == "") != (addDestinationFlags.KMSAWSRegion == "") {
+		return fmt.Errorf("--kms-key-id and --kms-aws-region must be given together")
+	}
+	relayer, err := getRelayerAccess()
+	if err != nil {
+		return err
+	}
+	destination, err := getRelayedChain(relayer.Network, args[0])
+	if err != nil {
+		return err
+	}
+	key := teleporter.RelayerDestinationKey{
+		PrivateKey:   addDestinationFlags.PrivateKey,
+		KMSKeyID:     addDestinationFlags.KMSKeyID,
+		KMSAWSRegion: addDestinationFlags.KMSAWSRegion,
+	}
+	switch {
+	case addDestinationFlags.KeyName != "":
+		k, err := app.GetKey(addDestinationFlags.KeyName, relayer.Network, false)
+		if err != nil {
+			return err
+		}
+		key.PrivateKey = k.PrivKeyHex()
+	case key.PrivateKey == "" && key.KMSKeyID == "":
+		_, key.PrivateKey, err = teleporter.GetRelayerKeyInfo(app.GetKeyPath(constants.AWMRelayerKeyName))
+		if err != nil {
+			return err
+		}
+	}
+	relayerConfig, err := relayer.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if err := teleporter.SetRelayerDestination(
+		relayerConfig,
+		relayer.Network,
+		destination.subnetID.String(),
+		destination.blockchainID.String(),
+		key,
+	); err != nil {
+		return err
+	}
+	if err := applyConfig(relayer, relayerConfig); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("%s set as a relayer destination", destination.name)
+	return nil
+}
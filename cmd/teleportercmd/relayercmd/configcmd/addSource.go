@@ -0,0 +1,110 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package configcmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/spf13/cobra"
+)
+
+type AddSourceFlags struct {
+	MessageFormats       []string
+	AllowedOriginSenders []string
+	Destinations         []string
+	DestinationAddresses []string
+	ProcessFromHeight    uint64
+}
+
+var addSourceFlags AddSourceFlags
+
+// avalanche teleporter relayer config add-source
+func newAddSourceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-source [blockchainName]",
+		Short: "Adds or updates a source blockchain of the relayer",
+		Long: `Adds a blockchain as a source of the relayer, or updates its settings if it is
+already one.
+
+By default every message sent on the blockchain is relayed. The messages can be
+restricted to the ones sent by given addresses, to given destination blockchains
+and addresses, and to given message contracts (teleporter, off-chain-registry).`,
+		RunE: addSource,
+		Args: cobrautils.ExactArgs(1),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &networkFlags, true, configSupportedNetworkOptions)
+	cmd.Flags().StringSliceVar(&addSourceFlags.MessageFormats, "message-formats", nil, fmt.Sprintf("only relay messages of these contracts %v (default all)", teleporter.RelayerMessageFormats))
+	cmd.Flags().StringSliceVar(&addSourceFlags.AllowedOriginSenders, "allowed-origin-senders", nil, "only relay messages sent by these addresses (default any)")
+	cmd.Flags().StringSliceVar(&addSourceFlags.Destinations, "destinations", nil, "only relay messages to these blockchains (default all)")
+	cmd.Flags().StringSliceVar(&addSourceFlags.DestinationAddresses, "destination-addresses", nil, "only relay messages to these addresses on the destinations (default any)")
+	cmd.Flags().Uint64Var(&addSourceFlags.ProcessFromHeight, "process-from-height", 0, "relay the messages sent since this height when the relayer starts")
+	return cmd
+}
+
+func addSource(_ *cobra.Command, args []string) error {
+	if len(addSourceFlags.DestinationAddresses) > 0 && len(addSourceFlags.Destinations) == 0 {
+		return fmt.Errorf("--destination-addresses requires --destinations to be set")
+	}
+	for _, address := range addSourceFlags.DestinationAddresses {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("invalid destination address %q", address)
+		}
+	}
+	relayer, err := getRelayerAccess()
+	if err != nil {
+		return err
+	}
+	source, err := getRelayedChain(relayer.Network, args[0])
+	if err != nil {
+		return err
+	}
+	destinations, err := getRelayedChains(relayer.Network, addSourceFlags.Destinations)
+	if err != nil {
+		return err
+	}
+	supportedDestinations := []*config.SupportedDestination{}
+	for _, destination := range destinations {
+		supportedDestinations = append(supportedDestinations, &config.SupportedDestination{
+			BlockchainID: destination.blockchainID.String(),
+			Addresses:    addSourceFlags.DestinationAddresses,
+		})
+	}
+	relayerAddress, _, err := teleporter.GetRelayerKeyInfo(app.GetKeyPath(constants.AWMRelayerKeyName))
+	if err != nil {
+		return err
+	}
+	relayerConfig, err := relayer.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if err := teleporter.SetRelayerSource(
+		relayerConfig,
+		relayer.Network,
+		source.subnetID.String(),
+		source.blockchainID.String(),
+		source.messengerAddress,
+		source.registryAddress,
+		relayerAddress,
+		teleporter.RelayerSourceSettings{
+			MessageFormats:        addSourceFlags.MessageFormats,
+			AllowedOriginSenders:  addSourceFlags.AllowedOriginSenders,
+			SupportedDestinations: supportedDestinations,
+			ProcessFromHeight:     addSourceFlags.ProcessFromHeight,
+		},
+	); err != nil {
+		return err
+	}
+	if err := applyConfig(relayer, relayerConfig); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("%s set as a relayer source", source.name)
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package configcmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/ssh"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/spf13/cobra"
+)
+
+const cChainName = "c-chain"
+
+var (
+	app                           *application.Avalanche
+	configSupportedNetworkOptions = []networkoptions.NetworkOption{networkoptions.Local, networkoptions.Cluster}
+	networkFlags                  networkoptions.NetworkFlags
+)
+
+// avalanche teleporter relayer config
+func NewCmd(injectedApp *application.Avalanche) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect and modify the relayer configuration",
+		Long: `The config command suite provides a collection of tools for inspecting and modifying
+the configuration of the local or cloud AWM relayer: which blockchains it relays messages
+from and to, which senders and destination addresses are allowed, which message contracts
+are relayed, and the keys used to deliver messages on each destination.
+
+Blockchains are given by subnet name, or as c-chain for the C-Chain. Every change is
+validated as the relayer does at startup, and applied by restarting the relayer if it
+is running.`,
+		RunE: cobrautils.CommandSuiteUsage,
+	}
+	app = injectedApp
+	// relayer config list
+	cmd.AddCommand(newListCmd())
+	// relayer config add-source
+	cmd.AddCommand(newAddSourceCmd())
+	// relayer config remove-source
+	cmd.AddCommand(newRemoveSourceCmd())
+	// relayer config add-destination
+	cmd.AddCommand(newAddDestinationCmd())
+	// relayer config remove-destination
+	cmd.AddCommand(newRemoveDestinationCmd())
+	// relayer config set
+	cmd.AddCommand(newSetCmd())
+	return cmd
+}
+
+// gets the access to the relayer managed by CLI on the network given by flags
+func getRelayerAccess() (teleporter.RelayerAccess, error) {
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		networkFlags,
+		false,
+		false,
+		configSupportedNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return teleporter.RelayerAccess{}, err
+	}
+	return teleporter.GetRelayerAccess(app, network)
+}
+
+// validates and saves [relayerConfig], restarting the relayer if it is running
+func applyConfig(r teleporter.RelayerAccess, relayerConfig *config.Config) error {
+	if err := teleporter.SaveRelayerConfig(r.ConfigPath, relayerConfig); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("Relayer configuration updated at %s", r.ConfigPath)
+	if !r.IsCloud() {
+		relayerIsUp, _, _, err := teleporter.RelayerIsUp(app.GetAWMRelayerRunPath())
+		if err != nil {
+			return err
+		}
+		if !relayerIsUp {
+			return nil
+		}
+		if err := teleporter.DeployRelayer(
+			app.GetAWMRelayerBinDir(),
+			r.ConfigPath,
+			app.GetAWMRelayerLogPath(),
+			app.GetAWMRelayerRunPath(),
+			app.GetAWMRelayerStorageDir(),
+		); err != nil {
+			return err
+		}
+		ux.Logger.GreenCheckmarkToUser("Local AWM Relayer restarted")
+		return nil
+	}
+	if err := ssh.RunSSHUploadNodeAWMRelayerConfig(r.Host, r.NodeInstanceDir); err != nil {
+		return err
+	}
+	if err := ssh.RunSSHStopAWMRelayerService(r.Host); err != nil {
+		return err
+	}
+	if err := ssh.RunSSHStartAWMRelayerService(r.Host); err != nil {
+		return err
+	}
+	ux.Logger.GreenCheckmarkToUser("Remote AWM Relayer on %s restarted", r.Host.GetCloudID())
+	return nil
+}
+
+// teleporter info of a blockchain given by name
+type relayedChain struct {
+	name             string
+	subnetID         ids.ID
+	blockchainID     ids.ID
+	messengerAddress string
+	registryAddress  string
+}
+
+func getRelayedChain(network models.Network, blockchainName string) (relayedChain, error) {
+	isCChain := strings.EqualFold(blockchainName, cChainName)
+	if isCChain {
+		blockchainName = ""
+	}
+	_, name, subnetID, blockchainID, messengerAddress, registryAddress, _, err := teleporter.GetSubnetParams(
+		app,
+		network,
+		blockchainName,
+		isCChain,
+	)
+	if err != nil {
+		return relayedChain{}, err
+	}
+	return relayedChain{
+		name:             name,
+		subnetID:         subnetID,
+		blockchainID:     blockchainID,
+		messengerAddress: messengerAddress,
+		registryAddress:  registryAddress,
+	}, nil
+}
+
+func getRelayedChains(network models.Network, blockchainNames []string) ([]relayedChain, error) {
+	chains := []relayedChain{}
+	for _, blockchainName := range blockchainNames {
+		chain, err := getRelayedChain(network, blockchainName)
+		if err != nil {
+			return nil, fmt.Errorf("failure getting %s info: %w", blockchainName, err)
+		}
+		chains = append(chains, chain)
+	}
+	return chains, nil
+}
+
+// returns the names of the teleporter enabled blockchains of [network], by blockchain ID
+func getChainNames(network models.Network) map[string]string {
+	names := map[string]string{}
+	blockchainNames := []string{cChainName}
+	if subnetNames, err := app.GetSubnetNamesOnNetwork(network); err == nil {
+		blockchainNames = append(blockchainNames, subnetNames...)
+	}
+	for _, blockchainName := range blockchainNames {
+		if chain, err := getRelayedChain(network, blockchainName); err == nil {
+			names[chain.blockchainID.String()] = chain.name
+		}
+	}
+	return names
+}
@@ -0,0 +1,126 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package configcmd
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/spf13/cobra"
+)
+
+// avalanche teleporter relayer config list
+func newListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the relayer source and destination blockchains",
+		Long: `Lists the source and destination blockchains of the relayer configuration,
+together with the settings used for each of them.`,
+		RunE: list,
+		Args: cobrautils.ExactArgs(0),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &networkFlags, true, configSupportedNetworkOptions)
+	return cmd
+}
+
+func list(_ *cobra.Command, _ []string) error {
+	relayer, err := getRelayerAccess()
+	if err != nil {
+		return err
+	}
+	relayerConfig, err := relayer.LoadConfig()
+	if err != nil {
+		return err
+	}
+	names := getChainNames(relayer.Network)
+	chainName := func(blockchainID string) string {
+		if name, ok := names[blockchainID]; ok {
+			return name
+		}
+		return blockchainID
+	}
+	ux.Logger.PrintToUser("Config: %s", relayer.ConfigPath)
+	ux.Logger.PrintToUser("Log Level: %s", relayerConfig.LogLevel)
+	ux.Logger.PrintToUser("Process Missed Blocks: %t", relayerConfig.ProcessMissedBlocks)
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Sources:")
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Blockchain", "Message Formats", "Allowed Senders", "Destinations", "Process From Height"})
+	table.SetRowLine(true)
+	for _, source := range relayerConfig.SourceBlockchains {
+		messageFormats := []string{}
+		for _, messageContract := range source.MessageContracts {
+			messageFormats = append(messageFormats, messageContract.MessageFormat)
+		}
+		sort.Strings(messageFormats)
+		allowedSenders := "any"
+		if len(source.AllowedOriginSenderAddresses) > 0 {
+			allowedSenders = strings.Join(source.AllowedOriginSenderAddresses, "\n")
+		}
+		destinations := "all"
+		if len(source.SupportedDestinations) > 0 {
+			destinations = formatSupportedDestinations(source.SupportedDestinations, chainName)
+		}
+		processFrom := "-"
+		if source.ProcessHistoricalBlocksFromHeight != 0 {
+			processFrom = strconv.FormatUint(source.ProcessHistoricalBlocksFromHeight, 10)
+		}
+		table.Append([]string{
+			chainName(source.BlockchainID),
+			strings.Join(messageFormats, "\n"),
+			allowedSenders,
+			destinations,
+			processFrom,
+		})
+	}
+	table.Render()
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("Destinations:")
+	table = tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Blockchain", "Relayer Key"})
+	table.SetRowLine(true)
+	for _, destination := range relayerConfig.DestinationBlockchains {
+		table.Append([]string{
+			chainName(destination.BlockchainID),
+			formatDestinationKey(destination),
+		})
+	}
+	table.Render()
+	return nil
+}
+
+func formatSupportedDestinations(
+	supportedDestinations []*config.SupportedDestination,
+	chainName func(string) string,
+) string {
+	lines := []string{}
+	for _, supportedDestination := range supportedDestinations {
+		line := chainName(supportedDestination.BlockchainID)
+		if len(supportedDestination.Addresses) > 0 {
+			line += ": " + strings.Join(supportedDestination.Addresses, ", ")
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// shows the address of the relayer key, or the KMS key ID if a KMS key is used
+func formatDestinationKey(destination *config.DestinationBlockchain) string {
+	if destination.KMSKeyID != "" {
+		return "KMS " + destination.KMSKeyID + " (" + destination.KMSAWSRegion + ")"
+	}
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(destination.AccountPrivateKey, "0x"))
+	if err != nil {
+		return "invalid private key"
+	}
+	return crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+}
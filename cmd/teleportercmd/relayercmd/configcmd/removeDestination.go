@@ -0,0 +1,49 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package configcmd
+
+import (
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+
+	"github.com/spf13/cobra"
+)
+
+// avalanche teleporter relayer config remove-destination
+func newRemoveDestinationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove-destination [blockchainName]",
+		Short: "Stops relaying messages to a blockchain",
+		Long: `Removes a blockchain from the destinations of the relayer, and from the
+supported destinations of its sources.`,
+		RunE: removeDestination,
+		Args: cobrautils.ExactArgs(1),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &networkFlags, true, configSupportedNetworkOptions)
+	return cmd
+}
+
+func removeDestination(_ *cobra.Command, args []string) error {
+	relayer, err := getRelayerAccess()
+	if err != nil {
+		return err
+	}
+	destination, err := getRelayedChain(relayer.Network, args[0])
+	if err != nil {
+		return err
+	}
+	relayerConfig, err := relayer.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if err := teleporter.RemoveRelayerDestination(relayerConfig, destination.blockchainID.String()); err != nil {
+		return err
+	}
+	if err := applyConfig(relayer, relayerConfig); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("%s removed from the relayer destinations", destination.name)
+	return nil
+}
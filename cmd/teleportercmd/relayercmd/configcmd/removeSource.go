@@ -0,0 +1,48 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package configcmd
+
+import (
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+
+	"github.com/spf13/cobra"
+)
+
+// avalanche teleporter relayer config remove-source
+func newRemoveSourceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove-source [blockchainName]",
+		Short: "Stops relaying messages from a blockchain",
+		Long:  `Removes a blockchain from the sources of the relayer.`,
+		RunE:  removeSource,
+		Args:  cobrautils.ExactArgs(1),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &networkFlags, true, configSupportedNetworkOptions)
+	return cmd
+}
+
+func removeSource(_ *cobra.Command, args []string) error {
+	relayer, err := getRelayerAccess()
+	if err != nil {
+		return err
+	}
+	source, err := getRelayedChain(relayer.Network, args[0])
+	if err != nil {
+		return err
+	}
+	relayerConfig, err := relayer.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if err := teleporter.RemoveRelayerSource(relayerConfig, source.blockchainID.String()); err != nil {
+		return err
+	}
+	if err := applyConfig(relayer, relayerConfig); err != nil {
+		return err
+	}
+	ux.Logger.PrintToUser("%s removed from the relayer sources", source.name)
+	return nil
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package configcmd
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+
+	"github.com/spf13/cobra"
+)
+
+type SetFlags struct {
+	ProcessMissedBlocks bool
+	LogLevel            string
+}
+
+var setFlags SetFlags
+
+// avalanche teleporter relayer config set
+func newSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Sets global relayer settings",
+		Long: `Sets the global settings of the relayer: its log level, and its processing mode,
+that is, if the messages sent while the relayer was down are relayed when it starts.`,
+		RunE: set,
+		Args: cobrautils.ExactArgs(0),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &networkFlags, true, configSupportedNetworkOptions)
+	cmd.Flags().BoolVar(&setFlags.ProcessMissedBlocks, "process-missed-blocks", true, "relay the messages sent while the relayer was down")
+	cmd.Flags().StringVar(&setFlags.LogLevel, "log-level", "", "relayer log level")
+	return cmd
+}
+
+func set(cmd *cobra.Command, _ []string) error {
+	processMissedBlocksChanged := cmd.Flags().Changed("process-missed-blocks")
+	logLevelChanged := cmd.Flags().Changed("log-level")
+	if !processMissedBlocksChanged && !logLevelChanged {
+		return fmt.Errorf("no setting given: use --process-missed-blocks or --log-level")
+	}
+	relayer, err := getRelayerAccess()
+	if err != nil {
+		return err
+	}
+	relayerConfig, err := relayer.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if processMissedBlocksChanged {
+		relayerConfig.ProcessMissedBlocks = setFlags.ProcessMissedBlocks
+	}
+	if logLevelChanged {
+		relayerConfig.LogLevel = setFlags.LogLevel
+	}
+	return applyConfig(relayer, relayerConfig)
+}
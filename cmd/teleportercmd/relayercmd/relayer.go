@@ -3,6 +3,7 @@
 package relayercmd
 
 import (
+	"github.com/ava-labs/avalanche-cli/cmd/teleportercmd/relayercmd/configcmd"
 	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/spf13/cobra"
@@ -25,5 +26,9 @@ and configuring an AWM relayer on localhost.`,
 	cmd.AddCommand(newStopCmd())
 	cmd.AddCommand(newStartCmd())
 	cmd.AddCommand(newLogsCmd())
+	cmd.AddCommand(newStatusCmd())
+	cmd.AddCommand(newAutofundCmd())
+	// relayer config
+	cmd.AddCommand(configcmd.NewCmd(app))
 	return cmd
 }
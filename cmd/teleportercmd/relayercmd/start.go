@@ -4,6 +4,7 @@ package relayercmd
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
@@ -20,6 +21,8 @@ import (
 var (
 	startNetworkOptions = []networkoptions.NetworkOption{networkoptions.Local, networkoptions.Cluster}
 	globalNetworkFlags  networkoptions.NetworkFlags
+	startAutofund       bool
+	startAutofundFlags  AutofundFlags
 )
 
 // avalanche teleporter relayer start
@@ -27,11 +30,16 @@ func newStartCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "start",
 		Short: "starts AWM relayer",
-		Long:  `Starts AWM relayer on the specified network (Currently only for local network).`,
-		RunE:  start,
-		Args:  cobrautils.ExactArgs(0),
+		Long: `Starts AWM relayer on the specified network (Currently only for local network).
+
+With --autofund, the relayer keys are topped up once before starting, as done by relayer
+autofund. Use relayer autofund --watch to keep them funded while the relayer runs.`,
+		RunE: start,
+		Args: cobrautils.ExactArgs(0),
 	}
 	networkoptions.AddNetworkFlagsToCmd(cmd, &globalNetworkFlags, true, startNetworkOptions)
+	cmd.Flags().BoolVar(&startAutofund, "autofund", false, "top up the relayer keys before starting")
+	addAutofundFlags(cmd, &startAutofundFlags)
 	return cmd
 }
 
@@ -48,6 +56,11 @@ func start(_ *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	if startAutofund {
+		if err := autofundBeforeStart(network); err != nil {
+			return err
+		}
+	}
 	switch {
 	case network.Kind == models.Local:
 		if relayerIsUp, _, _, err := teleporter.RelayerIsUp(
@@ -84,3 +97,16 @@ func start(_ *cobra.Command, _ []string) error {
 	}
 	return nil
 }
+
+// tops up the relayer keys of the relayer to be started on [network]
+func autofundBeforeStart(network models.Network) error {
+	access, err := teleporter.GetRelayerAccess(app, network)
+	if err != nil {
+		return err
+	}
+	relayerConfig, err := access.LoadRunningConfig()
+	if err != nil {
+		return err
+	}
+	return topUpRelayerKeys(network, relayerConfig, startAutofundFlags, big.NewInt(0))
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package relayercmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/spf13/cobra"
+)
+
+var statusNetworkOptions = []networkoptions.NetworkOption{networkoptions.Local, networkoptions.Cluster}
+
+// avalanche teleporter relayer status
+func newStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "shows AWM relayer health and metrics",
+		Long: `Shows the health of the AWM relayer on the specified network (Currently only for local network, cluster),
+together with the state of each of its routes: successful, failed and pending messages, and last
+processed heights. Also shows the balance of the relayer key on each destination blockchain.`,
+		RunE: status,
+		Args: cobrautils.ExactArgs(0),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &globalNetworkFlags, true, statusNetworkOptions)
+	return cmd
+}
+
+func status(_ *cobra.Command, _ []string) error {
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		globalNetworkFlags,
+		false,
+		false,
+		statusNetworkOptions,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+	if network.Kind == models.Local {
+		b, _, _, err := teleporter.RelayerIsUp(
+			app.GetAWMRelayerRunPath(),
+		)
+		if err != nil {
+			return err
+		}
+		if !b {
+			return fmt.Errorf("there is no CLI-managed local AWM relayer running")
+		}
+	}
+	access, err := teleporter.GetRelayerAccess(app, network)
+	if err != nil {
+		return err
+	}
+	relayerConfig, err := access.LoadRunningConfig()
+	if err != nil {
+		return err
+	}
+
+	healthy, healthErrors := false, []string{}
+	healthResponse, err := access.Get(teleporter.GetRelayerAPIPort(relayerConfig), "/health")
+	if err == nil {
+		healthy, healthErrors, err = teleporter.ParseRelayerHealth(healthResponse)
+	}
+	if err != nil {
+		healthErrors = append(healthErrors, err.Error())
+	}
+	if healthy {
+		ux.Logger.GreenCheckmarkToUser("AWM Relayer is healthy")
+	} else {
+		ux.Logger.RedXToUser("AWM Relayer is not healthy")
+		for _, healthError := range healthErrors {
+			ux.Logger.PrintToUser("  %s", healthError)
+		}
+	}
+	metrics, err := access.Get(teleporter.GetRelayerMetricsPort(relayerConfig), "/metrics")
+	if err != nil {
+		ux.Logger.RedXToUser("could not get relayer metrics: %s", err)
+		metrics = nil
+	}
+	relayerStatus, err := teleporter.GetRelayerStatus(network, relayerConfig, metrics, access.ReadRouteState)
+	if err != nil {
+		return err
+	}
+	blockchainIDToSubnetName, err := getBlockchainIDToSubnetNameMap(network)
+	if err != nil {
+		return err
+	}
+	chainName := func(blockchainID string) string {
+		if name, ok := blockchainIDToSubnetName[blockchainID]; ok {
+			return name
+		}
+		return blockchainID
+	}
+	ux.Logger.PrintToUser("")
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Source", "Destination", "Successful", "Failed", "Pending", "Last Processed Height", "Source Height"})
+	for _, route := range relayerStatus.Routes {
+		lastProcessedHeight := "-"
+		if route.LastProcessedHeight != 0 {
+			lastProcessedHeight = strconv.FormatUint(route.LastProcessedHeight, 10)
+		}
+		table.Append([]string{
+			chainName(route.SourceBlockchainID),
+			chainName(route.DestinationBlockchainID),
+			strconv.FormatUint(route.Successful, 10),
+			strconv.FormatUint(route.Failed, 10),
+			strconv.FormatUint(route.Pending, 10),
+			lastProcessedHeight,
+			strconv.FormatUint(route.SourceHeight, 10),
+		})
+	}
+	table.Render()
+	ux.Logger.PrintToUser("")
+	table = tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Destination", "Relayer Address", "Balance"})
+	for _, destination := range relayerStatus.Destinations {
+		address, balance := "KMS key", "-"
+		if destination.Address != "" {
+			address = destination.Address
+			balance = formatAvax(destination.Balance)
+		}
+		table.Append([]string{chainName(destination.BlockchainID), address, balance})
+	}
+	table.Render()
+	return nil
+}
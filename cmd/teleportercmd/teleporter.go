@@ -4,6 +4,7 @@ package teleportercmd
 
 import (
 	"github.com/ava-labs/avalanche-cli/cmd/teleportercmd/bridgecmd"
+	"github.com/ava-labs/avalanche-cli/cmd/teleportercmd/messagecmd"
 	"github.com/ava-labs/avalanche-cli/cmd/teleportercmd/relayercmd"
 	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
@@ -24,8 +25,14 @@ with Teleporter-Enabled Subnets.`,
 	app = injectedApp
 	// teleporter msg
 	cmd.AddCommand(newMsgCmd())
+	// teleporter message
+	cmd.AddCommand(messagecmd.NewCmd(app))
+	// teleporter messages
+	cmd.AddCommand(messagecmd.NewMessagesCmd(app))
 	// teleporter deploy
 	cmd.AddCommand(newDeployCmd())
+	// teleporter upgrade
+	cmd.AddCommand(newUpgradeCmd())
 	// teleporter relayer
 	cmd.AddCommand(relayercmd.NewCmd(app))
 	// teleporter bridge
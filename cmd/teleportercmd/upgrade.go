@@ -0,0 +1,329 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleportercmd
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/cobrautils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/networkoptions"
+	"github.com/ava-labs/avalanche-cli/pkg/prompts"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/spf13/cobra"
+)
+
+type UpgradeFlags struct {
+	Network         networkoptions.NetworkFlags
+	Version         string
+	PrivateKeyFlags contract.PrivateKeyFlags
+	FromBlock       uint64
+}
+
+var upgradeFlags UpgradeFlags
+
+// avalanche teleporter upgrade
+func newUpgradeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade [blockchainName]",
+		Short: "Upgrades the Teleporter Messenger of a blockchain to a new version",
+		Long: `Upgrades the Teleporter Messenger of a blockchain to a new version.
+
+The new messenger is deployed with the keyless method, and registered as the latest
+version on the blockchain Teleporter Registry, so registry based dApps start sending
+through it.
+
+Registering a version requires an off-chain warp message signed by the blockchain
+validators. The message is added to the blockchain chain config, so the validators
+sign it once restarted. On local networks the nodes are restarted automatically,
+on other networks the user is asked to install the chain config on the validators.
+
+dApps still accepting messages from the old messenger are listed at the end, so their
+owners can update their min teleporter version. They are found from the messages sent or
+received through the old messenger starting at --from-block, or on the last 100000 blocks
+if not given.`,
+		RunE: upgrade,
+		Args: cobrautils.ExactArgs(1),
+	}
+	networkoptions.AddNetworkFlagsToCmd(cmd, &upgradeFlags.Network, true, deploySupportedNetworkOptions)
+	contract.AddPrivateKeyFlagsToCmd(cmd, &upgradeFlags.PrivateKeyFlags, "to pay for the upgrade txs")
+	cmd.Flags().StringVar(&upgradeFlags.Version, "version", "latest", "teleporter version to upgrade to")
+	cmd.Flags().Uint64Var(&upgradeFlags.FromBlock, "from-block", 0, "look for dApps using the old messenger starting at the given block (default: the last 100000 blocks)")
+	return cmd
+}
+
+func upgrade(cmd *cobra.Command, args []string) error {
+	blockchainName := args[0]
+	network, err := networkoptions.GetNetworkFromCmdLineFlags(
+		app,
+		"",
+		upgradeFlags.Network,
+		true,
+		false,
+		deploySupportedNetworkOptions,
+		blockchainName,
+	)
+	if err != nil {
+		return err
+	}
+	sc, err := app.LoadSidecar(blockchainName)
+	if err != nil {
+		return fmt.Errorf("failed to load sidecar: %w", err)
+	}
+	networkData := sc.Networks[network.Name()]
+	if networkData.BlockchainID == ids.Empty {
+		return fmt.Errorf("blockchain %s has not been deployed to %s", blockchainName, network.Name())
+	}
+	if !sc.TeleporterReady || networkData.TeleporterRegistryAddress == "" {
+		return fmt.Errorf("blockchain %s has no Teleporter Registry on %s. Deploy it with avalanche teleporter deploy", blockchainName, network.Name())
+	}
+	if err := checkWarpEnabled(blockchainName); err != nil {
+		return err
+	}
+	version := upgradeFlags.Version
+	if version == "" || version == "latest" {
+		teleporterInfo, err := teleporter.GetInfo(app)
+		if err != nil {
+			return err
+		}
+		version = teleporterInfo.Version
+	}
+	if version == sc.TeleporterVersion {
+		ux.Logger.PrintToUser("Teleporter is already at version %s on %s", version, blockchainName)
+		return nil
+	}
+	privateKey, err := getUpgradePrivateKey(network, blockchainName, sc)
+	if err != nil {
+		return err
+	}
+	rpcURL := network.BlockchainEndpoint(networkData.BlockchainID.String())
+	registryAddress := common.HexToAddress(networkData.TeleporterRegistryAddress)
+	oldMessengerAddress := common.HexToAddress(networkData.TeleporterMessengerAddress)
+
+	td := teleporter.Deployer{}
+	if err := td.DownloadAssets(app.GetTeleporterBinDir(), version); err != nil {
+		return err
+	}
+	_, messengerAddressStr, err := td.DeployMessenger(blockchainName, rpcURL, privateKey)
+	if err != nil {
+		return err
+	}
+	messengerAddress := common.HexToAddress(messengerAddressStr)
+
+	registryVersion, err := teleporter.GetRegistryVersion(rpcURL, registryAddress, messengerAddress)
+	if err != nil {
+		return err
+	}
+	if registryVersion != nil {
+		ux.Logger.PrintToUser("Teleporter Messenger %s is already registered as version %s", messengerAddress.Hex(), registryVersion)
+	} else {
+		latestVersion, err := teleporter.GetRegistryLatestVersion(rpcURL, registryAddress)
+		if err != nil {
+			return err
+		}
+		registryVersion = latestVersion.Add(latestVersion, common.Big1)
+		unsignedMessage, err := teleporter.NewProtocolVersionMessage(
+			network.ID,
+			networkData.BlockchainID,
+			registryAddress,
+			registryVersion,
+			messengerAddress,
+		)
+		if err != nil {
+			return err
+		}
+		ready, err := installOffChainMessage(network, blockchainName, networkData.BlockchainID, unsignedMessage)
+		if err != nil {
+			return err
+		}
+		if !ready {
+			ux.Logger.PrintToUser("Run this command again once the validators have been restarted")
+			return nil
+		}
+		ux.Logger.PrintToUser("Gathering the validators signatures of the registry message...")
+		signedMessage, err := teleporter.GetSignedOffChainMessage(rpcURL, networkData.SubnetID, unsignedMessage)
+		if err != nil {
+			return err
+		}
+		if err := teleporter.AddProtocolVersion(rpcURL, privateKey, registryAddress, signedMessage); err != nil {
+			return err
+		}
+		ux.Logger.PrintToUser("Teleporter Messenger %s registered as version %s on %s", messengerAddress.Hex(), registryVersion, blockchainName)
+	}
+
+	sc, err = app.LoadSidecar(blockchainName)
+	if err != nil {
+		return fmt.Errorf("failed to load sidecar: %w", err)
+	}
+	sc.TeleporterVersion = version
+	networkData = sc.Networks[network.Name()]
+	networkData.TeleporterMessengerAddress = messengerAddress.Hex()
+	sc.Networks[network.Name()] = networkData
+	if err := app.UpdateSidecar(&sc); err != nil {
+		return err
+	}
+
+	if oldMessengerAddress != (common.Address{}) && oldMessengerAddress != messengerAddress {
+		fromBlock := uint64(evm.FromRecentBlocks)
+		if cmd.Flags().Changed("from-block") {
+			fromBlock = upgradeFlags.FromBlock
+		}
+		return printPinnedDApps(rpcURL, oldMessengerAddress, registryVersion, fromBlock)
+	}
+	return nil
+}
+
+// the registry can only verify warp messages if the Warp precompile is enabled
+// at genesis or by a network upgrade
+func checkWarpEnabled(blockchainName string) error {
+	genesis, err := app.LoadEvmGenesis(blockchainName)
+	if err != nil {
+		return err
+	}
+	if genesis.Config != nil && genesis.Config.GenesisPrecompiles[warp.ConfigKey] != nil {
+		return nil
+	}
+	if upgradeBytes, err := app.ReadUpgradeFile(blockchainName); err == nil && strings.Contains(string(upgradeBytes), warp.ConfigKey) {
+		return nil
+	}
+	return fmt.Errorf("the Warp precompile is not enabled on %s: enable it with avalanche blockchain upgrade generate, and apply the upgrade", blockchainName)
+}
+
+// gets the key to pay for the upgrade txs, from the sidecar teleporter key, flags, or prompting the user
+func getUpgradePrivateKey(
+	network models.Network,
+	blockchainName string,
+	sc models.Sidecar,
+) (string, error) {
+	if sc.TeleporterKey != "" {
+		k, err := app.GetKey(sc.TeleporterKey, network, true)
+		if err != nil {
+			return "", err
+		}
+		return k.PrivKeyHex(), nil
+	}
+	genesisAddress, genesisPrivateKey, err := contract.GetEVMSubnetPrefundedKey(
+		app,
+		network,
+		blockchainName,
+		false,
+		"",
+	)
+	if err != nil {
+		return "", err
+	}
+	privateKey, err := contract.GetPrivateKeyFromFlags(
+		app,
+		upgradeFlags.PrivateKeyFlags,
+		genesisPrivateKey,
+	)
+	if err != nil {
+		return "", err
+	}
+	if privateKey == "" {
+		privateKey, err = prompts.PromptPrivateKey(
+			app.Prompt,
+			"upgrade teleporter",
+			app.GetKeyDir(),
+			app.GetKey,
+			genesisAddress,
+			genesisPrivateKey,
+		)
+		if err != nil {
+			return "", err
+		}
+	}
+	return privateKey, nil
+}
+
+// adds the off-chain message to the blockchain chain config, and gets it installed
+// on the validators. Returns false if the validators are not yet ready to sign it
+func installOffChainMessage(
+	network models.Network,
+	blockchainName string,
+	blockchainID ids.ID,
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+) (bool, error) {
+	var chainConfig []byte
+	if app.ChainConfigExists(blockchainName) {
+		var err error
+		chainConfig, err = app.LoadRawChainConfig(blockchainName)
+		if err != nil {
+			return false, err
+		}
+	}
+	newChainConfig, changed, err := teleporter.AddOffChainMessageToChainConfig(chainConfig, unsignedMessage)
+	if err != nil {
+		return false, err
+	}
+	if changed {
+		if err := app.WriteChainConfigFile(blockchainName, newChainConfig); err != nil {
+			return false, err
+		}
+	}
+	if network.Kind == models.Local {
+		if !changed {
+			return true, nil
+		}
+		ux.Logger.PrintToUser("Restarting the local network so the validators sign the registry message...")
+		return true, localnet.RestartWithChainConfigs(
+			blockchainName,
+			map[string]string{blockchainID.String(): string(newChainConfig)},
+			app.Conf.GetConfigBoolValue(constants.ConfigSnapshotsAutoSaveKey),
+		)
+	}
+	ux.Logger.PrintToUser("The validators of %s need to sign the registry message.", blockchainName)
+	ux.Logger.PrintToUser("Install the chain config at %s as config.json on the", app.GetChainConfigPath(blockchainName))
+	ux.Logger.PrintToUser("chain config dir of every validator (eg $HOME/.avalanchego/configs/chains/%s),", blockchainID)
+	ux.Logger.PrintToUser("and restart them.")
+	return app.Prompt.CaptureYesNo("Have the validators been restarted with the new chain config?")
+}
+
+// prints the dApps that used [oldMessengerAddress] starting at [fromBlock], and still
+// accept its messages
+func printPinnedDApps(
+	rpcURL string,
+	oldMessengerAddress common.Address,
+	newVersion *big.Int,
+	fromBlock uint64,
+) error {
+	dApps, err := teleporter.GetMessengerDApps(rpcURL, oldMessengerAddress, fromBlock)
+	if err != nil {
+		return err
+	}
+	pinned := []string{}
+	for _, dApp := range dApps {
+		minVersion, err := teleporter.GetMinTeleporterVersion(rpcURL, dApp)
+		if err != nil {
+			return err
+		}
+		switch {
+		case minVersion == nil:
+			pinned = append(pinned, fmt.Sprintf("%s: not registry based, bound to the old messenger", dApp.Hex()))
+		case minVersion.Cmp(newVersion) < 0:
+			pinned = append(pinned, fmt.Sprintf("%s: min teleporter version %s", dApp.Hex(), minVersion))
+		}
+	}
+	if len(pinned) == 0 {
+		return nil
+	}
+	ux.Logger.PrintToUser("")
+	ux.Logger.PrintToUser("dApps still pinned to the old Teleporter Messenger %s:", oldMessengerAddress.Hex())
+	for _, s := range pinned {
+		ux.Logger.PrintToUser("  %s", s)
+	}
+	ux.Logger.PrintToUser("Registry based dApps can move to the new version with updateMinTeleporterVersion(%s)", newVersion)
+	return nil
+}
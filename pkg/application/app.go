@@ -50,7 +50,7 @@ func (app *Avalanche) GetRunFile() string {
 }
 
 func (app *Avalanche) GetSnapshotsDir() string {
-	return filepath.Join(app.baseDir, constants.SnapshotsDirName)
+	return filepath.Join(app.getLocalNetworkInstanceDir(), constants.SnapshotsDirName)
 }
 
 func (app *Avalanche) GetBaseDir() string {
@@ -69,8 +69,12 @@ func (app *Avalanche) GetReposDir() string {
 	return filepath.Join(app.baseDir, constants.ReposDir)
 }
 
+func (app *Avalanche) GetICTTRegistryPath() string {
+	return filepath.Join(app.baseDir, constants.ICTTRegistryDir, constants.ICTTRegistryFileName)
+}
+
 func (app *Avalanche) GetRunDir() string {
-	return filepath.Join(app.baseDir, constants.RunDir)
+	return filepath.Join(app.getLocalNetworkInstanceDir(), constants.RunDir)
 }
 
 func (app *Avalanche) GetServicesDir(baseDir string) string {
@@ -85,7 +89,7 @@ func (app *Avalanche) GetCustomVMDir() string {
 }
 
 func (app *Avalanche) GetPluginsDir() string {
-	return filepath.Join(app.baseDir, constants.PluginDir)
+	return filepath.Join(app.getLocalNetworkInstanceDir(), constants.PluginDir)
 }
 
 // Remove all plugins from plugin dir
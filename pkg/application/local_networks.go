@@ -0,0 +1,149 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package application
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+)
+
+func (app *Avalanche) GetLocalNetworksDir() string {
+	return filepath.Join(app.baseDir, constants.LocalNetworksDir)
+}
+
+func (app *Avalanche) GetLocalNetworkDir(name string) string {
+	return filepath.Join(app.GetLocalNetworksDir(), name)
+}
+
+// dir holding the run files, snapshots and plugins of the current local network instance
+func (app *Avalanche) getLocalNetworkInstanceDir() string {
+	instance := models.GetLocalNetworkInstance()
+	if instance.IsDefault() {
+		return app.baseDir
+	}
+	return app.GetLocalNetworkDir(instance.Name)
+}
+
+// SetLocalNetwork makes all local network operations target the named local network [name].
+// A slot, that determines its ports, is assigned to the network on first use
+func (app *Avalanche) SetLocalNetwork(name string) error {
+	if err := models.ValidateLocalNetworkName(name); err != nil {
+		return err
+	}
+	instance, err := app.GetLocalNetworkInstance(name)
+	if errors.Is(err, os.ErrNotExist) {
+		instance, err = app.createLocalNetworkInstance(name)
+	}
+	if err != nil {
+		return err
+	}
+	models.SetLocalNetworkInstance(instance)
+	return nil
+}
+
+func (app *Avalanche) GetLocalNetworkInstance(name string) (models.LocalNetworkInstance, error) {
+	instancePath := filepath.Join(app.GetLocalNetworkDir(name), constants.LocalNetworkInstanceFile)
+	bs, err := os.ReadFile(instancePath)
+	if err != nil {
+		return models.LocalNetworkInstance{}, err
+	}
+	var instance models.LocalNetworkInstance
+	if err := json.Unmarshal(bs, &instance); err != nil {
+		return models.LocalNetworkInstance{}, fmt.Errorf("failed unmarshalling local network info at %s: %w", instancePath, err)
+	}
+	return instance, nil
+}
+
+// claims the first free slot for local network [name]. slots are claimed by exclusively
+// creating a file, so parallel invocations don't get the same one
+func (app *Avalanche) createLocalNetworkInstance(name string) (models.LocalNetworkInstance, error) {
+	slotsDir := filepath.Join(app.GetLocalNetworksDir(), constants.LocalNetworkSlotsDir)
+	if err := os.MkdirAll(slotsDir, constants.DefaultPerms755); err != nil {
+		return models.LocalNetworkInstance{}, err
+	}
+	for slot := 1; slot <= constants.MaxLocalNetworkInstances; slot++ {
+		f, err := os.OpenFile(filepath.Join(slotsDir, strconv.Itoa(slot)), os.O_CREATE|os.O_EXCL|os.O_WRONLY, constants.WriteReadReadPerms)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return models.LocalNetworkInstance{}, err
+		}
+		_, err = f.WriteString(name)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return models.LocalNetworkInstance{}, err
+		}
+		instance := models.LocalNetworkInstance{Name: name, Slot: slot}
+		bs, err := json.MarshalIndent(instance, "", "    ")
+		if err != nil {
+			return models.LocalNetworkInstance{}, err
+		}
+		if err := os.MkdirAll(app.GetLocalNetworkDir(name), constants.DefaultPerms755); err != nil {
+			return models.LocalNetworkInstance{}, err
+		}
+		instancePath := filepath.Join(app.GetLocalNetworkDir(name), constants.LocalNetworkInstanceFile)
+		if err := os.WriteFile(instancePath, bs, constants.WriteReadReadPerms); err != nil {
+			return models.LocalNetworkInstance{}, err
+		}
+		return instance, nil
+	}
+	return models.LocalNetworkInstance{}, fmt.Errorf(
+		"all %d local network slots are in use. remove unused networks with avalanche network clean --%s <name>",
+		constants.MaxLocalNetworkInstances,
+		constants.LocalNetworkFlag,
+	)
+}
+
+// GetLocalNetworkInstances returns the named local networks created on this machine
+func (app *Avalanche) GetLocalNetworkInstances() ([]models.LocalNetworkInstance, error) {
+	entries, err := os.ReadDir(app.GetLocalNetworksDir())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	instances := []models.LocalNetworkInstance{}
+	for _, entry := range entries {
+		if !entry.IsDir() || models.ValidateLocalNetworkName(entry.Name()) != nil {
+			continue
+		}
+		instance, err := app.GetLocalNetworkInstance(entry.Name())
+		if err != nil {
+			continue
+		}
+		instances = append(instances, instance)
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].Slot < instances[j].Slot
+	})
+	return instances, nil
+}
+
+// RemoveLocalNetwork deletes all the data of the named local network [name], and frees its slot
+func (app *Avalanche) RemoveLocalNetwork(name string) error {
+	instance, err := app.GetLocalNetworkInstance(name)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(app.GetLocalNetworkDir(name)); err != nil {
+		return err
+	}
+	slotPath := filepath.Join(app.GetLocalNetworksDir(), constants.LocalNetworkSlotsDir, strconv.Itoa(instance.Slot))
+	if utils.FileExists(slotPath) {
+		return os.Remove(slotPath)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package application
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetLocalNetwork(t *testing.T) {
+	require := require.New(t)
+	ap := newTestApp(t)
+	defer models.SetLocalNetworkInstance(models.LocalNetworkInstance{})
+
+	defaultRunDir := ap.GetRunDir()
+	require.Error(ap.SetLocalNetwork("../ci"))
+
+	require.NoError(ap.SetLocalNetwork("ci-1"))
+	require.Equal(models.LocalNetworkInstance{Name: "ci-1", Slot: 1}, models.GetLocalNetworkInstance())
+	require.Equal(filepath.Join(ap.GetLocalNetworkDir("ci-1"), constants.RunDir), ap.GetRunDir())
+	require.Equal(filepath.Join(ap.GetLocalNetworkDir("ci-1"), constants.SnapshotsDirName), ap.GetSnapshotsDir())
+	require.NotEqual(defaultRunDir, ap.GetRunDir())
+
+	require.NoError(ap.SetLocalNetwork("ci-2"))
+	require.Equal(2, models.GetLocalNetworkInstance().Slot)
+
+	// existing networks keep their slot
+	require.NoError(ap.SetLocalNetwork("ci-1"))
+	require.Equal(1, models.GetLocalNetworkInstance().Slot)
+
+	instances, err := ap.GetLocalNetworkInstances()
+	require.NoError(err)
+	require.Equal([]models.LocalNetworkInstance{{Name: "ci-1", Slot: 1}, {Name: "ci-2", Slot: 2}}, instances)
+
+	// removed networks free their slot
+	require.NoError(ap.RemoveLocalNetwork("ci-1"))
+	require.NoError(ap.SetLocalNetwork("ci-3"))
+	require.Equal(1, models.GetLocalNetworkInstance().Slot)
+	instances, err = ap.GetLocalNetworkInstances()
+	require.NoError(err)
+	require.Equal([]models.LocalNetworkInstance{{Name: "ci-3", Slot: 1}, {Name: "ci-2", Slot: 2}}, instances)
+}
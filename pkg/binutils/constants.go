@@ -6,9 +6,6 @@ import "time"
 
 const (
 	gRPCClientLogLevel = "error"
-	gRPCServerPort     = ":8097"
-	gRPCGatewayPort    = ":8098"
-	gRPCServerEndpoint = "localhost" + gRPCServerPort
 	gRPCDialTimeout    = 10 * time.Second
 
 	avalanchegoBinPrefix = "avalanchego-"
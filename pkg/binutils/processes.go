@@ -16,6 +16,7 @@ import (
 
 	"github.com/ava-labs/avalanche-cli/pkg/application"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
 	"github.com/ava-labs/avalanche-network-runner/client"
@@ -89,7 +90,7 @@ func NewGRPCClient(opts ...GRPCClientOpOption) (client.Client, error) {
 		return nil, err
 	}
 	client, err := client.New(client.Config{
-		Endpoint:    gRPCServerEndpoint,
+		Endpoint:    fmt.Sprintf("localhost:%d", models.GetLocalNetworkInstance().GRPCServerPort()),
 		DialTimeout: op.dialTimeout,
 	}, log)
 	if errors.Is(err, context.DeadlineExceeded) {
@@ -116,7 +117,9 @@ func NewGRPCClient(opts ...GRPCClientOpOption) (client.Client, error) {
 }
 
 // NewGRPCServer hides away the details (params) of creating a gRPC server
+// for the current local network instance
 func NewGRPCServer(snapshotsDir string) (server.Server, error) {
+	instance := models.GetLocalNetworkInstance()
 	logFactory := logging.NewFactory(logging.Config{
 		DisplayLevel: logging.Info,
 		LogLevel:     logging.Off,
@@ -126,8 +129,8 @@ func NewGRPCServer(snapshotsDir string) (server.Server, error) {
 		return nil, err
 	}
 	return server.New(server.Config{
-		Port:                gRPCServerPort,
-		GwPort:              gRPCGatewayPort,
+		Port:                fmt.Sprintf(":%d", instance.GRPCServerPort()),
+		GwPort:              fmt.Sprintf(":%d", instance.GRPCGatewayPort()),
 		DialTimeout:         gRPCDialTimeout,
 		SnapshotsDir:        snapshotsDir,
 		RedirectNodesOutput: false,
@@ -204,6 +207,9 @@ func StartServerProcess(app *application.Avalanche) error {
 	thisBin := reexec.Self()
 
 	args := []string{constants.BackendCmd}
+	if instance := models.GetLocalNetworkInstance(); !instance.IsDefault() {
+		args = append(args, "--"+constants.LocalNetworkFlag, instance.Name)
+	}
 	cmd := exec.Command(thisBin, args...)
 
 	outputDirPrefix := path.Join(app.GetRunDir(), "server")
@@ -124,6 +124,18 @@ const (
 	BootstrapSnapshotSingleNodePreCortina17SHA256URL   = BootstrapSnapshotRawBranch + AssetsDir + "sha256sumSingleNode.PreCortina17.txt"
 
 	ExtraLocalNetworkDataFilename = "extra-local-network-data.json"
+	LocalNetworkTopologyFilename  = "topology.json"
+
+	// named local networks, running side by side with the default one
+	LocalNetworksDir            = "local-networks"
+	LocalNetworkInstanceFile    = "instance.json"
+	LocalNetworkSlotsDir        = ".slots"
+	LocalNetworkFlag            = "local-network"
+	LocalNetworkEnvVar          = "AVALANCHE_LOCAL_NETWORK"
+	MaxLocalNetworkInstances    = 49
+	LocalNetworkNodesPortsRange = 100
+	GRPCServerPort              = 8097
+	GRPCGatewayPort             = 8098
 
 	CliInstallationURL         = "https://raw.githubusercontent.com/ava-labs/avalanche-cli/main/scripts/install.sh"
 	ExpectedCliInstallErr      = "resource temporarily unavailable"
@@ -249,6 +261,8 @@ const (
 	AWMRelayerKeyName = "cli-awm-relayer"
 
 	AWMRelayerMetricsPort = 9091
+	// API port of the relayers of named local networks, offset by their slot
+	AWMRelayerAPIPort = 9092
 
 	SubnetEVMBin = "subnet-evm"
 
@@ -356,4 +370,7 @@ const (
 	ICTTURL     = "https://github.com/ava-labs/avalanche-interchain-token-transfer"
 	ICTTBranch  = "main"
 	ICTTVersion = "v1.0.0"
+	// registry of the Token Transferrers deployed by CLI
+	ICTTRegistryDir      = "ictt"
+	ICTTRegistryFileName = "deployments.json"
 )
@@ -0,0 +1,55 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// FoundryArtifact is the subset of a forge build output file (out/<File>.sol/<Contract>.json)
+// used by CLI. StorageLayout is only present if the contract was built with
+// forge build --extra-output storageLayout
+type FoundryArtifact struct {
+	ABI      json.RawMessage `json:"abi"`
+	Bytecode struct {
+		Object string `json:"object"`
+	} `json:"bytecode"`
+	StorageLayout *StorageLayout `json:"storageLayout,omitempty"`
+}
+
+// LoadFoundryArtifact loads a forge build output file
+func LoadFoundryArtifact(path string) (FoundryArtifact, error) {
+	var artifact FoundryArtifact
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return artifact, err
+	}
+	if err := json.Unmarshal(bs, &artifact); err != nil {
+		return artifact, fmt.Errorf("failure parsing foundry artifact %s: %w", path, err)
+	}
+	if artifact.Bytecode.Object == "" {
+		return artifact, fmt.Errorf("no bytecode found at foundry artifact %s", path)
+	}
+	return artifact, nil
+}
+
+// LoadContractBytecode loads the hex encoded creation bytecode of a contract from [path],
+// that can be either a raw hex file (as produced by solc --bin) or a foundry artifact
+func LoadContractBytecode(path string) ([]byte, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	content := strings.TrimSpace(string(bs))
+	if strings.HasPrefix(content, "{") {
+		artifact, err := LoadFoundryArtifact(path)
+		if err != nil {
+			return nil, err
+		}
+		content = artifact.Bytecode.Object
+	}
+	return []byte(content), nil
+}
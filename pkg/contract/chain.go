@@ -33,22 +33,47 @@ func AddChainFlagsToCmd(
 	cmd.Flags().BoolVar(&chainFlags.CChain, cChainFlagName, false, fmt.Sprintf("%s into C-Chain", goal))
 }
 
-func GetRPCURL(
+func GetBlockchainID(
 	app *application.Avalanche,
 	network models.Network,
 	subnetName string,
 	isCChain bool,
 ) (string, error) {
 	if isCChain {
-		return network.CChainEndpoint(), nil
-	} else {
-		sc, err := app.LoadSidecar(subnetName)
-		if err != nil {
-			return "", fmt.Errorf("failed to load sidecar: %w", err)
-		}
-		if sc.Networks[network.Name()].BlockchainID == ids.Empty {
-			return "", fmt.Errorf("subnet has not been deployed to %s", network.Name())
-		}
-		return network.BlockchainEndpoint(sc.Networks[network.Name()].BlockchainID.String()), nil
+		return "C", nil
+	}
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return "", fmt.Errorf("failed to load sidecar: %w", err)
+	}
+	if sc.Networks[network.Name()].BlockchainID == ids.Empty {
+		return "", fmt.Errorf("subnet has not been deployed to %s", network.Name())
+	}
+	return sc.Networks[network.Name()].BlockchainID.String(), nil
+}
+
+func GetRPCURL(
+	app *application.Avalanche,
+	network models.Network,
+	subnetName string,
+	isCChain bool,
+) (string, error) {
+	blockchainID, err := GetBlockchainID(app, network, subnetName, isCChain)
+	if err != nil {
+		return "", err
+	}
+	return network.BlockchainEndpoint(blockchainID), nil
+}
+
+func GetWSURL(
+	app *application.Avalanche,
+	network models.Network,
+	subnetName string,
+	isCChain bool,
+) (string, error) {
+	blockchainID, err := GetBlockchainID(app, network, subnetName, isCChain)
+	if err != nil {
+		return "", err
 	}
+	return network.BlockchainWSEndpoint(blockchainID), nil
 }
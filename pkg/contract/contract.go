@@ -147,10 +147,11 @@ func getMap(
 			if err != nil {
 				return nil, err
 			}
-			m["components"], err = getMap(getWords(t), param)
+			components, err := getMap(getWords(t), param)
 			if err != nil {
 				return nil, err
 			}
+			m["components"] = nameComponents(components)
 			if structName != "" {
 				m["internalType"] = "struct " + structName
 			} else {
@@ -169,16 +170,20 @@ func getMap(
 				if err != nil {
 					return nil, err
 				}
-				rt := reflect.ValueOf(param)
-				if rt.Kind() != reflect.Slice {
-					return nil, fmt.Errorf("expected param for field %d of esp %q to be an slice", i, types)
+				// with no go value given, the struct fields are left unnamed
+				if param != nil {
+					rt := reflect.ValueOf(param)
+					if rt.Kind() != reflect.Slice {
+						return nil, fmt.Errorf("expected param for field %d of esp %q to be an slice", i, types)
+					}
+					param = reflect.Zero(rt.Type().Elem()).Interface()
+					structName = rt.Type().Elem().Name()
 				}
-				param = reflect.Zero(rt.Type().Elem()).Interface()
-				structName = rt.Type().Elem().Name()
-				m["components"], err = getMap(getWords(t), param)
+				components, err := getMap(getWords(t), param)
 				if err != nil {
 					return nil, err
 				}
+				m["components"] = nameComponents(components)
 				if structName != "" {
 					m["internalType"] = "struct " + structName + "[]"
 				} else {
@@ -201,6 +206,17 @@ func getMap(
 	return r, nil
 }
 
+// abi does not support anonymous struct fields, so unnamed ones (as
+// obtained when no go value is given for the struct) get a positional name
+func nameComponents(components []map[string]interface{}) []map[string]interface{} {
+	for i := range components {
+		if components[i]["name"] == "" {
+			components[i]["name"] = fieldName(i)
+		}
+	}
+	return components
+}
+
 func ParseEsp(
 	esp string,
 	indexedFields []int,
@@ -315,13 +331,13 @@ func TxToMethod(
 	txOpts.Value = payment
 	tx, err := contract.Transact(txOpts, methodName, params...)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, revertError(err)
 	}
 	receipt, success, err := evm.WaitForTransaction(client, tx)
 	if err != nil {
 		return tx, nil, err
 	} else if !success {
-		return tx, receipt, ErrFailedReceiptStatus
+		return tx, receipt, failedReceiptError(rpcURL, tx, receipt)
 	}
 	return tx, receipt, nil
 }
@@ -357,6 +373,26 @@ func CallToMethod(
 	return out, nil
 }
 
+// PackMethodCall returns the input data of a call to [methodEsp] with [params],
+// as sent by TxToMethod, without issuing any tx
+func PackMethodCall(
+	methodEsp string,
+	params ...interface{},
+) ([]byte, error) {
+	methodName, methodABI, err := ParseEsp(methodEsp, nil, false, false, false, false, params...)
+	if err != nil {
+		return nil, err
+	}
+	metadata := &bind.MetaData{
+		ABI: methodABI,
+	}
+	abi, err := metadata.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return abi.Pack(methodName, params...)
+}
+
 func DeployContract(
 	rpcURL string,
 	privateKey string,
@@ -388,12 +424,12 @@ func DeployContract(
 	}
 	address, tx, _, err := bind.DeployContract(txOpts, *abi, bin, client, params...)
 	if err != nil {
-		return common.Address{}, err
+		return common.Address{}, revertError(err)
 	}
-	if _, success, err := evm.WaitForTransaction(client, tx); err != nil {
+	if receipt, success, err := evm.WaitForTransaction(client, tx); err != nil {
 		return common.Address{}, err
 	} else if !success {
-		return common.Address{}, ErrFailedReceiptStatus
+		return common.Address{}, failedReceiptError(rpcURL, tx, receipt)
 	}
 	return address, nil
 }
@@ -0,0 +1,113 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: Ecosystem
+
+pragma solidity ^ 0.8.18;
+
+import "@openzeppelin/contracts@4.8.1/token/ERC20/ERC20.sol";
+import "@openzeppelin/contracts@4.8.1/token/ERC20/extensions/draft-ERC20Permit.sol";
+import "@openzeppelin/contracts@4.8.1/access/AccessControl.sol";
+import "@openzeppelin/contracts@4.8.1/security/Pausable.sol";
+
+// optional behaviours of the token, fixed at deploy time
+struct TokenFeatures {
+    uint8 decimals;
+    bool mintable;
+    bool burnable;
+    // max supply in whole tokens. 0 means uncapped
+    uint256 cap;
+    bool pausable;
+    bool permit;
+}
+
+contract ConfigurableToken is ERC20, ERC20Permit, AccessControl, Pausable {
+    bytes32 public constant MINTER_ROLE = keccak256("MINTER_ROLE");
+    bytes32 public constant PAUSER_ROLE = keccak256("PAUSER_ROLE");
+
+    uint8 private immutable _decimals;
+    uint256 private immutable _cap;
+    bool public immutable mintable;
+    bool public immutable burnable;
+    bool public immutable pausable;
+    bool public immutable permitEnabled;
+
+    constructor(
+        string memory name,
+        string memory symbol,
+        address funded,
+        uint256 balance,
+        address admin,
+        address minter,
+        TokenFeatures memory features
+    ) ERC20(name, symbol) ERC20Permit(name) {
+        require(features.cap == 0 || balance <= features.cap, "ConfigurableToken: supply exceeds cap");
+        _decimals = features.decimals;
+        _cap = features.cap * 10 ** features.decimals;
+        mintable = features.mintable;
+        burnable = features.burnable;
+        pausable = features.pausable;
+        permitEnabled = features.permit;
+        _grantRole(DEFAULT_ADMIN_ROLE, admin);
+        if (features.mintable) {
+            _grantRole(MINTER_ROLE, minter);
+        }
+        if (features.pausable) {
+            _grantRole(PAUSER_ROLE, admin);
+        }
+        _mint(funded, balance * 10 ** features.decimals);
+    }
+
+    function decimals() public view override returns (uint8) {
+        return _decimals;
+    }
+
+    function cap() public view returns (uint256) {
+        return _cap;
+    }
+
+    function mint(address to, uint256 amount) public onlyRole(MINTER_ROLE) {
+        require(mintable, "ConfigurableToken: not mintable");
+        require(_cap == 0 || totalSupply() + amount <= _cap, "ConfigurableToken: cap exceeded");
+        _mint(to, amount);
+    }
+
+    function burn(uint256 amount) public {
+        require(burnable, "ConfigurableToken: not burnable");
+        _burn(_msgSender(), amount);
+    }
+
+    function burnFrom(address account, uint256 amount) public {
+        require(burnable, "ConfigurableToken: not burnable");
+        _spendAllowance(account, _msgSender(), amount);
+        _burn(account, amount);
+    }
+
+    function pause() public onlyRole(PAUSER_ROLE) {
+        require(pausable, "ConfigurableToken: not pausable");
+        _pause();
+    }
+
+    function unpause() public onlyRole(PAUSER_ROLE) {
+        require(pausable, "ConfigurableToken: not pausable");
+        _unpause();
+    }
+
+    function permit(
+        address owner,
+        address spender,
+        uint256 value,
+        uint256 deadline,
+        uint8 v,
+        bytes32 r,
+        bytes32 s
+    ) public override {
+        require(permitEnabled, "ConfigurableToken: permit not enabled");
+        super.permit(owner, spender, value, deadline, v, r, s);
+    }
+
+    function _beforeTokenTransfer(address from, address to, uint256 amount) internal override {
+        require(!paused(), "ConfigurableToken: token transfer while paused");
+        super._beforeTokenTransfer(from, to, amount);
+    }
+}
@@ -0,0 +1,23 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: Ecosystem
+
+pragma solidity ^ 0.8.18;
+
+import "@openzeppelin/contracts@4.8.1/token/ERC1155/ERC1155.sol";
+import "@openzeppelin/contracts@4.8.1/access/Ownable.sol";
+
+contract MultiToken is ERC1155, Ownable {
+    string public name;
+    string public symbol;
+
+    constructor(string memory name_, string memory symbol_, string memory uri_) ERC1155(uri_) {
+        name = name_;
+        symbol = symbol_;
+    }
+
+    function mint(address to, uint256 id, uint256 amount, bytes memory data) public onlyOwner {
+        _mint(to, id, amount, data);
+    }
+}
@@ -0,0 +1,30 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: Ecosystem
+
+pragma solidity ^ 0.8.18;
+
+import "@openzeppelin/contracts@4.8.1/token/ERC721/ERC721.sol";
+import "@openzeppelin/contracts@4.8.1/access/Ownable.sol";
+
+contract NFT is ERC721, Ownable {
+    string private _baseTokenURI;
+    uint256 private _nextTokenId;
+
+    constructor(string memory name, string memory symbol, string memory baseURI) ERC721(name, symbol) {
+        _baseTokenURI = baseURI;
+    }
+
+    // mints the next token id into [to]
+    function mint(address to) public onlyOwner returns (uint256) {
+        uint256 tokenId = _nextTokenId;
+        _nextTokenId++;
+        _safeMint(to, tokenId);
+        return tokenId;
+    }
+
+    function _baseURI() internal view override returns (string memory) {
+        return _baseTokenURI;
+    }
+}
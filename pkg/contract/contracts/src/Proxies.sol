@@ -0,0 +1,12 @@
+// (c) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: Ecosystem
+
+pragma solidity ^ 0.8.18;
+
+// OpenZeppelin proxies deployed by avalanche contract deploy bytecode --proxy.
+// They are used as is, only imported here so forge builds them.
+import "@openzeppelin/contracts@4.8.1/proxy/ERC1967/ERC1967Proxy.sol";
+import "@openzeppelin/contracts@4.8.1/proxy/transparent/TransparentUpgradeableProxy.sol";
+import "@openzeppelin/contracts@4.8.1/proxy/transparent/ProxyAdmin.sol";
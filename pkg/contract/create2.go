@@ -0,0 +1,210 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Deterministic deployment proxy (https://github.com/Arachnid/deterministic-deployment-proxy)
+//
+// The proxy is deployed with a keyless presigned transaction (Nick's method), the same
+// way Teleporter Messenger is deployed, so it lands on the same address on every chain.
+// Once deployed, calling it with data = salt (32 bytes) ++ init code deploys the
+// contract through CREATE2, at an address that only depends on the salt and the init code.
+const (
+	Create2FactoryAddress         = "0x4e59b44847b379578588920cA78FbF26c0B4956C"
+	Create2FactoryDeployerAddress = "0x3fab184622dc19b6109349b94811493bf2a45362"
+	create2FactoryDeployerTx      = "0xf8a58085174876e800830186a08080b853604580600e600039806000f350fe7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe03601600081602082378035828234f58015156039578182fd5b8082525050506014600cf31ba02222222222222222222222222222222222222222222222222222222222222222a02222222222222222222222222222222222222222222222222222222222222222"
+	create2FactoryRuntimeCode     = "0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe03601600081602082378035828234f58015156039578182fd5b8082525050506014600cf3"
+	create2FactoryDeployerGas     = 100_000
+)
+
+var (
+	// gas price fixed in the presigned deploy tx: 100 gwei
+	create2FactoryDeployerGasPrice = big.NewInt(100_000_000_000)
+	// 0.01 AVAX: gas * gas price of the presigned deploy tx
+	create2FactoryDeployerRequiredBalance = new(big.Int).Mul(
+		big.NewInt(create2FactoryDeployerGas),
+		create2FactoryDeployerGasPrice,
+	)
+)
+
+// GetCreate2FactoryGenesisCode returns the address and runtime code of the
+// deterministic deployment proxy, to be included into a genesis allocation
+func GetCreate2FactoryGenesisCode() (common.Address, []byte) {
+	return common.HexToAddress(Create2FactoryAddress), common.FromHex(create2FactoryRuntimeCode)
+}
+
+// DeployCreate2Factory deploys the deterministic deployment proxy into the
+// blockchain at [rpcURL], funding the keyless deployer from [privateKey] if needed.
+// Returns true if the proxy was already deployed
+func DeployCreate2Factory(
+	rpcURL string,
+	privateKey string,
+) (bool, error) {
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+	if alreadyDeployed, err := evm.ContractAlreadyDeployed(client, Create2FactoryAddress); err != nil {
+		return false, fmt.Errorf("failure making a request to %s: %w", rpcURL, err)
+	} else if alreadyDeployed {
+		return true, nil
+	}
+	baseFee, err := evm.EstimateBaseFee(client)
+	if err != nil {
+		return false, err
+	}
+	if baseFee.Cmp(create2FactoryDeployerGasPrice) > 0 {
+		return false, fmt.Errorf(
+			"base fee %s is greater than the presigned deploy tx gas price %s: the CREATE2 factory can only be included at genesis on this blockchain",
+			baseFee,
+			create2FactoryDeployerGasPrice,
+		)
+	}
+	deployerBalance, err := evm.GetAddressBalance(client, Create2FactoryDeployerAddress)
+	if err != nil {
+		return false, err
+	}
+	if deployerBalance.Cmp(create2FactoryDeployerRequiredBalance) < 0 {
+		toFund := new(big.Int).Sub(create2FactoryDeployerRequiredBalance, deployerBalance)
+		if err := evm.FundAddress(client, privateKey, Create2FactoryDeployerAddress, toFund); err != nil {
+			return false, err
+		}
+	}
+	if err := evm.IssueTx(client, create2FactoryDeployerTx); err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// GetCreate2InitCode returns the contract creation code for [binBytes], with the
+// constructor params, described by [methodEsp], appended to it
+func GetCreate2InitCode(
+	binBytes []byte,
+	methodEsp string,
+	params ...interface{},
+) ([]byte, error) {
+	bin := common.FromHex(strings.TrimSpace(string(binBytes)))
+	if len(bin) == 0 {
+		return nil, fmt.Errorf("empty contract bytecode")
+	}
+	if methodEsp == "" {
+		return bin, nil
+	}
+	_, methodABI, err := ParseEsp(methodEsp, nil, true, false, false, false, params...)
+	if err != nil {
+		return nil, err
+	}
+	metadata := &bind.MetaData{
+		ABI: methodABI,
+	}
+	abi, err := metadata.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	packedParams, err := abi.Pack("", params...)
+	if err != nil {
+		return nil, err
+	}
+	return append(bin, packedParams...), nil
+}
+
+// GetCreate2Address returns the address a contract with [initCode] is going to be
+// deployed to by the deterministic deployment proxy, using [salt]
+func GetCreate2Address(
+	salt common.Hash,
+	initCode []byte,
+) common.Address {
+	return crypto.CreateAddress2(
+		common.HexToAddress(Create2FactoryAddress),
+		salt,
+		crypto.Keccak256(initCode),
+	)
+}
+
+// GetCreate2Salt returns [salt] as is if it is an hex encoded 32 bytes value,
+// the zero hash if it is empty, or the keccak256 hash of it in other case
+func GetCreate2Salt(salt string) common.Hash {
+	if salt == "" {
+		return common.Hash{}
+	}
+	if strings.HasPrefix(salt, "0x") && len(salt) == 2+2*common.HashLength {
+		if bs := common.FromHex(salt); len(bs) == common.HashLength {
+			return common.BytesToHash(bs)
+		}
+	}
+	return crypto.Keccak256Hash([]byte(salt))
+}
+
+// DeployContractCreate2 deploys the contract [binBytes] through the deterministic
+// deployment proxy, so that the address only depends on the bytecode, the
+// constructor params and [salt], and not on the deployer key or nonce.
+// Returns the contract address, and true if it was already deployed
+func DeployContractCreate2(
+	rpcURL string,
+	privateKey string,
+	salt common.Hash,
+	binBytes []byte,
+	methodEsp string,
+	params ...interface{},
+) (common.Address, bool, error) {
+	initCode, err := GetCreate2InitCode(binBytes, methodEsp, params...)
+	if err != nil {
+		return common.Address{}, false, err
+	}
+	address := GetCreate2Address(salt, initCode)
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return common.Address{}, false, err
+	}
+	defer client.Close()
+	if factoryDeployed, err := evm.ContractAlreadyDeployed(client, Create2FactoryAddress); err != nil {
+		return common.Address{}, false, fmt.Errorf("failure making a request to %s: %w", rpcURL, err)
+	} else if !factoryDeployed {
+		return common.Address{}, false, fmt.Errorf("CREATE2 factory is not deployed at %s", Create2FactoryAddress)
+	}
+	if alreadyDeployed, err := evm.ContractAlreadyDeployed(client, address.Hex()); err != nil {
+		return common.Address{}, false, fmt.Errorf("failure making a request to %s: %w", rpcURL, err)
+	} else if alreadyDeployed {
+		ux.Logger.PrintToUser("Contract has already been deployed at %s", address.Hex())
+		return address, true, nil
+	}
+	txOpts, err := evm.GetTxOptsWithSigner(client, privateKey)
+	if err != nil {
+		return common.Address{}, false, err
+	}
+	factory := bind.NewBoundContract(
+		common.HexToAddress(Create2FactoryAddress),
+		abi.ABI{},
+		client,
+		client,
+		client,
+	)
+	tx, err := factory.RawTransact(txOpts, append(salt.Bytes(), initCode...))
+	if err != nil {
+		return common.Address{}, false, revertError(err)
+	}
+	if receipt, success, err := evm.WaitForTransaction(client, tx); err != nil {
+		return common.Address{}, false, err
+	} else if !success {
+		return common.Address{}, false, failedReceiptError(rpcURL, tx, receipt)
+	}
+	if deployed, err := evm.ContractAlreadyDeployed(client, address.Hex()); err != nil {
+		return common.Address{}, false, err
+	} else if !deployed {
+		return common.Address{}, false, fmt.Errorf("no contract code found at expected CREATE2 address %s", address.Hex())
+	}
+	return address, false, nil
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreate2FactoryDeployerTx(t *testing.T) {
+	require := require.New(t)
+	tx := new(types.Transaction)
+	require.NoError(tx.UnmarshalBinary(common.FromHex(create2FactoryDeployerTx)))
+	sender, err := types.Sender(types.HomesteadSigner{}, tx)
+	require.NoError(err)
+	require.Equal(common.HexToAddress(Create2FactoryDeployerAddress), sender)
+	require.Equal(common.HexToAddress(Create2FactoryAddress), crypto.CreateAddress(sender, 0))
+	require.Equal(create2FactoryDeployerRequiredBalance, new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas()), tx.GasPrice()))
+	address, code := GetCreate2FactoryGenesisCode()
+	require.Equal(common.HexToAddress(Create2FactoryAddress), address)
+	require.Equal(code, tx.Data()[len(tx.Data())-len(code):])
+}
+
+func TestCreate2Address(t *testing.T) {
+	require := require.New(t)
+	salt := "0x0000000000000000000000000000000000000000000000000000000000000001"
+	require.Equal(common.HexToHash(salt), GetCreate2Salt(salt))
+	require.Equal(common.Hash{}, GetCreate2Salt(""))
+	require.Equal(crypto.Keccak256Hash([]byte("my-dapp")), GetCreate2Salt("my-dapp"))
+	initCode, err := GetCreate2InitCode(tokenBin, "(string, address, uint256)", "TOK", common.Address{}, big.NewInt(1))
+	require.NoError(err)
+	otherInitCode, err := GetCreate2InitCode(tokenBin, "(string, address, uint256)", "TOK", common.Address{}, big.NewInt(2))
+	require.NoError(err)
+	require.NotEqual(GetCreate2Address(GetCreate2Salt("a"), initCode), GetCreate2Address(GetCreate2Salt("b"), initCode))
+	require.NotEqual(GetCreate2Address(GetCreate2Salt("a"), initCode), GetCreate2Address(GetCreate2Salt("a"), otherInitCode))
+}
@@ -4,14 +4,65 @@ package contract
 
 import (
 	_ "embed"
+	"fmt"
 	"math/big"
 
+	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ethereum/go-ethereum/common"
 )
 
+const (
+	DefaultERC20Decimals = 18
+
+	configurableTokenConstructorEsp = "(string, string, address, uint256, address, address, (uint8, bool, bool, uint256, bool, bool))"
+)
+
+// contract bytecode under contracts/bin is generated from contracts/src by
+// scripts/build_contracts.sh, with the solc version pinned there
+//
 //go:embed contracts/bin/Token.bin
 var tokenBin []byte
 
+//go:embed contracts/bin/ConfigurableToken.bin
+var configurableTokenBin []byte
+
+// checks that the embedded bytecode of [contractName] has been generated
+func checkEmbeddedBytecode(contractName string, bin []byte) error {
+	if len(bin) == 0 {
+		return fmt.Errorf(
+			"%s bytecode is not available on this build. generate it with scripts/build_contracts.sh",
+			contractName,
+		)
+	}
+	return nil
+}
+
+// TokenFeatures is the go counterpart of ConfigurableToken.sol constructor features struct
+type TokenFeatures struct {
+	Decimals uint8
+	Mintable bool
+	Burnable bool
+	Cap      *big.Int
+	Pausable bool
+	Permit   bool
+}
+
+// IsDefaultERC20 indicates if [features] are the ones of the fixed supply
+// token deployed by DeployERC20
+func IsDefaultERC20(features models.ERC20Features) bool {
+	return features.Decimals == DefaultERC20Decimals &&
+		!features.Mintable &&
+		!features.Burnable &&
+		(features.Cap == nil || features.Cap.Sign() == 0) &&
+		!features.Pausable &&
+		!features.Permit
+}
+
+// GetDefaultERC20Name returns the token name set by DeployERC20 for [symbol]
+func GetDefaultERC20Name(symbol string) string {
+	return symbol + " Token"
+}
+
 func DeployERC20(
 	rpcURL string,
 	privateKey string,
@@ -29,3 +80,114 @@ func DeployERC20(
 		supply,
 	)
 }
+
+func DeployERC20Create2(
+	rpcURL string,
+	privateKey string,
+	salt common.Hash,
+	symbol string,
+	funded common.Address,
+	supply *big.Int,
+) (common.Address, bool, error) {
+	return DeployContractCreate2(
+		rpcURL,
+		privateKey,
+		salt,
+		tokenBin,
+		"(string, address, uint256)",
+		symbol,
+		funded,
+		supply,
+	)
+}
+
+func getConfigurableERC20Params(
+	name string,
+	symbol string,
+	funded common.Address,
+	supply *big.Int,
+	admin common.Address,
+	features models.ERC20Features,
+) ([]interface{}, error) {
+	if err := checkEmbeddedBytecode("ConfigurableToken", configurableTokenBin); err != nil {
+		return nil, err
+	}
+	minter := admin
+	if features.Minter != "" {
+		minter = common.HexToAddress(features.Minter)
+	}
+	tokenCap := features.Cap
+	if tokenCap == nil {
+		tokenCap = big.NewInt(0)
+	}
+	if tokenCap.Sign() > 0 && supply.Cmp(tokenCap) > 0 {
+		return nil, fmt.Errorf("token supply %s exceeds token cap %s", supply, tokenCap)
+	}
+	return []interface{}{
+		name,
+		symbol,
+		funded,
+		supply,
+		admin,
+		minter,
+		TokenFeatures{
+			Decimals: features.Decimals,
+			Mintable: features.Mintable,
+			Burnable: features.Burnable,
+			Cap:      tokenCap,
+			Pausable: features.Pausable,
+			Permit:   features.Permit,
+		},
+	}, nil
+}
+
+// DeployConfigurableERC20 deploys an ERC20 token with the optional behaviours given
+// by [features]. [admin] is given the role to manage minters and pausers. It is
+// also set as minter if no specific minter is given on [features]
+func DeployConfigurableERC20(
+	rpcURL string,
+	privateKey string,
+	name string,
+	symbol string,
+	funded common.Address,
+	supply *big.Int,
+	admin common.Address,
+	features models.ERC20Features,
+) (common.Address, error) {
+	params, err := getConfigurableERC20Params(name, symbol, funded, supply, admin, features)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return DeployContract(
+		rpcURL,
+		privateKey,
+		configurableTokenBin,
+		configurableTokenConstructorEsp,
+		params...,
+	)
+}
+
+func DeployConfigurableERC20Create2(
+	rpcURL string,
+	privateKey string,
+	salt common.Hash,
+	name string,
+	symbol string,
+	funded common.Address,
+	supply *big.Int,
+	admin common.Address,
+	features models.ERC20Features,
+) (common.Address, bool, error) {
+	params, err := getConfigurableERC20Params(name, symbol, funded, supply, admin, features)
+	if err != nil {
+		return common.Address{}, false, err
+	}
+	return DeployContractCreate2(
+		rpcURL,
+		privateKey,
+		salt,
+		configurableTokenBin,
+		configurableTokenConstructorEsp,
+		params...,
+	)
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsDefaultERC20(t *testing.T) {
+	require := require.New(t)
+	features := models.ERC20Features{Decimals: DefaultERC20Decimals}
+	require.True(IsDefaultERC20(features))
+	features.Cap = big.NewInt(0)
+	require.True(IsDefaultERC20(features))
+	features.Cap = big.NewInt(1000)
+	require.False(IsDefaultERC20(features))
+	require.False(IsDefaultERC20(models.ERC20Features{Decimals: 6}))
+	require.False(IsDefaultERC20(models.ERC20Features{Decimals: DefaultERC20Decimals, Permit: true}))
+}
+
+func TestConfigurableERC20ConstructorPacking(t *testing.T) {
+	require := require.New(t)
+	funded := common.HexToAddress("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC")
+	initCode, err := GetCreate2InitCode(
+		[]byte("0x00"),
+		configurableTokenConstructorEsp,
+		"Test Token",
+		"TST",
+		funded,
+		big.NewInt(1000),
+		funded,
+		funded,
+		TokenFeatures{
+			Decimals: 6,
+			Mintable: true,
+			Cap:      big.NewInt(2000),
+			Permit:   true,
+		},
+	)
+	require.NoError(err)
+	// bytecode, 6 head words (2 of them offsets to the strings), 6 inlined struct
+	// words, and 2 words for each string
+	require.Len(initCode, 1+32*(6+6+2+2))
+	word := func(i int) []byte {
+		return initCode[1+32*i : 1+32*(i+1)]
+	}
+	require.Equal(common.LeftPadBytes([]byte{6}, 32), word(6))
+	require.Equal(common.LeftPadBytes([]byte{1}, 32), word(7))
+	require.Equal(common.LeftPadBytes(big.NewInt(2000).Bytes(), 32), word(9))
+}
+
+// fails if any embedded contract bytecode has not been generated with scripts/build_contracts.sh
+func TestEmbeddedBytecode(t *testing.T) {
+	for name, bin := range map[string][]byte{
+		"Token":                        tokenBin,
+		"ConfigurableToken":            configurableTokenBin,
+		"NFT":                          nftBin,
+		"MultiToken":                   multiTokenBin,
+		"SafeL2":                       safeL2Bin,
+		"SafeProxyFactory":             safeProxyFactoryBin,
+		"CompatibilityFallbackHandler": safeFallbackHandlerBin,
+		"ERC1967Proxy":                 erc1967ProxyBin,
+		"TransparentUpgradeableProxy":  transparentProxyBin,
+		"ProxyAdmin":                   proxyAdminBin,
+		"Multicall3":                   multicallBin,
+		"Multicall3Runtime":            multicallRuntimeBin,
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, checkEmbeddedBytecode(name, bin))
+			require.NotEmpty(t, common.FromHex(strings.TrimSpace(string(bin))))
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GetEvent returns the ABI event described by [eventEsp] and [indexedFields]
+func GetEvent(
+	eventEsp string,
+	indexedFields []int,
+) (abi.Event, error) {
+	// no go value is given to get the field names from, so fields are left unnamed
+	eventName, eventABI, err := ParseEsp(eventEsp, indexedFields, false, true, false, false, nil)
+	if err != nil {
+		return abi.Event{}, err
+	}
+	metadata := &bind.MetaData{
+		ABI: eventABI,
+	}
+	contractABI, err := metadata.GetAbi()
+	if err != nil {
+		return abi.Event{}, err
+	}
+	event, ok := contractABI.Events[eventName]
+	if !ok {
+		return abi.Event{}, fmt.Errorf("event %s not found on esp %q", eventName, eventEsp)
+	}
+	return event, nil
+}
+
+// GetEventTopic returns the topic (signature hash) of the event described by [eventEsp]
+func GetEventTopic(
+	eventEsp string,
+	indexedFields []int,
+) (common.Hash, error) {
+	event, err := GetEvent(eventEsp, indexedFields)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return event.ID, nil
+}
+
+// UnpackLogValues decodes [log] as an instance of the event [eventEsp], without the need of
+// a go struct to unpack into as UnpackLog requires. Returns the event fields, in order.
+// Indexed fields of dynamic types (strings, bytes, arrays) are returned as their
+// keccak256 hash, as that is what is stored on the topics
+func UnpackLogValues(
+	eventEsp string,
+	indexedFields []int,
+	log types.Log,
+) ([]interface{}, error) {
+	event, err := GetEvent(eventEsp, indexedFields)
+	if err != nil {
+		return nil, err
+	}
+	if len(log.Topics) == 0 || log.Topics[0] != event.ID {
+		return nil, fmt.Errorf("log topic does not match event %s", event.Sig)
+	}
+	// name the fields, so they can be unpacked into a map
+	indexedArgs := abi.Arguments{}
+	nonIndexedArgs := abi.Arguments{}
+	for i, arg := range event.Inputs {
+		arg.Name = fieldName(i)
+		if arg.Indexed {
+			indexedArgs = append(indexedArgs, arg)
+		} else {
+			nonIndexedArgs = append(nonIndexedArgs, arg)
+		}
+	}
+	values := map[string]interface{}{}
+	if len(log.Data) > 0 {
+		if err := nonIndexedArgs.UnpackIntoMap(values, log.Data); err != nil {
+			return nil, err
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(values, indexedArgs, log.Topics[1:]); err != nil {
+		return nil, err
+	}
+	fields := []interface{}{}
+	for i := range event.Inputs {
+		fields = append(fields, values[fieldName(i)])
+	}
+	return fields, nil
+}
+
+func fieldName(i int) string {
+	return fmt.Sprintf("field%d", i)
+}
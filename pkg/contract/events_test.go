@@ -0,0 +1,37 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpackLogValues(t *testing.T) {
+	require := require.New(t)
+	from := common.HexToAddress("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC")
+	to := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	amount := big.NewInt(1000)
+	topic, err := GetEventTopic("Transfer(address,address,uint256)", []int{0, 1})
+	require.NoError(err)
+	require.Equal(crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")), topic)
+	log := types.Log{
+		Topics: []common.Hash{
+			topic,
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data: common.LeftPadBytes(amount.Bytes(), 32),
+	}
+	values, err := UnpackLogValues("Transfer(address,address,uint256)", []int{0, 1}, log)
+	require.NoError(err)
+	require.Equal([]interface{}{from, to, amount}, values)
+	// topic mismatch
+	_, err = UnpackLogValues("Approval(address,address,uint256)", []int{0, 1}, log)
+	require.Error(err)
+}
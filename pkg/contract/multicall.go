@@ -0,0 +1,322 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Multicall3 (https://github.com/mds1/multicall) batches several calls into one.
+//
+// It can be included at genesis at its canonical address, the one it has on most EVM
+// chains, or be deployed through the CREATE2 factory, at an address that only depends
+// on its bytecode.
+const (
+	MulticallAddress = "0xcA11bde05977b3631167028862bE2a173976CA11"
+
+	multicallAggregate3Esp      = "aggregate3([(address, bool, bytes)])->([(bool, bytes)])"
+	multicallAggregate3ValueEsp = "aggregate3Value([(address, bool, uint256, bytes)])->([(bool, bytes)])"
+)
+
+var ErrMulticallNotAvailable = errors.New("multicall contract is not deployed on the blockchain")
+
+//go:embed contracts/bin/Multicall3.bin
+var multicallBin []byte
+
+//go:embed contracts/bin/Multicall3Runtime.bin
+var multicallRuntimeBin []byte
+
+// Call is a contract method call to be batched with others. [MethodEsp] describes
+// both the method inputs and outputs (eg "balanceOf(address)->(uint256)")
+type Call struct {
+	Target       common.Address
+	MethodEsp    string
+	Params       []interface{}
+	Value        *big.Int
+	AllowFailure bool
+}
+
+// CallResult is the outcome of a batched call. [Values] are the method
+// outputs, decoded, if the call succeeded
+type CallResult struct {
+	Success    bool
+	ReturnData []byte
+	Values     []interface{}
+}
+
+// CallsFile is the YAML description of a batch of calls, eg
+//
+//	calls:
+//	  - to: "0x..."
+//	    method: "balanceOf(address)->(uint256)"
+//	    args: ["0x..."]
+//	    allowFailure: true
+type CallsFile struct {
+	Calls []CallSpec `yaml:"calls"`
+}
+
+// CallSpec is a call given at a CallsFile. [Value] is in wei
+type CallSpec struct {
+	To           string   `yaml:"to"`
+	Method       string   `yaml:"method"`
+	Args         []string `yaml:"args"`
+	Value        string   `yaml:"value"`
+	AllowFailure bool     `yaml:"allowFailure"`
+}
+
+// go counterparts of Multicall3 Call3 and Call3Value structs
+type multicallCall3 struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+type multicallCall3Value struct {
+	Target       common.Address
+	AllowFailure bool
+	Value        *big.Int
+	CallData     []byte
+}
+
+// GetMulticallGenesisCode returns the canonical address and runtime code of Multicall3,
+// to be included into a genesis allocation
+func GetMulticallGenesisCode() (common.Address, []byte, error) {
+	if err := checkEmbeddedBytecode("Multicall3Runtime", multicallRuntimeBin); err != nil {
+		return common.Address{}, nil, err
+	}
+	return common.HexToAddress(MulticallAddress), common.FromHex(strings.TrimSpace(string(multicallRuntimeBin))), nil
+}
+
+// GetMulticallCreate2Address returns the address Multicall3 gets when deployed
+// through the CREATE2 factory
+func GetMulticallCreate2Address() (common.Address, error) {
+	if err := checkEmbeddedBytecode("Multicall3", multicallBin); err != nil {
+		return common.Address{}, err
+	}
+	initCode, err := GetCreate2InitCode(multicallBin, "")
+	if err != nil {
+		return common.Address{}, err
+	}
+	return GetCreate2Address(common.Hash{}, initCode), nil
+}
+
+// DeployMulticall deploys Multicall3 through the CREATE2 factory, so it gets the same
+// address on every blockchain. Returns true if it was already deployed
+func DeployMulticall(
+	rpcURL string,
+	privateKey string,
+) (common.Address, bool, error) {
+	if err := checkEmbeddedBytecode("Multicall3", multicallBin); err != nil {
+		return common.Address{}, false, err
+	}
+	return DeployContractCreate2(rpcURL, privateKey, common.Hash{}, multicallBin, "")
+}
+
+// GetMulticallAddress returns the address of the Multicall3 deployment of the blockchain
+// at [rpcURL], either the canonical one or the CREATE2 one
+func GetMulticallAddress(rpcURL string) (common.Address, error) {
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return common.Address{}, err
+	}
+	defer client.Close()
+	if deployed, err := evm.ContractAlreadyDeployed(client, MulticallAddress); err != nil {
+		return common.Address{}, fmt.Errorf("failure making a request to %s: %w", rpcURL, err)
+	} else if deployed {
+		return common.HexToAddress(MulticallAddress), nil
+	}
+	if address, err := GetMulticallCreate2Address(); err == nil {
+		if deployed, err := evm.ContractAlreadyDeployed(client, address.Hex()); err != nil {
+			return common.Address{}, fmt.Errorf("failure making a request to %s: %w", rpcURL, err)
+		} else if deployed {
+			return address, nil
+		}
+	}
+	return common.Address{}, ErrMulticallNotAvailable
+}
+
+// LoadCallsFile reads the CallsFile at [path], returning its calls
+func LoadCallsFile(path string) ([]Call, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var callsFile CallsFile
+	if err := yaml.Unmarshal(bs, &callsFile); err != nil {
+		return nil, fmt.Errorf("failure parsing calls file %s: %w", path, err)
+	}
+	if len(callsFile.Calls) == 0 {
+		return nil, fmt.Errorf("no calls found on calls file %s", path)
+	}
+	calls := []Call{}
+	for i, spec := range callsFile.Calls {
+		if !common.IsHexAddress(spec.To) {
+			return nil, fmt.Errorf("invalid target address %q for call %d", spec.To, i)
+		}
+		params, err := ParseMethodEspValues(spec.Method, spec.Args)
+		if err != nil {
+			return nil, fmt.Errorf("invalid call %d: %w", i, err)
+		}
+		value := big.NewInt(0)
+		if spec.Value != "" {
+			var b bool
+			value, b = new(big.Int).SetString(spec.Value, 0)
+			if !b || value.Sign() < 0 {
+				return nil, fmt.Errorf("invalid value %q for call %d", spec.Value, i)
+			}
+		}
+		calls = append(calls, Call{
+			Target:       common.HexToAddress(spec.To),
+			MethodEsp:    spec.Method,
+			Params:       params,
+			Value:        value,
+			AllowFailure: spec.AllowFailure,
+		})
+	}
+	return calls, nil
+}
+
+func packCalls(calls []Call) ([][]byte, error) {
+	callsData := [][]byte{}
+	for i, call := range calls {
+		callData, err := PackMethodCall(call.MethodEsp, call.Params...)
+		if err != nil {
+			return nil, fmt.Errorf("failure packing call %d to %s: %w", i, call.MethodEsp, err)
+		}
+		callsData = append(callsData, callData)
+	}
+	return callsData, nil
+}
+
+// decodes the [(bool, bytes)] multicall output into the results of [calls]
+func unpackCallResults(calls []Call, out interface{}) ([]CallResult, error) {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Slice || rv.Len() != len(calls) {
+		return nil, fmt.Errorf("unexpected multicall output %#v", out)
+	}
+	results := []CallResult{}
+	for i, call := range calls {
+		result := CallResult{
+			Success:    rv.Index(i).Field(0).Bool(),
+			ReturnData: rv.Index(i).Field(1).Bytes(),
+		}
+		if result.Success {
+			method, err := GetMethodFromEsp(call.MethodEsp)
+			if err != nil {
+				return nil, err
+			}
+			if result.Values, err = method.Outputs.Unpack(result.ReturnData); err != nil {
+				return nil, fmt.Errorf("failure unpacking output of call %d to %s: %w", i, call.MethodEsp, err)
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// MulticallRead executes [calls] in one eth_call through Multicall3. If Multicall3 is
+// not available on the blockchain, the calls are made one by one
+func MulticallRead(
+	rpcURL string,
+	calls []Call,
+) ([]CallResult, error) {
+	multicallAddress, err := GetMulticallAddress(rpcURL)
+	if errors.Is(err, ErrMulticallNotAvailable) {
+		return sequentialRead(rpcURL, calls)
+	}
+	if err != nil {
+		return nil, err
+	}
+	callsData, err := packCalls(calls)
+	if err != nil {
+		return nil, err
+	}
+	call3s := []multicallCall3{}
+	for i, call := range calls {
+		call3s = append(call3s, multicallCall3{
+			Target:       call.Target,
+			AllowFailure: call.AllowFailure,
+			CallData:     callsData[i],
+		})
+	}
+	out, err := CallToMethod(rpcURL, multicallAddress, multicallAggregate3Esp, call3s)
+	if err != nil {
+		return nil, err
+	}
+	return unpackCallResults(calls, out[0])
+}
+
+func sequentialRead(
+	rpcURL string,
+	calls []Call,
+) ([]CallResult, error) {
+	results := []CallResult{}
+	for i, call := range calls {
+		values, err := CallToMethod(rpcURL, call.Target, call.MethodEsp, call.Params...)
+		if err != nil {
+			if !call.AllowFailure {
+				return nil, fmt.Errorf("failure on call %d to %s: %w", i, call.MethodEsp, err)
+			}
+			results = append(results, CallResult{})
+			continue
+		}
+		results = append(results, CallResult{
+			Success: true,
+			Values:  values,
+		})
+	}
+	return results, nil
+}
+
+// MulticallWrite issues [calls] in one tx through Multicall3, paying for the fees and
+// the calls values with [privateKey]. Note that the called contracts see Multicall3,
+// not the signer, as the caller
+func MulticallWrite(
+	rpcURL string,
+	privateKey string,
+	calls []Call,
+) (*types.Transaction, *types.Receipt, error) {
+	multicallAddress, err := GetMulticallAddress(rpcURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	callsData, err := packCalls(calls)
+	if err != nil {
+		return nil, nil, err
+	}
+	totalValue := big.NewInt(0)
+	call3Values := []multicallCall3Value{}
+	for i, call := range calls {
+		value := call.Value
+		if value == nil {
+			value = big.NewInt(0)
+		}
+		totalValue.Add(totalValue, value)
+		call3Values = append(call3Values, multicallCall3Value{
+			Target:       call.Target,
+			AllowFailure: call.AllowFailure,
+			Value:        value,
+			CallData:     callsData[i],
+		})
+	}
+	return TxToMethod(
+		rpcURL,
+		privateKey,
+		multicallAddress,
+		totalValue,
+		multicallAggregate3ValueEsp,
+		call3Values,
+	)
+}
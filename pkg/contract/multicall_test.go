@@ -0,0 +1,91 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCallsFile(t *testing.T) {
+	require := require.New(t)
+	callsFile := `calls:
+  - to: "0x0200000000000000000000000000000000000002"
+    method: "readAllowList(address)->(uint256)"
+    args: ["0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"]
+  - to: "0x0200000000000000000000000000000000000001"
+    method: "mintNativeCoin(address, uint256)"
+    args: ["0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC", "1000"]
+    value: "0x10"
+    allowFailure: true
+`
+	path := filepath.Join(t.TempDir(), "calls.yaml")
+	require.NoError(os.WriteFile(path, []byte(callsFile), 0o600))
+	calls, err := LoadCallsFile(path)
+	require.NoError(err)
+	require.Len(calls, 2)
+	require.Equal(common.HexToAddress("0x0200000000000000000000000000000000000002"), calls[0].Target)
+	require.Equal([]interface{}{common.HexToAddress("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC")}, calls[0].Params)
+	require.Equal(big.NewInt(0), calls[0].Value)
+	require.False(calls[0].AllowFailure)
+	require.Equal(big.NewInt(1000), calls[1].Params[1])
+	require.Equal(big.NewInt(16), calls[1].Value)
+	require.True(calls[1].AllowFailure)
+
+	invalidCallsFile := `calls:
+  - to: "0x0200000000000000000000000000000000000002"
+    method: "readAllowList(address)->(uint256)"
+`
+	require.NoError(os.WriteFile(path, []byte(invalidCallsFile), 0o600))
+	_, err = LoadCallsFile(path)
+	require.ErrorContains(err, "expected 1 values")
+}
+
+func TestUnpackCallResults(t *testing.T) {
+	require := require.New(t)
+	calls := []Call{
+		{MethodEsp: "balanceOf(address)->(uint256)"},
+		{MethodEsp: "symbol()->(string)", AllowFailure: true},
+		{MethodEsp: "getOwners()->([address])"},
+	}
+	balanceMethod, err := GetMethodFromEsp(calls[0].MethodEsp)
+	require.NoError(err)
+	balanceData, err := balanceMethod.Outputs.Pack(big.NewInt(42))
+	require.NoError(err)
+	ownersMethod, err := GetMethodFromEsp(calls[2].MethodEsp)
+	require.NoError(err)
+	owners := []common.Address{common.HexToAddress("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC")}
+	ownersData, err := ownersMethod.Outputs.Pack(owners)
+	require.NoError(err)
+	// encode and decode the results as an aggregate3 output, which has unnamed fields
+	aggregate3, err := GetMethodFromEsp(multicallAggregate3Esp)
+	require.NoError(err)
+	type result struct {
+		Field0 bool
+		Field1 []byte
+	}
+	packed, err := aggregate3.Outputs.Pack([]result{
+		{Field0: true, Field1: balanceData},
+		{Field0: false, Field1: []byte{}},
+		{Field0: true, Field1: ownersData},
+	})
+	require.NoError(err)
+	out, err := aggregate3.Outputs.Unpack(packed)
+	require.NoError(err)
+	results, err := unpackCallResults(calls, out[0])
+	require.NoError(err)
+	require.Len(results, 3)
+	require.True(results[0].Success)
+	require.Equal([]interface{}{big.NewInt(42)}, results[0].Values)
+	require.False(results[1].Success)
+	require.Nil(results[1].Values)
+	require.Equal([]interface{}{owners}, results[2].Values)
+
+	_, err = unpackCallResults(calls[:2], out[0])
+	require.Error(err)
+}
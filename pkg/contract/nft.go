@@ -0,0 +1,290 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	_ "embed"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type NFTStandard int64
+
+const (
+	UndefinedNFT NFTStandard = iota
+	ERC721
+	ERC1155
+)
+
+const (
+	erc721InterfaceID  = "0x80ac58cd"
+	erc1155InterfaceID = "0xd9b67a26"
+	transferEventEsp   = "Transfer(address,address,uint256)"
+)
+
+func (s NFTStandard) String() string {
+	switch s {
+	case ERC721:
+		return "ERC721"
+	case ERC1155:
+		return "ERC1155"
+	}
+	return "undefined"
+}
+
+//go:embed contracts/bin/NFT.bin
+var nftBin []byte
+
+//go:embed contracts/bin/MultiToken.bin
+var multiTokenBin []byte
+
+// DeployERC721 deploys an ERC721 collection owned by the deployer, with
+// token URIs given by [baseURI] + tokenID
+func DeployERC721(
+	rpcURL string,
+	privateKey string,
+	name string,
+	symbol string,
+	baseURI string,
+) (common.Address, error) {
+	if err := checkEmbeddedBytecode("NFT", nftBin); err != nil {
+		return common.Address{}, err
+	}
+	return DeployContract(
+		rpcURL,
+		privateKey,
+		nftBin,
+		"(string, string, string)",
+		name,
+		symbol,
+		baseURI,
+	)
+}
+
+// DeployERC1155 deploys an ERC1155 collection owned by the deployer, with
+// token URIs given by [uri], on which clients replace {id} by the token id
+func DeployERC1155(
+	rpcURL string,
+	privateKey string,
+	name string,
+	symbol string,
+	uri string,
+) (common.Address, error) {
+	if err := checkEmbeddedBytecode("MultiToken", multiTokenBin); err != nil {
+		return common.Address{}, err
+	}
+	return DeployContract(
+		rpcURL,
+		privateKey,
+		multiTokenBin,
+		"(string, string, string)",
+		name,
+		symbol,
+		uri,
+	)
+}
+
+func supportsInterface(
+	rpcURL string,
+	address common.Address,
+	interfaceID string,
+) (bool, error) {
+	out, err := CallToMethod(
+		rpcURL,
+		address,
+		"supportsInterface(bytes4)->(bool)",
+		[4]byte(common.FromHex(interfaceID)),
+	)
+	if err != nil {
+		return false, err
+	}
+	supported, b := out[0].(bool)
+	if !b {
+		return false, fmt.Errorf("error at supportsInterface call, expected bool, got %T", out[0])
+	}
+	return supported, nil
+}
+
+// GetNFTStandard returns the NFT standard implemented by the contract at [address],
+// as informed by ERC165
+func GetNFTStandard(
+	rpcURL string,
+	address common.Address,
+) (NFTStandard, error) {
+	if supported, err := supportsInterface(rpcURL, address, erc721InterfaceID); err != nil {
+		return UndefinedNFT, fmt.Errorf("contract %s does not implement ERC165: %w", address.Hex(), err)
+	} else if supported {
+		return ERC721, nil
+	}
+	if supported, err := supportsInterface(rpcURL, address, erc1155InterfaceID); err != nil {
+		return UndefinedNFT, err
+	} else if supported {
+		return ERC1155, nil
+	}
+	return UndefinedNFT, fmt.Errorf("contract %s is neither an ERC721 nor an ERC1155", address.Hex())
+}
+
+// ERC721Mint mints the next token of the NFT contract at [address] into [to],
+// returning its token id
+func ERC721Mint(
+	rpcURL string,
+	privateKey string,
+	address common.Address,
+	to common.Address,
+) (*big.Int, error) {
+	_, receipt, err := TxToMethod(
+		rpcURL,
+		privateKey,
+		address,
+		nil,
+		"mint(address)->(uint256)",
+		to,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, log := range receipt.Logs {
+		values, err := UnpackLogValues(transferEventEsp, []int{0, 1, 2}, *log)
+		if err != nil {
+			continue
+		}
+		if tokenID, ok := values[2].(*big.Int); ok {
+			return tokenID, nil
+		}
+	}
+	return nil, fmt.Errorf("no Transfer event found on mint receipt")
+}
+
+func ERC1155Mint(
+	rpcURL string,
+	privateKey string,
+	address common.Address,
+	to common.Address,
+	tokenID *big.Int,
+	amount *big.Int,
+) error {
+	_, _, err := TxToMethod(
+		rpcURL,
+		privateKey,
+		address,
+		nil,
+		"mint(address, uint256, uint256, bytes)",
+		to,
+		tokenID,
+		amount,
+		[]byte{},
+	)
+	return err
+}
+
+func ERC721Transfer(
+	rpcURL string,
+	privateKey string,
+	address common.Address,
+	from common.Address,
+	to common.Address,
+	tokenID *big.Int,
+) error {
+	_, _, err := TxToMethod(
+		rpcURL,
+		privateKey,
+		address,
+		nil,
+		"safeTransferFrom(address, address, uint256)",
+		from,
+		to,
+		tokenID,
+	)
+	return err
+}
+
+func ERC1155Transfer(
+	rpcURL string,
+	privateKey string,
+	address common.Address,
+	from common.Address,
+	to common.Address,
+	tokenID *big.Int,
+	amount *big.Int,
+) error {
+	_, _, err := TxToMethod(
+		rpcURL,
+		privateKey,
+		address,
+		nil,
+		"safeTransferFrom(address, address, uint256, uint256, bytes)",
+		from,
+		to,
+		tokenID,
+		amount,
+		[]byte{},
+	)
+	return err
+}
+
+func ERC721OwnerOf(
+	rpcURL string,
+	address common.Address,
+	tokenID *big.Int,
+) (common.Address, error) {
+	out, err := CallToMethod(
+		rpcURL,
+		address,
+		"ownerOf(uint256)->(address)",
+		tokenID,
+	)
+	if err != nil {
+		return common.Address{}, err
+	}
+	owner, b := out[0].(common.Address)
+	if !b {
+		return common.Address{}, fmt.Errorf("error at ownerOf call, expected common.Address, got %T", out[0])
+	}
+	return owner, nil
+}
+
+func ERC721TokenURI(
+	rpcURL string,
+	address common.Address,
+	tokenID *big.Int,
+) (string, error) {
+	out, err := CallToMethod(
+		rpcURL,
+		address,
+		"tokenURI(uint256)->(string)",
+		tokenID,
+	)
+	if err != nil {
+		return "", err
+	}
+	uri, b := out[0].(string)
+	if !b {
+		return "", fmt.Errorf("error at tokenURI call, expected string, got %T", out[0])
+	}
+	return uri, nil
+}
+
+func ERC1155BalanceOf(
+	rpcURL string,
+	address common.Address,
+	account common.Address,
+	tokenID *big.Int,
+) (*big.Int, error) {
+	out, err := CallToMethod(
+		rpcURL,
+		address,
+		"balanceOf(address, uint256)->(uint256)",
+		account,
+		tokenID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	balance, b := out[0].(*big.Int)
+	if !b {
+		return nil, fmt.Errorf("error at balanceOf call, expected *big.Int, got %T", out[0])
+	}
+	return balance, nil
+}
@@ -0,0 +1,262 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ProxyKind is the upgradeability pattern of an ERC1967 proxy
+type ProxyKind string
+
+const (
+	// TransparentProxy is upgraded by a ProxyAdmin contract, set as the proxy admin
+	TransparentProxy ProxyKind = "transparent"
+	// UUPSProxy is upgraded by calling the implementation upgrade methods through the proxy
+	UUPSProxy ProxyKind = "uups"
+)
+
+// ERC1967 storage slots
+const (
+	erc1967ImplementationSlot = "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"
+	erc1967AdminSlot          = "0xb53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103"
+)
+
+//go:embed contracts/bin/ERC1967Proxy.bin
+var erc1967ProxyBin []byte
+
+//go:embed contracts/bin/TransparentUpgradeableProxy.bin
+var transparentProxyBin []byte
+
+//go:embed contracts/bin/ProxyAdmin.bin
+var proxyAdminBin []byte
+
+// ProxyDeployment are the addresses of the contracts deployed for an upgradeable contract
+type ProxyDeployment struct {
+	Proxy          common.Address
+	Implementation common.Address
+	// only set for transparent proxies
+	ProxyAdmin common.Address
+}
+
+// GetProxyKind parses the proxy kind given by the user
+func GetProxyKind(kind string) (ProxyKind, error) {
+	switch ProxyKind(kind) {
+	case TransparentProxy, UUPSProxy:
+		return ProxyKind(kind), nil
+	}
+	return "", fmt.Errorf("invalid proxy kind %q: expected %s or %s", kind, TransparentProxy, UUPSProxy)
+}
+
+// checks that [implementation] is an UUPS implementation, so the proxy can be upgraded later
+func checkUUPSImplementation(
+	rpcURL string,
+	implementation common.Address,
+) error {
+	out, err := CallToMethod(rpcURL, implementation, "proxiableUUID()->(bytes32)")
+	if err != nil {
+		return fmt.Errorf("contract %s is not an UUPS implementation: %w", implementation.Hex(), err)
+	}
+	uuid, b := out[0].([32]byte)
+	if !b {
+		return fmt.Errorf("error at proxiableUUID call, expected [32]byte, got %T", out[0])
+	}
+	if common.Hash(uuid) != common.HexToHash(erc1967ImplementationSlot) {
+		return fmt.Errorf("contract %s has an unsupported proxiableUUID %s", implementation.Hex(), common.Hash(uuid).Hex())
+	}
+	return nil
+}
+
+// DeployProxy deploys an ERC1967 proxy of [kind] pointing to [implementation], calling
+// it with [initData] (if not empty) as part of the proxy construction. For transparent
+// proxies, a ProxyAdmin owned by the deployer is also deployed
+func DeployProxy(
+	rpcURL string,
+	privateKey string,
+	kind ProxyKind,
+	implementation common.Address,
+	initData []byte,
+) (ProxyDeployment, error) {
+	deployment := ProxyDeployment{
+		Implementation: implementation,
+	}
+	if initData == nil {
+		initData = []byte{}
+	}
+	var err error
+	switch kind {
+	case TransparentProxy:
+		if err := checkEmbeddedBytecode("ProxyAdmin", proxyAdminBin); err != nil {
+			return ProxyDeployment{}, err
+		}
+		if err := checkEmbeddedBytecode("TransparentUpgradeableProxy", transparentProxyBin); err != nil {
+			return ProxyDeployment{}, err
+		}
+		deployment.ProxyAdmin, err = DeployContract(rpcURL, privateKey, proxyAdminBin, "()")
+		if err != nil {
+			return ProxyDeployment{}, err
+		}
+		deployment.Proxy, err = DeployContract(
+			rpcURL,
+			privateKey,
+			transparentProxyBin,
+			"(address, address, bytes)",
+			implementation,
+			deployment.ProxyAdmin,
+			initData,
+		)
+	case UUPSProxy:
+		if err := checkEmbeddedBytecode("ERC1967Proxy", erc1967ProxyBin); err != nil {
+			return ProxyDeployment{}, err
+		}
+		if err := checkUUPSImplementation(rpcURL, implementation); err != nil {
+			return ProxyDeployment{}, err
+		}
+		deployment.Proxy, err = DeployContract(
+			rpcURL,
+			privateKey,
+			erc1967ProxyBin,
+			"(address, bytes)",
+			implementation,
+			initData,
+		)
+	default:
+		return ProxyDeployment{}, fmt.Errorf("unsupported proxy kind %q", kind)
+	}
+	if err != nil {
+		return ProxyDeployment{}, err
+	}
+	return deployment, nil
+}
+
+// GetProxyInfo reads the ERC1967 slots of [proxy], returning its kind, current
+// implementation and (for transparent proxies) admin
+func GetProxyInfo(
+	rpcURL string,
+	proxy common.Address,
+) (ProxyKind, ProxyDeployment, error) {
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return "", ProxyDeployment{}, err
+	}
+	defer client.Close()
+	implementation, err := evm.GetStorageAt(client, proxy, common.HexToHash(erc1967ImplementationSlot))
+	if err != nil {
+		return "", ProxyDeployment{}, err
+	}
+	if implementation == (common.Hash{}) {
+		return "", ProxyDeployment{}, fmt.Errorf("contract %s is not an ERC1967 proxy", proxy.Hex())
+	}
+	admin, err := evm.GetStorageAt(client, proxy, common.HexToHash(erc1967AdminSlot))
+	if err != nil {
+		return "", ProxyDeployment{}, err
+	}
+	deployment := ProxyDeployment{
+		Proxy:          proxy,
+		Implementation: common.BytesToAddress(implementation.Bytes()),
+		ProxyAdmin:     common.BytesToAddress(admin.Bytes()),
+	}
+	if deployment.ProxyAdmin != (common.Address{}) {
+		return TransparentProxy, deployment, nil
+	}
+	return UUPSProxy, deployment, nil
+}
+
+// UpgradeProxy points [proxy] to [newImplementation], calling it with [callData] as part
+// of the upgrade if not empty. [privateKey] must be the ProxyAdmin owner for transparent
+// proxies, or be authorized by the implementation upgrade logic for UUPS proxies
+func UpgradeProxy(
+	rpcURL string,
+	privateKey string,
+	proxy common.Address,
+	newImplementation common.Address,
+	callData []byte,
+) error {
+	kind, deployment, err := GetProxyInfo(rpcURL, proxy)
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case TransparentProxy:
+		if len(callData) == 0 {
+			_, _, err = TxToMethod(
+				rpcURL,
+				privateKey,
+				deployment.ProxyAdmin,
+				nil,
+				"upgrade(address, address)",
+				proxy,
+				newImplementation,
+			)
+		} else {
+			_, _, err = TxToMethod(
+				rpcURL,
+				privateKey,
+				deployment.ProxyAdmin,
+				nil,
+				"upgradeAndCall(address, address, bytes)",
+				proxy,
+				newImplementation,
+				callData,
+			)
+		}
+	case UUPSProxy:
+		if err := checkUUPSImplementation(rpcURL, newImplementation); err != nil {
+			return err
+		}
+		if len(callData) == 0 {
+			_, _, err = TxToMethod(
+				rpcURL,
+				privateKey,
+				proxy,
+				nil,
+				"upgradeTo(address)",
+				newImplementation,
+			)
+		} else {
+			_, _, err = TxToMethod(
+				rpcURL,
+				privateKey,
+				proxy,
+				nil,
+				"upgradeToAndCall(address, bytes)",
+				newImplementation,
+				callData,
+			)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	_, upgraded, err := GetProxyInfo(rpcURL, proxy)
+	if err != nil {
+		return err
+	}
+	if upgraded.Implementation != newImplementation {
+		return fmt.Errorf("proxy %s implementation is %s after the upgrade, expected %s", proxy.Hex(), upgraded.Implementation.Hex(), newImplementation.Hex())
+	}
+	return nil
+}
+
+// TransferProxyAdminOwnership sets [newOwner] as owner of [proxyAdmin], so it is
+// the one able to upgrade the proxies administered by it
+func TransferProxyAdminOwnership(
+	rpcURL string,
+	privateKey string,
+	proxyAdmin common.Address,
+	newOwner common.Address,
+) error {
+	_, _, err := TxToMethod(
+		rpcURL,
+		privateKey,
+		proxyAdmin,
+		nil,
+		"transferOwnership(address)",
+		newOwner,
+	)
+	return err
+}
@@ -0,0 +1,93 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DecodeRevertReason gives a human readable description of the revert [data]
+// returned by a failed call. Error(string) and Panic(uint256) are decoded
+// as specified by solidity, and custom errors are decoded if they belong to
+// the contracts commonly managed by CLI
+func DecodeRevertReason(data []byte) string {
+	if len(data) == 0 {
+		return "execution reverted without reason"
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+	if len(data) < 4 {
+		return fmt.Sprintf("invalid revert data 0x%s", common.Bytes2Hex(data))
+	}
+	customError, ok := knownErrors[[4]byte(data[:4])]
+	if !ok {
+		return fmt.Sprintf("custom error 0x%s (data 0x%s)", common.Bytes2Hex(data[:4]), common.Bytes2Hex(data[4:]))
+	}
+	values, err := customError.Inputs.Unpack(data[4:])
+	if err != nil {
+		return fmt.Sprintf("%s (undecodable data 0x%s)", customError.Sig, common.Bytes2Hex(data[4:]))
+	}
+	return fmt.Sprintf("%s(%s)", customError.RawName, FormatValues(values))
+}
+
+// GetRevertReason obtains the revert reason of a failed [tx] included on [receipt],
+// by replaying it
+func GetRevertReason(
+	rpcURL string,
+	tx *types.Transaction,
+	receipt *types.Receipt,
+) (string, error) {
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+	var blockNumber *big.Int
+	if receipt != nil {
+		blockNumber = receipt.BlockNumber
+	}
+	data, err := evm.ReplayTransaction(client, tx, blockNumber)
+	if err != nil {
+		return "", err
+	}
+	if data == nil {
+		return "", fmt.Errorf("tx %s did not revert when replayed", tx.Hash())
+	}
+	return DecodeRevertReason(data), nil
+}
+
+// failedReceiptError wraps ErrFailedReceiptStatus with the revert reason of [tx],
+// if it can be obtained
+func failedReceiptError(
+	rpcURL string,
+	tx *types.Transaction,
+	receipt *types.Receipt,
+) error {
+	reason, err := GetRevertReason(rpcURL, tx, receipt)
+	if err != nil {
+		return fmt.Errorf("%w for tx %s (revert reason not available: %s)", ErrFailedReceiptStatus, tx.Hash(), err)
+	}
+	return fmt.Errorf("%w for tx %s: %s", ErrFailedReceiptStatus, tx.Hash(), reason)
+}
+
+// revertError adds the decoded revert reason to [err], if it contains revert data,
+// as it is the case for txs that fail on gas estimation
+func revertError(err error) error {
+	data, ok := evm.GetRevertData(err)
+	if !ok || len(data) == 0 {
+		return err
+	}
+	reason := DecodeRevertReason(data)
+	if strings.Contains(err.Error(), reason) {
+		return err
+	}
+	return fmt.Errorf("%w: %s", err, reason)
+}
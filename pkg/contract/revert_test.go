@@ -0,0 +1,59 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeRevertReason(t *testing.T) {
+	require := require.New(t)
+	word := func(bs []byte) []byte {
+		return common.LeftPadBytes(bs, 32)
+	}
+	selector := func(sig string) []byte {
+		return crypto.Keccak256([]byte(sig))[:4]
+	}
+	// Error(string)
+	data := selector("Error(string)")
+	data = append(data, word(big.NewInt(32).Bytes())...)
+	data = append(data, word(big.NewInt(4).Bytes())...)
+	data = append(data, common.RightPadBytes([]byte("oops"), 32)...)
+	require.Equal("oops", DecodeRevertReason(data))
+	// Panic(uint256)
+	data = append(selector("Panic(uint256)"), word(big.NewInt(0x12).Bytes())...)
+	require.Equal("division or modulo by zero", DecodeRevertReason(data))
+	// known custom error
+	account := common.HexToAddress("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC")
+	data = selector("ERC20InsufficientBalance(address,uint256,uint256)")
+	data = append(data, word(account.Bytes())...)
+	data = append(data, word(big.NewInt(10).Bytes())...)
+	data = append(data, word(big.NewInt(20).Bytes())...)
+	require.Equal(
+		"ERC20InsufficientBalance(0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC, 10, 20)",
+		DecodeRevertReason(data),
+	)
+	// unknown custom error
+	data = append([]byte{0xde, 0xad, 0xbe, 0xef}, word([]byte{1})...)
+	require.Contains(DecodeRevertReason(data), "custom error 0xdeadbeef")
+	// no data
+	require.Equal("execution reverted without reason", DecodeRevertReason(nil))
+}
+
+func TestGetKnownMethod(t *testing.T) {
+	require := require.New(t)
+	input := append(common.FromHex("0xa9059cbb"), make([]byte, 64)...)
+	method, ok := GetKnownMethod(input)
+	require.True(ok)
+	require.Equal("transfer(address,uint256)", method.Sig)
+	method, ok = GetKnownMethod(crypto.Keccak256([]byte("mintNativeCoin(address,uint256)"))[:4])
+	require.True(ok)
+	require.Equal("mintNativeCoin(address,uint256)", method.Sig)
+	_, ok = GetKnownMethod(common.FromHex("0xdeadbeef"))
+	require.False(ok)
+}
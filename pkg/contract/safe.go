@@ -0,0 +1,495 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Safe (https://github.com/safe-global/safe-smart-account) v1.4.1 multisig wallet support.
+//
+// A Safe wallet is a proxy to the Safe singleton, created by the Safe proxy factory, that
+// executes a transaction when it is signed by a threshold of its owners. Owners sign the
+// EIP-712 hash of the transaction off chain, so the signatures can be collected in a file
+// and then given all together to execTransaction by anyone paying for the fees.
+const (
+	SafeCallOperation         = 0
+	SafeDelegateCallOperation = 1
+
+	safeSignatureLength  = 65
+	safeSetupEsp         = "setup([address], uint256, address, bytes, address, address, uint256, address)"
+	safeProxyCreationEsp = "ProxyCreation(address,address)"
+	safeExecFailureEsp   = "ExecutionFailure(bytes32,uint256)"
+	safeDomainTypeEsp    = "EIP712Domain(uint256 chainId,address verifyingContract)"
+	safeTxTypeEsp        = "SafeTx(address to,uint256 value,bytes data,uint8 operation,uint256 safeTxGas,uint256 baseGas,uint256 gasPrice,address gasToken,address refundReceiver,uint256 nonce)"
+)
+
+//go:embed contracts/bin/SafeL2.bin
+var safeL2Bin []byte
+
+//go:embed contracts/bin/SafeProxyFactory.bin
+var safeProxyFactoryBin []byte
+
+//go:embed contracts/bin/CompatibilityFallbackHandler.bin
+var safeFallbackHandlerBin []byte
+
+// SafeContracts are the contracts shared by all Safe wallets of a blockchain
+type SafeContracts struct {
+	Singleton       common.Address
+	ProxyFactory    common.Address
+	FallbackHandler common.Address
+}
+
+// SafeTransaction is the tx to be executed by a Safe wallet, as described by
+// the Safe execTransaction method
+type SafeTransaction struct {
+	To             common.Address `json:"to"`
+	Value          *big.Int       `json:"value"`
+	Data           hexutil.Bytes  `json:"data"`
+	Operation      uint8          `json:"operation"`
+	SafeTxGas      *big.Int       `json:"safeTxGas"`
+	BaseGas        *big.Int       `json:"baseGas"`
+	GasPrice       *big.Int       `json:"gasPrice"`
+	GasToken       common.Address `json:"gasToken"`
+	RefundReceiver common.Address `json:"refundReceiver"`
+	Nonce          *big.Int       `json:"nonce"`
+}
+
+// SafeSignature is the signature of a Safe tx hash by one of the Safe owners
+type SafeSignature struct {
+	Signer    common.Address `json:"signer"`
+	Signature hexutil.Bytes  `json:"signature"`
+}
+
+// SafeTransactionProposal is the file based exchange format for a Safe tx
+// that is collecting signatures from the Safe owners
+type SafeTransactionProposal struct {
+	Safe        common.Address  `json:"safe"`
+	ChainID     *big.Int        `json:"chainId"`
+	Transaction SafeTransaction `json:"transaction"`
+	Hash        common.Hash     `json:"hash"`
+	Signatures  []SafeSignature `json:"signatures"`
+}
+
+func deploySafeContract(
+	rpcURL string,
+	privateKey string,
+	deterministic bool,
+	contractName string,
+	bin []byte,
+) (common.Address, error) {
+	if err := checkEmbeddedBytecode(contractName, bin); err != nil {
+		return common.Address{}, err
+	}
+	if deterministic {
+		address, _, err := DeployContractCreate2(rpcURL, privateKey, common.Hash{}, bin, "()")
+		return address, err
+	}
+	return DeployContract(rpcURL, privateKey, bin, "()")
+}
+
+// DeploySafeContracts deploys the Safe singleton, proxy factory and fallback handler
+// into the blockchain at [rpcURL]. If the CREATE2 factory is available, they are deployed
+// through it, so they get the same addresses on every blockchain, and are reused if
+// they were already deployed. Returns true if the deployment was deterministic
+func DeploySafeContracts(
+	rpcURL string,
+	privateKey string,
+) (SafeContracts, bool, error) {
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return SafeContracts{}, false, err
+	}
+	deterministic, err := evm.ContractAlreadyDeployed(client, Create2FactoryAddress)
+	client.Close()
+	if err != nil {
+		return SafeContracts{}, false, fmt.Errorf("failure making a request to %s: %w", rpcURL, err)
+	}
+	var contracts SafeContracts
+	if contracts.Singleton, err = deploySafeContract(rpcURL, privateKey, deterministic, "SafeL2", safeL2Bin); err != nil {
+		return SafeContracts{}, false, err
+	}
+	if contracts.ProxyFactory, err = deploySafeContract(rpcURL, privateKey, deterministic, "SafeProxyFactory", safeProxyFactoryBin); err != nil {
+		return SafeContracts{}, false, err
+	}
+	if contracts.FallbackHandler, err = deploySafeContract(rpcURL, privateKey, deterministic, "CompatibilityFallbackHandler", safeFallbackHandlerBin); err != nil {
+		return SafeContracts{}, false, err
+	}
+	return contracts, deterministic, nil
+}
+
+// CreateSafe creates a new Safe wallet with [owners], requiring [threshold] of them to
+// sign every tx. The wallet address depends on the owners, threshold and [saltNonce]
+func CreateSafe(
+	rpcURL string,
+	privateKey string,
+	contracts SafeContracts,
+	owners []common.Address,
+	threshold uint64,
+	saltNonce *big.Int,
+) (common.Address, error) {
+	if len(owners) == 0 {
+		return common.Address{}, fmt.Errorf("a Safe needs at least one owner")
+	}
+	if threshold == 0 || threshold > uint64(len(owners)) {
+		return common.Address{}, fmt.Errorf("threshold must be between 1 and the number of owners (%d), got %d", len(owners), threshold)
+	}
+	initializer, err := PackMethodCall(
+		safeSetupEsp,
+		owners,
+		new(big.Int).SetUint64(threshold),
+		common.Address{},
+		[]byte{},
+		contracts.FallbackHandler,
+		common.Address{},
+		big.NewInt(0),
+		common.Address{},
+	)
+	if err != nil {
+		return common.Address{}, err
+	}
+	_, receipt, err := TxToMethod(
+		rpcURL,
+		privateKey,
+		contracts.ProxyFactory,
+		nil,
+		"createProxyWithNonce(address, bytes, uint256)->(address)",
+		contracts.Singleton,
+		initializer,
+		saltNonce,
+	)
+	if err != nil {
+		return common.Address{}, err
+	}
+	for _, log := range receipt.Logs {
+		if log.Address != contracts.ProxyFactory {
+			continue
+		}
+		values, err := UnpackLogValues(safeProxyCreationEsp, []int{0}, *log)
+		if err != nil {
+			continue
+		}
+		if address, ok := values[0].(common.Address); ok {
+			return address, nil
+		}
+	}
+	return common.Address{}, fmt.Errorf("no ProxyCreation event found on Safe creation receipt")
+}
+
+// GetSafeOwners returns the owners of the Safe wallet at [safe]
+func GetSafeOwners(
+	rpcURL string,
+	safe common.Address,
+) ([]common.Address, error) {
+	out, err := CallToMethod(rpcURL, safe, "getOwners()->([address])")
+	if err != nil {
+		return nil, err
+	}
+	owners, b := out[0].([]common.Address)
+	if !b {
+		return nil, fmt.Errorf("error at getOwners call, expected []common.Address, got %T", out[0])
+	}
+	return owners, nil
+}
+
+// GetSafeThreshold returns the number of owner signatures needed by the Safe
+// wallet at [safe] to execute a tx
+func GetSafeThreshold(
+	rpcURL string,
+	safe common.Address,
+) (uint64, error) {
+	out, err := CallToMethod(rpcURL, safe, "getThreshold()->(uint256)")
+	if err != nil {
+		return 0, err
+	}
+	threshold, b := out[0].(*big.Int)
+	if !b {
+		return 0, fmt.Errorf("error at getThreshold call, expected *big.Int, got %T", out[0])
+	}
+	return threshold.Uint64(), nil
+}
+
+// GetSafeNonce returns the nonce the next tx of the Safe wallet at [safe] must have
+func GetSafeNonce(
+	rpcURL string,
+	safe common.Address,
+) (*big.Int, error) {
+	out, err := CallToMethod(rpcURL, safe, "nonce()->(uint256)")
+	if err != nil {
+		return nil, err
+	}
+	nonce, b := out[0].(*big.Int)
+	if !b {
+		return nil, fmt.Errorf("error at nonce call, expected *big.Int, got %T", out[0])
+	}
+	return nonce, nil
+}
+
+func uint256Word(n *big.Int) []byte {
+	if n == nil {
+		n = big.NewInt(0)
+	}
+	return common.LeftPadBytes(n.Bytes(), common.HashLength)
+}
+
+func addressWord(address common.Address) []byte {
+	return common.LeftPadBytes(address.Bytes(), common.HashLength)
+}
+
+// GetSafeTransactionHash returns the EIP-712 hash of [tx] for the Safe wallet [safe]
+// at [chainID], that is what the Safe owners sign
+func GetSafeTransactionHash(
+	chainID *big.Int,
+	safe common.Address,
+	tx SafeTransaction,
+) common.Hash {
+	domainSeparator := crypto.Keccak256(
+		crypto.Keccak256([]byte(safeDomainTypeEsp)),
+		uint256Word(chainID),
+		addressWord(safe),
+	)
+	safeTxHash := crypto.Keccak256(
+		crypto.Keccak256([]byte(safeTxTypeEsp)),
+		addressWord(tx.To),
+		uint256Word(tx.Value),
+		crypto.Keccak256(tx.Data),
+		uint256Word(big.NewInt(int64(tx.Operation))),
+		uint256Word(tx.SafeTxGas),
+		uint256Word(tx.BaseGas),
+		uint256Word(tx.GasPrice),
+		addressWord(tx.GasToken),
+		addressWord(tx.RefundReceiver),
+		uint256Word(tx.Nonce),
+	)
+	return crypto.Keccak256Hash([]byte{0x19, 0x01}, domainSeparator, safeTxHash)
+}
+
+// NewSafeTransactionProposal creates a proposal for [tx] to be executed by the Safe wallet
+// at [safe]. If [tx] has no nonce, the current Safe nonce is used. The hash is checked
+// against the one computed by the Safe itself
+func NewSafeTransactionProposal(
+	rpcURL string,
+	safe common.Address,
+	tx SafeTransaction,
+) (*SafeTransactionProposal, error) {
+	if tx.Operation != SafeCallOperation && tx.Operation != SafeDelegateCallOperation {
+		return nil, fmt.Errorf("invalid Safe operation %d", tx.Operation)
+	}
+	for _, n := range []**big.Int{&tx.Value, &tx.SafeTxGas, &tx.BaseGas, &tx.GasPrice} {
+		if *n == nil {
+			*n = big.NewInt(0)
+		}
+	}
+	if tx.Data == nil {
+		tx.Data = []byte{}
+	}
+	if tx.Nonce == nil {
+		nonce, err := GetSafeNonce(rpcURL, safe)
+		if err != nil {
+			return nil, err
+		}
+		tx.Nonce = nonce
+	}
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := evm.GetChainID(client)
+	client.Close()
+	if err != nil {
+		return nil, err
+	}
+	hash := GetSafeTransactionHash(chainID, safe, tx)
+	out, err := CallToMethod(
+		rpcURL,
+		safe,
+		"getTransactionHash(address, uint256, bytes, uint8, uint256, uint256, uint256, address, address, uint256)->(bytes32)",
+		tx.To,
+		tx.Value,
+		[]byte(tx.Data),
+		tx.Operation,
+		tx.SafeTxGas,
+		tx.BaseGas,
+		tx.GasPrice,
+		tx.GasToken,
+		tx.RefundReceiver,
+		tx.Nonce,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failure getting tx hash from Safe %s: %w", safe.Hex(), err)
+	}
+	safeHash, b := out[0].([32]byte)
+	if !b {
+		return nil, fmt.Errorf("error at getTransactionHash call, expected [32]byte, got %T", out[0])
+	}
+	if common.Hash(safeHash) != hash {
+		return nil, fmt.Errorf("tx hash mismatch: Safe %s computes %s, expected %s", safe.Hex(), common.Hash(safeHash).Hex(), hash.Hex())
+	}
+	return &SafeTransactionProposal{
+		Safe:        safe,
+		ChainID:     chainID,
+		Transaction: tx,
+		Hash:        hash,
+		Signatures:  []SafeSignature{},
+	}, nil
+}
+
+// LoadSafeTransactionProposal loads a proposal from [path], checking its hash
+// and signatures are consistent with the tx
+func LoadSafeTransactionProposal(path string) (*SafeTransactionProposal, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var proposal SafeTransactionProposal
+	if err := json.Unmarshal(bs, &proposal); err != nil {
+		return nil, fmt.Errorf("failure parsing Safe tx proposal %s: %w", path, err)
+	}
+	if proposal.ChainID == nil {
+		return nil, fmt.Errorf("no chain id found on Safe tx proposal %s", path)
+	}
+	if hash := GetSafeTransactionHash(proposal.ChainID, proposal.Safe, proposal.Transaction); hash != proposal.Hash {
+		return nil, fmt.Errorf("Safe tx proposal %s hash %s does not match its tx hash %s", path, proposal.Hash.Hex(), hash.Hex())
+	}
+	for _, sig := range proposal.Signatures {
+		if err := verifySafeSignature(proposal.Hash, sig); err != nil {
+			return nil, fmt.Errorf("invalid signature on Safe tx proposal %s: %w", path, err)
+		}
+	}
+	return &proposal, nil
+}
+
+// Save writes the proposal into [path]
+func (p *SafeTransactionProposal) Save(path string) error {
+	bs, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, bs, constants.WriteReadReadPerms)
+}
+
+// SignSafeTransaction signs [hash] with [privateKey], in the format expected by
+// the Safe execTransaction method
+func SignSafeTransaction(
+	privateKey string,
+	hash common.Hash,
+) (SafeSignature, error) {
+	pk, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		return SafeSignature{}, err
+	}
+	signature, err := crypto.Sign(hash.Bytes(), pk)
+	if err != nil {
+		return SafeSignature{}, err
+	}
+	// Safe expects ethereum style recovery ids
+	signature[crypto.RecoveryIDOffset] += 27
+	return SafeSignature{
+		Signer:    crypto.PubkeyToAddress(pk.PublicKey),
+		Signature: signature,
+	}, nil
+}
+
+func verifySafeSignature(hash common.Hash, sig SafeSignature) error {
+	if len(sig.Signature) != safeSignatureLength {
+		return fmt.Errorf("expected signature of %s to have %d bytes, got %d", sig.Signer.Hex(), safeSignatureLength, len(sig.Signature))
+	}
+	signature := bytes.Clone(sig.Signature)
+	if signature[crypto.RecoveryIDOffset] < 27 {
+		return fmt.Errorf("unsupported recovery id on signature of %s", sig.Signer.Hex())
+	}
+	signature[crypto.RecoveryIDOffset] -= 27
+	pubKey, err := crypto.SigToPub(hash.Bytes(), signature)
+	if err != nil {
+		return err
+	}
+	if signer := crypto.PubkeyToAddress(*pubKey); signer != sig.Signer {
+		return fmt.Errorf("signature was made by %s, not by %s", signer.Hex(), sig.Signer.Hex())
+	}
+	return nil
+}
+
+// AddSignature adds [sig] to the proposal, replacing any previous signature
+// of the same signer
+func (p *SafeTransactionProposal) AddSignature(sig SafeSignature) error {
+	if err := verifySafeSignature(p.Hash, sig); err != nil {
+		return err
+	}
+	for i := range p.Signatures {
+		if p.Signatures[i].Signer == sig.Signer {
+			p.Signatures[i] = sig
+			return nil
+		}
+	}
+	p.Signatures = append(p.Signatures, sig)
+	return nil
+}
+
+// EncodedSignatures returns the proposal signatures as expected by the Safe
+// execTransaction method: concatenated and sorted by signer address
+func (p *SafeTransactionProposal) EncodedSignatures() []byte {
+	signatures := append([]SafeSignature{}, p.Signatures...)
+	sort.Slice(signatures, func(i, j int) bool {
+		return bytes.Compare(signatures[i].Signer.Bytes(), signatures[j].Signer.Bytes()) < 0
+	})
+	encoded := []byte{}
+	for _, sig := range signatures {
+		encoded = append(encoded, sig.Signature...)
+	}
+	return encoded
+}
+
+// ExecuteSafeTransaction sends the proposal tx, with its signatures, to the
+// Safe wallet, paying the fees with [privateKey]
+func ExecuteSafeTransaction(
+	rpcURL string,
+	privateKey string,
+	proposal *SafeTransactionProposal,
+) (*types.Transaction, *types.Receipt, error) {
+	tx := proposal.Transaction
+	ethTx, receipt, err := TxToMethod(
+		rpcURL,
+		privateKey,
+		proposal.Safe,
+		nil,
+		"execTransaction(address, uint256, bytes, uint8, uint256, uint256, uint256, address, address, bytes)->(bool)",
+		tx.To,
+		tx.Value,
+		[]byte(tx.Data),
+		tx.Operation,
+		tx.SafeTxGas,
+		tx.BaseGas,
+		tx.GasPrice,
+		tx.GasToken,
+		tx.RefundReceiver,
+		proposal.EncodedSignatures(),
+	)
+	if err != nil {
+		return ethTx, receipt, err
+	}
+	// the Safe does not revert if the inner call fails with a non zero safeTxGas,
+	// but emits ExecutionFailure instead
+	failureTopic, err := GetEventTopic(safeExecFailureEsp, nil)
+	if err != nil {
+		return ethTx, receipt, err
+	}
+	for _, log := range receipt.Logs {
+		if log.Address == proposal.Safe && len(log.Topics) > 0 && log.Topics[0] == failureTopic {
+			return ethTx, receipt, fmt.Errorf("%w for Safe tx %s: inner call failed", ErrFailedReceiptStatus, proposal.Hash.Hex())
+		}
+	}
+	return ethTx, receipt, nil
+}
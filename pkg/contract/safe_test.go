@@ -0,0 +1,166 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"bytes"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/stretchr/testify/require"
+)
+
+func testSafeTransaction() SafeTransaction {
+	return SafeTransaction{
+		To:             common.HexToAddress("0x0200000000000000000000000000000000000002"),
+		Value:          big.NewInt(7),
+		Data:           common.FromHex("0x704b6c02000000000000000000000000000000000000000000000000000000000000abcd"),
+		Operation:      SafeCallOperation,
+		SafeTxGas:      big.NewInt(0),
+		BaseGas:        big.NewInt(0),
+		GasPrice:       big.NewInt(0),
+		GasToken:       common.Address{},
+		RefundReceiver: common.Address{},
+		Nonce:          big.NewInt(3),
+	}
+}
+
+func TestGetSafeTransactionHash(t *testing.T) {
+	require := require.New(t)
+	chainID := big.NewInt(99999)
+	safe := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	tx := testSafeTransaction()
+	// compare against the generic EIP-712 implementation
+	typedData := apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"SafeTx": {
+				{Name: "to", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "data", Type: "bytes"},
+				{Name: "operation", Type: "uint8"},
+				{Name: "safeTxGas", Type: "uint256"},
+				{Name: "baseGas", Type: "uint256"},
+				{Name: "gasPrice", Type: "uint256"},
+				{Name: "gasToken", Type: "address"},
+				{Name: "refundReceiver", Type: "address"},
+				{Name: "nonce", Type: "uint256"},
+			},
+		},
+		PrimaryType: "SafeTx",
+		Domain: apitypes.TypedDataDomain{
+			ChainId:           (*math.HexOrDecimal256)(chainID),
+			VerifyingContract: safe.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"to":             tx.To.Hex(),
+			"value":          tx.Value.String(),
+			"data":           hexutil.Encode(tx.Data),
+			"operation":      "0",
+			"safeTxGas":      "0",
+			"baseGas":        "0",
+			"gasPrice":       "0",
+			"gasToken":       tx.GasToken.Hex(),
+			"refundReceiver": tx.RefundReceiver.Hex(),
+			"nonce":          tx.Nonce.String(),
+		},
+	}
+	expected, _, err := apitypes.TypedDataAndHash(typedData)
+	require.NoError(err)
+	require.Equal(common.BytesToHash(expected), GetSafeTransactionHash(chainID, safe, tx))
+	// any field change modifies the hash
+	tx.Nonce = big.NewInt(4)
+	require.NotEqual(common.BytesToHash(expected), GetSafeTransactionHash(chainID, safe, tx))
+}
+
+func TestSafeTransactionProposalSignatures(t *testing.T) {
+	require := require.New(t)
+	tx := testSafeTransaction()
+	proposal := &SafeTransactionProposal{
+		Safe:        common.HexToAddress("0x1234567890123456789012345678901234567890"),
+		ChainID:     big.NewInt(99999),
+		Transaction: tx,
+		Signatures:  []SafeSignature{},
+	}
+	proposal.Hash = GetSafeTransactionHash(proposal.ChainID, proposal.Safe, tx)
+	signatures := []SafeSignature{}
+	for i := 0; i < 3; i++ {
+		pk, err := crypto.GenerateKey()
+		require.NoError(err)
+		sig, err := SignSafeTransaction(hexutil.Encode(crypto.FromECDSA(pk))[2:], proposal.Hash)
+		require.NoError(err)
+		require.Len(sig.Signature, safeSignatureLength)
+		require.GreaterOrEqual(sig.Signature[crypto.RecoveryIDOffset], byte(27))
+		require.NoError(proposal.AddSignature(sig))
+		signatures = append(signatures, sig)
+	}
+	// signing again replaces the previous signature
+	require.NoError(proposal.AddSignature(signatures[0]))
+	require.Len(proposal.Signatures, 3)
+	// signatures are encoded sorted by signer
+	encoded := proposal.EncodedSignatures()
+	require.Len(encoded, 3*safeSignatureLength)
+	for i := 1; i < 3; i++ {
+		prev, err := signerOfEncoded(proposal.Hash, encoded[(i-1)*safeSignatureLength:i*safeSignatureLength])
+		require.NoError(err)
+		next, err := signerOfEncoded(proposal.Hash, encoded[i*safeSignatureLength:(i+1)*safeSignatureLength])
+		require.NoError(err)
+		require.Negative(bytes.Compare(prev.Bytes(), next.Bytes()))
+	}
+	// a signature attributed to another signer is rejected
+	forged := signatures[1]
+	forged.Signer = signatures[2].Signer
+	require.Error(proposal.AddSignature(forged))
+	// proposal files are loaded back with their signatures
+	path := filepath.Join(t.TempDir(), "proposal.json")
+	require.NoError(proposal.Save(path))
+	loaded, err := LoadSafeTransactionProposal(path)
+	require.NoError(err)
+	require.Equal(proposal.Hash, loaded.Hash)
+	require.Equal(encoded, loaded.EncodedSignatures())
+}
+
+func signerOfEncoded(hash common.Hash, signature []byte) (common.Address, error) {
+	signature = bytes.Clone(signature)
+	signature[crypto.RecoveryIDOffset] -= 27
+	pubKey, err := crypto.SigToPub(hash.Bytes(), signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// the embedded Safe contracts should be the ones of the canonical Safe v1.4.1 deployments
+// (https://github.com/safe-global/safe-deployments), made through the Safe singleton
+// factory with a zero salt, so their init code hashes to the published addresses
+func TestSafeCanonicalBytecode(t *testing.T) {
+	safeSingletonFactory := common.HexToAddress("0x914d7Fec6aaC8cd542e72Bca78B30650d45643d7")
+	for name, tc := range map[string]struct {
+		bin     []byte
+		address string
+	}{
+		"SafeL2":                       {safeL2Bin, "0x29fcB43b46531BcA003ddC8FCB67FFE91900C762"},
+		"SafeProxyFactory":             {safeProxyFactoryBin, "0x4e1DCf7AD4e460CfD30791CCC4F9c8a4f820ec67"},
+		"CompatibilityFallbackHandler": {safeFallbackHandlerBin, "0xfd0732Dc9E303f09fCEf3a7388Ad10A83459Ec99"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, checkEmbeddedBytecode(name, tc.bin))
+			initCode := common.FromHex(strings.TrimSpace(string(tc.bin)))
+			require.Equal(
+				t,
+				common.HexToAddress(tc.address),
+				crypto.CreateAddress2(safeSingletonFactory, [32]byte{}, crypto.Keccak256(initCode)),
+			)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ava-labs/subnet-evm/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	teleporterMessageEsp = "(uint256, address, bytes32, address, uint256, [address], [(uint256, address)], bytes)"
+	icttSendInputEsp     = "(bytes32, address, address, address, uint256, uint256, uint256, address)"
+	icttSendAndCallEsp   = "(bytes32, address, address, bytes, uint256, uint256, address, address, address, uint256, uint256)"
+)
+
+// methods of the contracts commonly managed by CLI, used to give names to
+// calls found on tx traces
+var knownMethodsEsps = []string{
+	// teleporter messenger
+	"sendCrossChainMessage((bytes32, address, (address, uint256), uint256, [address], bytes))",
+	"receiveCrossChainMessage(uint32, address)",
+	"retryMessageExecution(bytes32, " + teleporterMessageEsp + ")",
+	"retrySendCrossChainMessage(" + teleporterMessageEsp + ")",
+	"addFeeAmount(bytes32, address, uint256)",
+	"sendSpecifiedReceipts(bytes32, [bytes32], (address, uint256), [address])",
+	"redeemRelayerRewards(address)",
+	"checkRelayerRewardAmount(address, address)",
+	"getNextMessageID(bytes32)",
+	"messageReceived(bytes32)",
+	"getRelayerRewardAddress(bytes32)",
+	"getMessageHash(bytes32)",
+	"getReceiptQueueSize(bytes32)",
+	"getFeeInfo(bytes32)",
+	"initializeBlockchainID()",
+	"receiveTeleporterMessage(bytes32, address, bytes)",
+	// teleporter registry
+	"latestVersion()",
+	"addProtocolVersion(uint32)",
+	"getAddressFromVersion(uint256)",
+	"getVersionFromAddress(address)",
+	"getLatestTeleporter()",
+	// ictt
+	"send(" + icttSendInputEsp + ", uint256)",
+	"send(" + icttSendInputEsp + ")",
+	"sendAndCall(" + icttSendAndCallEsp + ", uint256)",
+	"sendAndCall(" + icttSendAndCallEsp + ")",
+	"addCollateral(bytes32, address, uint256)",
+	"addCollateral(bytes32, address)",
+	"registerWithHome((address, uint256))",
+	"token()",
+	"wrappedToken()",
+	"isCollateralized()",
+	"tokenHomeAddress()",
+	"totalNativeAssetSupply()",
+	"registeredRemotes(bytes32, address)",
+	// erc20
+	"transfer(address, uint256)",
+	"transferFrom(address, address, uint256)",
+	"approve(address, uint256)",
+	"balanceOf(address)",
+	"allowance(address, address)",
+	"totalSupply()",
+	"name()",
+	"symbol()",
+	"decimals()",
+	"mint(address, uint256)",
+	"burn(uint256)",
+	"deposit()",
+	"withdraw(uint256)",
+	// nfts
+	"mint(address)",
+	"mint(address, uint256, uint256, bytes)",
+	"ownerOf(uint256)",
+	"tokenURI(uint256)",
+	"safeTransferFrom(address, address, uint256)",
+	"safeTransferFrom(address, address, uint256, uint256, bytes)",
+	"balanceOf(address, uint256)",
+	"supportsInterface(bytes4)",
+	// safe
+	safeSetupEsp,
+	"createProxyWithNonce(address, bytes, uint256)",
+	"execTransaction(address, uint256, bytes, uint8, uint256, uint256, uint256, address, address, bytes)",
+	"getTransactionHash(address, uint256, bytes, uint8, uint256, uint256, uint256, address, address, uint256)",
+	"getOwners()",
+	"getThreshold()",
+	"nonce()",
+	"addOwnerWithThreshold(address, uint256)",
+	"removeOwner(address, address, uint256)",
+	"changeThreshold(uint256)",
+	// proxies
+	"upgrade(address, address)",
+	"upgradeAndCall(address, address, bytes)",
+	"upgradeTo(address)",
+	"upgradeToAndCall(address, bytes)",
+	"proxiableUUID()",
+	"transferOwnership(address)",
+	// multicall
+	multicallAggregate3Esp,
+	multicallAggregate3ValueEsp,
+	"getEthBalance(address)",
+	// precompiles
+	"setAdmin(address)",
+	"setManager(address)",
+	"setEnabled(address)",
+	"setNone(address)",
+	"readAllowList(address)",
+	"mintNativeCoin(address, uint256)",
+	"sendWarpMessage(bytes)",
+	"getVerifiedWarpMessage(uint32)",
+	"getVerifiedWarpBlockHash(uint32)",
+	"getBlockchainID()",
+	"setFeeConfig(uint256, uint256, uint256, uint256, uint256, uint256, uint256, uint256)",
+	"getFeeConfig()",
+	"getFeeConfigLastChangedAt()",
+	"allowFeeRecipients()",
+	"areFeeRecipientsAllowed()",
+	"currentRewardAddress()",
+	"disableRewards()",
+	"setRewardAddress(address)",
+}
+
+// custom errors of the contracts commonly managed by CLI, used to decode
+// revert reasons
+var knownErrorsEsps = []string{
+	// openzeppelin erc20
+	"ERC20InsufficientBalance(address, uint256, uint256)",
+	"ERC20InvalidSender(address)",
+	"ERC20InvalidReceiver(address)",
+	"ERC20InsufficientAllowance(address, uint256, uint256)",
+	"ERC20InvalidApprover(address)",
+	"ERC20InvalidSpender(address)",
+	// openzeppelin utils
+	"OwnableUnauthorizedAccount(address)",
+	"OwnableInvalidOwner(address)",
+	"SafeERC20FailedOperation(address)",
+	"SafeERC20FailedDecreaseAllowance(address, uint256, uint256)",
+	"ReentrancyGuardReentrantCall()",
+	"AddressEmptyCode(address)",
+	"AddressInsufficientBalance(address)",
+	"FailedInnerCall()",
+	"InvalidInitialization()",
+	"NotInitializing()",
+	"EnforcedPause()",
+	"ExpectedPause()",
+}
+
+// names of the contracts that have the same address on all blockchains
+var knownAddresses = map[common.Address]string{
+	common.HexToAddress("0x0200000000000000000000000000000000000000"): "ContractDeployerAllowList",
+	common.HexToAddress("0x0200000000000000000000000000000000000001"): "NativeMinter",
+	common.HexToAddress("0x0200000000000000000000000000000000000002"): "TxAllowList",
+	common.HexToAddress("0x0200000000000000000000000000000000000003"): "FeeManager",
+	common.HexToAddress("0x0200000000000000000000000000000000000004"): "RewardManager",
+	common.HexToAddress("0x0200000000000000000000000000000000000005"): "Warp",
+	common.HexToAddress(Create2FactoryAddress):                        "Create2Factory",
+	common.HexToAddress(MulticallAddress):                             "Multicall3",
+}
+
+var (
+	knownMethods map[[4]byte]abi.Method
+	knownErrors  map[[4]byte]abi.Method
+)
+
+func init() {
+	var err error
+	if knownMethods, err = getMethodsBySelector(knownMethodsEsps); err != nil {
+		panic(err)
+	}
+	if knownErrors, err = getMethodsBySelector(knownErrorsEsps); err != nil {
+		panic(err)
+	}
+}
+
+// GetMethodFromEsp returns the ABI method described by [methodEsp], without
+// needing go values for its params
+func GetMethodFromEsp(methodEsp string) (abi.Method, error) {
+	methodName, methodABI, err := ParseEsp(methodEsp, nil, false, false, false, false, nil)
+	if err != nil {
+		return abi.Method{}, err
+	}
+	metadata := &bind.MetaData{
+		ABI: methodABI,
+	}
+	contractABI, err := metadata.GetAbi()
+	if err != nil {
+		return abi.Method{}, err
+	}
+	method, ok := contractABI.Methods[methodName]
+	if !ok {
+		return abi.Method{}, fmt.Errorf("method %s not found on esp %q", methodName, methodEsp)
+	}
+	return method, nil
+}
+
+func getMethodsBySelector(esps []string) (map[[4]byte]abi.Method, error) {
+	methods := map[[4]byte]abi.Method{}
+	for _, esp := range esps {
+		method, err := GetMethodFromEsp(esp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid known signature %q: %w", esp, err)
+		}
+		methods[[4]byte(method.ID)] = method
+	}
+	return methods, nil
+}
+
+// GetKnownMethod returns the method called by [input], if its selector
+// belongs to a contract commonly managed by CLI
+func GetKnownMethod(input []byte) (abi.Method, bool) {
+	if len(input) < 4 {
+		return abi.Method{}, false
+	}
+	method, ok := knownMethods[[4]byte(input[:4])]
+	return method, ok
+}
+
+// GetKnownAddressName returns the name of the contract at [address], if it
+// is a precompile or a well known deterministic deployment
+func GetKnownAddressName(address common.Address) (string, bool) {
+	name, ok := knownAddresses[address]
+	return name, ok
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+const storageGapPrefix = "__gap"
+
+// StorageLayout is the solc storage layout output, as included into foundry artifacts
+type StorageLayout struct {
+	Storage []StorageEntry         `json:"storage"`
+	Types   map[string]StorageType `json:"types"`
+}
+
+// StorageEntry is a state variable (or struct member) of a storage layout
+type StorageEntry struct {
+	Label  string `json:"label"`
+	Offset uint64 `json:"offset"`
+	Slot   string `json:"slot"`
+	Type   string `json:"type"`
+}
+
+// StorageType describes a type referenced by a storage layout entry
+type StorageType struct {
+	Encoding      string         `json:"encoding"`
+	Label         string         `json:"label"`
+	NumberOfBytes string         `json:"numberOfBytes"`
+	Base          string         `json:"base,omitempty"`
+	Key           string         `json:"key,omitempty"`
+	Value         string         `json:"value,omitempty"`
+	Members       []StorageEntry `json:"members,omitempty"`
+}
+
+// storage byte range of a layout entry
+type storageRange struct {
+	entry StorageEntry
+	start *big.Int
+	end   *big.Int
+}
+
+func (r storageRange) isGap() bool {
+	return strings.HasPrefix(r.entry.Label, storageGapPrefix)
+}
+
+func getStorageRanges(layout *StorageLayout) ([]storageRange, error) {
+	ranges := []storageRange{}
+	for _, entry := range layout.Storage {
+		slot, ok := new(big.Int).SetString(entry.Slot, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid slot %q for storage variable %s", entry.Slot, entry.Label)
+		}
+		t, ok := layout.Types[entry.Type]
+		if !ok {
+			return nil, fmt.Errorf("type %s of storage variable %s not found on layout", entry.Type, entry.Label)
+		}
+		size, ok := new(big.Int).SetString(t.NumberOfBytes, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid size %q for type %s", t.NumberOfBytes, entry.Type)
+		}
+		start := new(big.Int).Mul(slot, big.NewInt(32))
+		start.Add(start, new(big.Int).SetUint64(entry.Offset))
+		ranges = append(ranges, storageRange{
+			entry: entry,
+			start: start,
+			end:   new(big.Int).Add(start, size),
+		})
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start.Cmp(ranges[j].start) < 0
+	})
+	return ranges, nil
+}
+
+// indicates if type [currentType] of [current] layout and [nextType] of [next]
+// layout are stored the same way. Types ids are not compared directly, as they
+// include AST ids that change between compilations
+func compatibleStorageTypes(
+	current *StorageLayout,
+	currentType string,
+	next *StorageLayout,
+	nextType string,
+) bool {
+	ct, ok := current.Types[currentType]
+	if !ok {
+		return false
+	}
+	nt, ok := next.Types[nextType]
+	if !ok {
+		return false
+	}
+	if ct.Label != nt.Label || ct.Encoding != nt.Encoding || ct.NumberOfBytes != nt.NumberOfBytes {
+		return false
+	}
+	if (ct.Base == "") != (nt.Base == "") || (ct.Key == "") != (nt.Key == "") || (ct.Value == "") != (nt.Value == "") {
+		return false
+	}
+	if ct.Base != "" && !compatibleStorageTypes(current, ct.Base, next, nt.Base) {
+		return false
+	}
+	if ct.Key != "" && !compatibleStorageTypes(current, ct.Key, next, nt.Key) {
+		return false
+	}
+	if ct.Value != "" && !compatibleStorageTypes(current, ct.Value, next, nt.Value) {
+		return false
+	}
+	if len(ct.Members) != len(nt.Members) {
+		return false
+	}
+	for i := range ct.Members {
+		cm, nm := ct.Members[i], nt.Members[i]
+		if cm.Label != nm.Label || cm.Slot != nm.Slot || cm.Offset != nm.Offset {
+			return false
+		}
+		if !compatibleStorageTypes(current, cm.Type, next, nm.Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckStorageLayoutUpgrade returns the problems found when upgrading a contract with
+// [current] storage layout into a contract with [next] storage layout. New variables can
+// only be appended, or take the place of the start of a storage gap (an array named __gap)
+// that is reduced accordingly. Existing variables can not be removed, moved, renamed or
+// change their type
+func CheckStorageLayoutUpgrade(
+	current *StorageLayout,
+	next *StorageLayout,
+) ([]string, error) {
+	currentRanges, err := getStorageRanges(current)
+	if err != nil {
+		return nil, err
+	}
+	nextRanges, err := getStorageRanges(next)
+	if err != nil {
+		return nil, err
+	}
+	problems := []string{}
+	for _, cr := range currentRanges {
+		if cr.isGap() {
+			// gap space can be used by new variables, as long as they don't go past it
+			for _, nr := range nextRanges {
+				if nr.start.Cmp(cr.start) >= 0 && nr.start.Cmp(cr.end) < 0 && nr.end.Cmp(cr.end) > 0 {
+					problems = append(problems, fmt.Sprintf(
+						"variable %s overflows storage gap %s (slot %s)",
+						nr.entry.Label,
+						cr.entry.Label,
+						cr.entry.Slot,
+					))
+				}
+			}
+			continue
+		}
+		var match *storageRange
+		for i := range nextRanges {
+			if nextRanges[i].start.Cmp(cr.start) == 0 {
+				match = &nextRanges[i]
+				break
+			}
+		}
+		switch {
+		case match == nil:
+			problems = append(problems, fmt.Sprintf(
+				"variable %s (slot %s, offset %d) was removed or moved",
+				cr.entry.Label,
+				cr.entry.Slot,
+				cr.entry.Offset,
+			))
+		case match.entry.Label != cr.entry.Label:
+			problems = append(problems, fmt.Sprintf(
+				"variable %s (slot %s, offset %d) was replaced by %s",
+				cr.entry.Label,
+				cr.entry.Slot,
+				cr.entry.Offset,
+				match.entry.Label,
+			))
+		case !compatibleStorageTypes(current, cr.entry.Type, next, match.entry.Type):
+			problems = append(problems, fmt.Sprintf(
+				"variable %s (slot %s, offset %d) changed type from %s to %s",
+				cr.entry.Label,
+				cr.entry.Slot,
+				cr.entry.Offset,
+				current.Types[cr.entry.Type].Label,
+				next.Types[match.entry.Type].Label,
+			))
+		}
+	}
+	return problems, nil
+}
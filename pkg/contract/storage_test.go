@@ -0,0 +1,161 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testStorageTypes = map[string]StorageType{
+	"t_uint256":                    {Encoding: "inplace", Label: "uint256", NumberOfBytes: "32"},
+	"t_uint128":                    {Encoding: "inplace", Label: "uint128", NumberOfBytes: "16"},
+	"t_address":                    {Encoding: "inplace", Label: "address", NumberOfBytes: "20"},
+	"t_array(t_uint256)50_storage": {Encoding: "inplace", Label: "uint256[50]", NumberOfBytes: "1600", Base: "t_uint256"},
+	"t_array(t_uint256)49_storage": {Encoding: "inplace", Label: "uint256[49]", NumberOfBytes: "1568", Base: "t_uint256"},
+	"t_mapping(t_address,t_uint256)": {
+		Encoding:      "mapping",
+		Label:         "mapping(address => uint256)",
+		NumberOfBytes: "32",
+		Key:           "t_address",
+		Value:         "t_uint256",
+	},
+	"t_struct(Info)10_storage": {
+		Encoding:      "inplace",
+		Label:         "struct Token.Info",
+		NumberOfBytes: "64",
+		Members: []StorageEntry{
+			{Label: "owner", Slot: "0", Type: "t_address"},
+			{Label: "amount", Slot: "1", Type: "t_uint256"},
+		},
+	},
+	"t_struct(Info)20_storage": {
+		Encoding:      "inplace",
+		Label:         "struct Token.Info",
+		NumberOfBytes: "64",
+		Members: []StorageEntry{
+			{Label: "owner", Slot: "0", Type: "t_address"},
+			{Label: "amount", Slot: "1", Type: "t_uint128"},
+		},
+	},
+}
+
+func testStorageLayout(entries ...StorageEntry) *StorageLayout {
+	return &StorageLayout{
+		Storage: entries,
+		Types:   testStorageTypes,
+	}
+}
+
+func TestCheckStorageLayoutUpgrade(t *testing.T) {
+	current := testStorageLayout(
+		StorageEntry{Label: "owner", Slot: "0", Type: "t_address"},
+		StorageEntry{Label: "balances", Slot: "1", Type: "t_mapping(t_address,t_uint256)"},
+		StorageEntry{Label: "info", Slot: "2", Type: "t_struct(Info)10_storage"},
+		StorageEntry{Label: "__gap", Slot: "4", Type: "t_array(t_uint256)50_storage"},
+	)
+	tests := []struct {
+		name     string
+		next     *StorageLayout
+		problems int
+	}{
+		{
+			name: "same layout",
+			next: testStorageLayout(
+				StorageEntry{Label: "owner", Slot: "0", Type: "t_address"},
+				StorageEntry{Label: "balances", Slot: "1", Type: "t_mapping(t_address,t_uint256)"},
+				StorageEntry{Label: "info", Slot: "2", Type: "t_struct(Info)10_storage"},
+				StorageEntry{Label: "__gap", Slot: "4", Type: "t_array(t_uint256)50_storage"},
+			),
+		},
+		{
+			name: "variable appended after the gap",
+			next: testStorageLayout(
+				StorageEntry{Label: "owner", Slot: "0", Type: "t_address"},
+				StorageEntry{Label: "balances", Slot: "1", Type: "t_mapping(t_address,t_uint256)"},
+				StorageEntry{Label: "info", Slot: "2", Type: "t_struct(Info)10_storage"},
+				StorageEntry{Label: "__gap", Slot: "4", Type: "t_array(t_uint256)50_storage"},
+				StorageEntry{Label: "extra", Slot: "54", Type: "t_uint256"},
+			),
+		},
+		{
+			name: "variable taking gap space",
+			next: testStorageLayout(
+				StorageEntry{Label: "owner", Slot: "0", Type: "t_address"},
+				StorageEntry{Label: "balances", Slot: "1", Type: "t_mapping(t_address,t_uint256)"},
+				StorageEntry{Label: "info", Slot: "2", Type: "t_struct(Info)10_storage"},
+				StorageEntry{Label: "extra", Slot: "4", Type: "t_uint256"},
+				StorageEntry{Label: "__gap", Slot: "5", Type: "t_array(t_uint256)49_storage"},
+			),
+		},
+		{
+			name: "variable taking gap space without reducing it",
+			next: testStorageLayout(
+				StorageEntry{Label: "owner", Slot: "0", Type: "t_address"},
+				StorageEntry{Label: "balances", Slot: "1", Type: "t_mapping(t_address,t_uint256)"},
+				StorageEntry{Label: "info", Slot: "2", Type: "t_struct(Info)10_storage"},
+				StorageEntry{Label: "extra", Slot: "4", Type: "t_uint256"},
+				StorageEntry{Label: "__gap", Slot: "5", Type: "t_array(t_uint256)50_storage"},
+			),
+			problems: 1,
+		},
+		{
+			name: "variables reordered",
+			next: testStorageLayout(
+				StorageEntry{Label: "balances", Slot: "0", Type: "t_mapping(t_address,t_uint256)"},
+				StorageEntry{Label: "owner", Slot: "1", Type: "t_address"},
+				StorageEntry{Label: "info", Slot: "2", Type: "t_struct(Info)10_storage"},
+				StorageEntry{Label: "__gap", Slot: "4", Type: "t_array(t_uint256)50_storage"},
+			),
+			problems: 2,
+		},
+		{
+			name: "variable removed",
+			next: testStorageLayout(
+				StorageEntry{Label: "owner", Slot: "0", Type: "t_address"},
+				StorageEntry{Label: "balances", Slot: "1", Type: "t_mapping(t_address,t_uint256)"},
+				StorageEntry{Label: "__gap", Slot: "2", Type: "t_array(t_uint256)50_storage"},
+			),
+			problems: 1,
+		},
+		{
+			name: "struct member type changed",
+			next: testStorageLayout(
+				StorageEntry{Label: "owner", Slot: "0", Type: "t_address"},
+				StorageEntry{Label: "balances", Slot: "1", Type: "t_mapping(t_address,t_uint256)"},
+				StorageEntry{Label: "info", Slot: "2", Type: "t_struct(Info)20_storage"},
+				StorageEntry{Label: "__gap", Slot: "4", Type: "t_array(t_uint256)50_storage"},
+			),
+			problems: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			problems, err := CheckStorageLayoutUpgrade(current, test.next)
+			require.NoError(t, err)
+			require.Len(t, problems, test.problems, "%v", problems)
+		})
+	}
+}
+
+func TestLoadFoundryArtifactStorageLayout(t *testing.T) {
+	require := require.New(t)
+	artifact := map[string]interface{}{
+		"abi":           []interface{}{},
+		"bytecode":      map[string]interface{}{"object": "0x6080"},
+		"storageLayout": testStorageLayout(StorageEntry{Label: "owner", Slot: "0", Type: "t_address"}),
+	}
+	bs, err := json.Marshal(artifact)
+	require.NoError(err)
+	path := filepath.Join(t.TempDir(), "Token.json")
+	require.NoError(os.WriteFile(path, bs, 0o600))
+	loaded, err := LoadFoundryArtifact(path)
+	require.NoError(err)
+	require.NotNil(loaded.StorageLayout)
+	require.Equal("owner", loaded.StorageLayout.Storage[0].Label)
+	require.Equal("address", loaded.StorageLayout.Types["t_address"].Label)
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RecordERC20Token saves [token] into the sidecar of [subnetName], so it can
+// be referred to by name on later commands. A previous record with the same
+// address is replaced
+func RecordERC20Token(
+	app *application.Avalanche,
+	network models.Network,
+	subnetName string,
+	token models.ERC20Token,
+) error {
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return err
+	}
+	networkData, ok := sc.Networks[network.Name()]
+	if !ok {
+		return fmt.Errorf("subnet %s has not been deployed to %s", subnetName, network.Name())
+	}
+	tokens := []models.ERC20Token{}
+	for _, t := range networkData.ERC20Tokens {
+		if common.HexToAddress(t.Address) != common.HexToAddress(token.Address) {
+			tokens = append(tokens, t)
+		}
+	}
+	networkData.ERC20Tokens = append(tokens, token)
+	sc.Networks[network.Name()] = networkData
+	return app.UpdateSidecar(&sc)
+}
+
+// GetERC20Tokens returns the CLI deployed tokens of [subnetName] on [network]
+func GetERC20Tokens(
+	app *application.Avalanche,
+	network models.Network,
+	subnetName string,
+) ([]models.ERC20Token, error) {
+	sc, err := app.LoadSidecar(subnetName)
+	if err != nil {
+		return nil, err
+	}
+	return sc.Networks[network.Name()].ERC20Tokens, nil
+}
+
+// GetERC20Token looks for a CLI deployed token on [subnetName] whose name or
+// symbol matches [tokenName]
+func GetERC20Token(
+	app *application.Avalanche,
+	network models.Network,
+	subnetName string,
+	tokenName string,
+) (models.ERC20Token, error) {
+	tokens, err := GetERC20Tokens(app, network, subnetName)
+	if err != nil {
+		return models.ERC20Token{}, err
+	}
+	for _, token := range tokens {
+		if strings.EqualFold(token.Name, tokenName) || strings.EqualFold(token.Symbol, tokenName) {
+			return token, nil
+		}
+	}
+	return models.ERC20Token{}, fmt.Errorf("token %q not found on subnet %s for %s", tokenName, subnetName, network.Name())
+}
+
+// GetERC20TokenAddress returns [tokenRef] if it is an address, otherwise
+// looks for a CLI deployed token on [subnetName] with that name or symbol
+func GetERC20TokenAddress(
+	app *application.Avalanche,
+	network models.Network,
+	subnetName string,
+	isCChain bool,
+	tokenRef string,
+) (common.Address, error) {
+	if common.IsHexAddress(tokenRef) {
+		return common.HexToAddress(tokenRef), nil
+	}
+	if isCChain || subnetName == "" {
+		return common.Address{}, fmt.Errorf("%q is not an address, and tokens can only be referred to by name on CLI subnets", tokenRef)
+	}
+	token, err := GetERC20Token(app, network, subnetName, tokenRef)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.HexToAddress(token.Address), nil
+}
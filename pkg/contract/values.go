@@ -0,0 +1,195 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/subnet-evm/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ParseEspValues converts the string representations [values] into the go values
+// expected by the types given at [typesEsp] (eg "(address, uint256, [address])"),
+// so they can be used as params for DeployContract, TxToMethod or CallToMethod.
+// Arrays are given as comma separated values surrounded by brackets (eg "[0x1, 0x2]").
+// Only elementary types, and arrays of them, are supported
+func ParseEspValues(
+	typesEsp string,
+	values []string,
+) ([]interface{}, error) {
+	typesEsp, err := removeSurroundingParenthesis(typesEsp)
+	if err != nil {
+		return nil, err
+	}
+	types := getWords(typesEsp)
+	if len(types) != len(values) {
+		return nil, fmt.Errorf("expected %d values for esp %q, got %d", len(types), typesEsp, len(values))
+	}
+	params := []interface{}{}
+	for i, t := range types {
+		param, err := parseEspValue(t, values[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for field %d of esp %q: %w", values[i], i, typesEsp, err)
+		}
+		params = append(params, param)
+	}
+	return params, nil
+}
+
+// ParseMethodEspValues is as ParseEspValues, taking the types from the inputs of
+// [methodEsp] (eg "transfer(address, uint256)->(bool)")
+func ParseMethodEspValues(
+	methodEsp string,
+	values []string,
+) ([]interface{}, error) {
+	index := strings.Index(methodEsp, "(")
+	if index == -1 {
+		return nil, fmt.Errorf("invalid method %q: expected a signature as \"name(types)\"", methodEsp)
+	}
+	inputsEsp, _, _ := strings.Cut(methodEsp[index:], "->")
+	return ParseEspValues(inputsEsp, values)
+}
+
+// PackEspValues ABI encodes the string representations [values] of the types
+// given at [typesEsp], as abi.encode does. See ParseEspValues for the supported types
+func PackEspValues(
+	typesEsp string,
+	values []string,
+) ([]byte, error) {
+	params, err := ParseEspValues(typesEsp, values)
+	if err != nil {
+		return nil, err
+	}
+	typesEsp, err = removeSurroundingParenthesis(typesEsp)
+	if err != nil {
+		return nil, err
+	}
+	arguments := abi.Arguments{}
+	for _, t := range getWords(typesEsp) {
+		if strings.HasPrefix(t, "[") {
+			elemType, err := removeSurroundingBrackets(t)
+			if err != nil {
+				return nil, err
+			}
+			t = elemType + "[]"
+		}
+		abiType, err := abi.NewType(t, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, abi.Argument{Type: abiType})
+	}
+	return arguments.Pack(params...)
+}
+
+func parseEspValue(t string, value string) (interface{}, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(t, "(") {
+		return nil, fmt.Errorf("struct values are not supported")
+	}
+	if strings.HasPrefix(t, "[") {
+		elemType, err := removeSurroundingBrackets(t)
+		if err != nil {
+			return nil, err
+		}
+		abiType, err := abi.NewType(elemType+"[]", "", nil)
+		if err != nil {
+			return nil, err
+		}
+		value, err = removeSurroundingBrackets(value)
+		if err != nil {
+			return nil, err
+		}
+		slice := reflect.MakeSlice(abiType.GetType(), 0, 0)
+		if value == "" {
+			return slice.Interface(), nil
+		}
+		for _, elemValue := range strings.Split(value, ",") {
+			elem, err := parseEspValue(elemType, elemValue)
+			if err != nil {
+				return nil, err
+			}
+			slice = reflect.Append(slice, reflect.ValueOf(elem))
+		}
+		return slice.Interface(), nil
+	}
+	abiType, err := abi.NewType(t, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	switch abiType.T {
+	case abi.AddressTy:
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("invalid address")
+		}
+		return common.HexToAddress(value), nil
+	case abi.BoolTy:
+		return strconv.ParseBool(value)
+	case abi.StringTy:
+		return value, nil
+	case abi.BytesTy:
+		return common.FromHex(value), nil
+	case abi.FixedBytesTy:
+		bs := common.FromHex(value)
+		if len(bs) > abiType.Size {
+			return nil, fmt.Errorf("value exceeds %d bytes", abiType.Size)
+		}
+		fixedBytes := reflect.New(abiType.GetType()).Elem()
+		reflect.Copy(fixedBytes, reflect.ValueOf(bs))
+		return fixedBytes.Interface(), nil
+	case abi.IntTy, abi.UintTy:
+		n, ok := new(big.Int).SetString(value, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer")
+		}
+		if abiType.T == abi.UintTy && n.Sign() < 0 {
+			return nil, fmt.Errorf("negative value for unsigned integer")
+		}
+		if n.BitLen() > abiType.Size {
+			return nil, fmt.Errorf("value exceeds %d bits", abiType.Size)
+		}
+		goType := abiType.GetType()
+		switch goType.Kind() {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return reflect.ValueOf(n.Uint64()).Convert(goType).Interface(), nil
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return reflect.ValueOf(n.Int64()).Convert(goType).Interface(), nil
+		default:
+			return n, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// FormatValue gives a human readable representation of an ABI decoded value
+func FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case *big.Int:
+		return v.String()
+	case []byte:
+		return common.Bytes2Hex(v)
+	case [32]byte:
+		return common.Hash(v).Hex()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// FormatValues gives a comma separated human readable representation of ABI decoded values
+func FormatValues(values []interface{}) string {
+	strs := []string{}
+	for _, value := range values {
+		strs = append(strs, FormatValue(value))
+	}
+	return strings.Join(strs, ", ")
+}
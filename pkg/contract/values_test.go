@@ -0,0 +1,67 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEspValues(t *testing.T) {
+	require := require.New(t)
+	address := "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"
+	params, err := ParseEspValues(
+		"(address, uint256, uint8, bool, string, bytes32, [address])",
+		[]string{address, "1000000000000000000000", "18", "true", "hello", "0x01", "[" + address + "," + address + "]"},
+	)
+	require.NoError(err)
+	require.Len(params, 7)
+	require.Equal(common.HexToAddress(address), params[0])
+	expectedSupply, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	require.Equal(expectedSupply, params[1])
+	require.Equal(uint8(18), params[2])
+	require.Equal(true, params[3])
+	require.Equal("hello", params[4])
+	require.Equal([32]byte{1}, params[5])
+	require.Equal([]common.Address{common.HexToAddress(address), common.HexToAddress(address)}, params[6])
+	// the parsed values can be packed as constructor params
+	_, err = GetCreate2InitCode(tokenBin, "(address, uint256, uint8, bool, string, bytes32, [address])", params...)
+	require.NoError(err)
+
+	_, err = ParseEspValues("(address)", []string{"0x1234"})
+	require.Error(err)
+	_, err = ParseEspValues("(uint8)", []string{"256"})
+	require.Error(err)
+	_, err = ParseEspValues("(uint256)", []string{"-1"})
+	require.Error(err)
+	_, err = ParseEspValues("(uint256, address)", []string{"1"})
+	require.Error(err)
+}
+
+func TestPackEspValues(t *testing.T) {
+	require := require.New(t)
+	address := "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"
+	packed, err := PackEspValues("(address, uint256)", []string{address, "1"})
+	require.NoError(err)
+	require.Equal(
+		append(common.LeftPadBytes(common.HexToAddress(address).Bytes(), 32), common.LeftPadBytes([]byte{1}, 32)...),
+		packed,
+	)
+	// dynamic types are encoded after the head
+	packed, err = PackEspValues("([uint256])", []string{"[1, 2]"})
+	require.NoError(err)
+	require.Len(packed, 4*32)
+	_, err = PackEspValues("(address)", []string{"1"})
+	require.Error(err)
+}
+
+func TestFormatValues(t *testing.T) {
+	address := common.HexToAddress("0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC")
+	require.Equal(t, address.Hex(), FormatValue(address))
+	require.Equal(t, "0102", FormatValue([]byte{1, 2}))
+	require.Equal(t, common.Hash{1}.Hex(), FormatValue([32]byte{1}))
+	require.Equal(t, "10, true", FormatValues([]interface{}{big.NewInt(10), true}))
+}
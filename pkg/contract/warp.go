@@ -0,0 +1,103 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package contract
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/precompile/contracts/warp"
+	"github.com/ava-labs/subnet-evm/predicate"
+	subnetEvmUtils "github.com/ava-labs/subnet-evm/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TxToMethodWithWarpMessage calls [methodEsp] as TxToMethod does, also including the
+// signed [warpMessage] into the tx access list, so the method can get it verified
+// from the Warp precompile (getVerifiedWarpMessage(0))
+func TxToMethodWithWarpMessage(
+	rpcURL string,
+	privateKey string,
+	contractAddress common.Address,
+	warpMessage *avalancheWarp.Message,
+	payment *big.Int,
+	methodEsp string,
+	params ...interface{},
+) (*types.Transaction, *types.Receipt, error) {
+	data, err := PackMethodCall(methodEsp, params...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if payment == nil {
+		payment = big.NewInt(0)
+	}
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer client.Close()
+	signer, err := crypto.HexToECDSA(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	signerAddress := crypto.PubkeyToAddress(signer.PublicKey)
+	gasFeeCap, gasTipCap, nonce, err := evm.CalculateTxParams(client, signerAddress.Hex())
+	if err != nil {
+		return nil, nil, err
+	}
+	chainID, err := evm.GetChainID(client)
+	if err != nil {
+		return nil, nil, err
+	}
+	accessList := types.AccessList{
+		{
+			Address:     warp.ContractAddress,
+			StorageKeys: subnetEvmUtils.BytesToHashSlice(predicate.PackPredicate(warpMessage.Bytes())),
+		},
+	}
+	ctx, cancel := utils.GetAPILargeContext()
+	defer cancel()
+	gas, err := client.EstimateGas(ctx, interfaces.CallMsg{
+		From:       signerAddress,
+		To:         &contractAddress,
+		Value:      payment,
+		Data:       data,
+		AccessList: accessList,
+	})
+	if err != nil {
+		return nil, nil, revertError(fmt.Errorf("failure estimating gas for %s: %w", methodEsp, err))
+	}
+	tx := predicate.NewPredicateTx(
+		chainID,
+		nonce,
+		&contractAddress,
+		gas,
+		gasFeeCap,
+		gasTipCap,
+		payment,
+		data,
+		types.AccessList{},
+		warp.ContractAddress,
+		warpMessage.Bytes(),
+	)
+	signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(chainID), signer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := evm.SendTransaction(client, signedTx); err != nil {
+		return nil, nil, err
+	}
+	receipt, success, err := evm.WaitForTransaction(client, signedTx)
+	if err != nil {
+		return signedTx, nil, err
+	} else if !success {
+		return signedTx, receipt, failedReceiptError(rpcURL, signedTx, receipt)
+	}
+	return signedTx, receipt, nil
+}
@@ -66,6 +66,36 @@ func GetContractBytecode(
 	return code, err
 }
 
+// GetStorageAt returns the value stored at [slot] of the contract at [contractAddress]
+func GetStorageAt(
+	client ethclient.Client,
+	contractAddress common.Address,
+	slot common.Hash,
+) (common.Hash, error) {
+	var (
+		value []byte
+		err   error
+	)
+	for i := 0; i < repeatsOnFailure; i++ {
+		ctx, cancel := utils.GetAPILargeContext()
+		defer cancel()
+		value, err = client.StorageAt(ctx, contractAddress, slot, nil)
+		if err == nil {
+			break
+		}
+		err = fmt.Errorf(
+			"failure obtaining storage slot %s for %s on %#v: %w",
+			slot.Hex(),
+			contractAddress.Hex(),
+			client,
+			err,
+		)
+		ux.Logger.RedXToUser("%s", err)
+		time.Sleep(sleepBetweenRepeats)
+	}
+	return common.BytesToHash(value), err
+}
+
 func GetAddressBalance(
 	client ethclient.Client,
 	addressStr string,
@@ -0,0 +1,307 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+)
+
+const (
+	// max number of blocks to request logs for on a single eth_getLogs call
+	maxBlocksPerLogsRequest = 2048
+	// time to wait before trying to reconnect a failed logs subscription
+	logsReconnectInterval = 2 * time.Second
+	// time to wait between polls for new logs when no ws subscription is available
+	logsPollInterval = 2 * time.Second
+	// DefaultLogsBlockWindow is the number of recent blocks to look for logs on, when
+	// no starting block is given
+	DefaultLogsBlockWindow = 100_000
+	// FromRecentBlocks can be given as the starting block of GetLogsInRange, to only look
+	// for logs on the last [DefaultLogsBlockWindow] blocks of the range
+	FromRecentBlocks = math.MaxUint64
+)
+
+// GetRecentBlocksStart returns the first block of the window of [DefaultLogsBlockWindow]
+// blocks that ends at [headBlock]
+func GetRecentBlocksStart(headBlock uint64) uint64 {
+	if headBlock < DefaultLogsBlockWindow {
+		return 0
+	}
+	return headBlock - DefaultLogsBlockWindow + 1
+}
+
+func GetBlockNumber(
+	client ethclient.Client,
+) (uint64, error) {
+	var (
+		blockNumber uint64
+		err         error
+	)
+	for i := 0; i < repeatsOnFailure; i++ {
+		ctx, cancel := utils.GetAPILargeContext()
+		defer cancel()
+		blockNumber, err = client.BlockNumber(ctx)
+		if err == nil {
+			break
+		}
+		err = fmt.Errorf("failure obtaining block number on %#v: %w", client, err)
+		ux.Logger.RedXToUser("%s", err)
+		time.Sleep(sleepBetweenRepeats)
+	}
+	return blockNumber, err
+}
+
+// GetBlockTimestamp returns the timestamp of block [blockNumber]
+func GetBlockTimestamp(
+	client ethclient.Client,
+	blockNumber uint64,
+) (time.Time, error) {
+	var (
+		header *types.Header
+		err    error
+	)
+	for i := 0; i < repeatsOnFailure; i++ {
+		ctx, cancel := utils.GetAPILargeContext()
+		defer cancel()
+		header, err = client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+		if err == nil {
+			break
+		}
+		err = fmt.Errorf("failure obtaining header of block %d on %#v: %w", blockNumber, client, err)
+		ux.Logger.RedXToUser("%s", err)
+		time.Sleep(sleepBetweenRepeats)
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(int64(header.Time), 0), nil
+}
+
+func FilterLogs(
+	client ethclient.Client,
+	query interfaces.FilterQuery,
+) ([]types.Log, error) {
+	var (
+		logs []types.Log
+		err  error
+	)
+	for i := 0; i < repeatsOnFailure; i++ {
+		ctx, cancel := utils.GetAPILargeContext()
+		defer cancel()
+		logs, err = client.FilterLogs(ctx, query)
+		if err == nil {
+			break
+		}
+		err = fmt.Errorf("failure filtering logs on %#v: %w", client, err)
+		ux.Logger.RedXToUser("%s", err)
+		time.Sleep(sleepBetweenRepeats)
+	}
+	return logs, err
+}
+
+// GetLogsInRange returns the logs matching [query] for the block range [fromBlock, toBlock],
+// splitting the request into several eth_getLogs calls if needed. If [fromBlock] is
+// [FromRecentBlocks], the range starts [DefaultLogsBlockWindow] blocks before [toBlock]
+func GetLogsInRange(
+	client ethclient.Client,
+	query interfaces.FilterQuery,
+	fromBlock uint64,
+	toBlock uint64,
+) ([]types.Log, error) {
+	if fromBlock == FromRecentBlocks {
+		fromBlock = GetRecentBlocksStart(toBlock)
+	}
+	logs := []types.Log{}
+	for from := fromBlock; from <= toBlock; from += maxBlocksPerLogsRequest {
+		to := from + maxBlocksPerLogsRequest - 1
+		if to > toBlock {
+			to = toBlock
+		}
+		query.FromBlock = new(big.Int).SetUint64(from)
+		query.ToBlock = new(big.Int).SetUint64(to)
+		rangeLogs, err := FilterLogs(client, query)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, rangeLogs...)
+	}
+	return logs, nil
+}
+
+// WatchLogs calls [handler] for all logs matching [query] starting at [fromBlock], until [ctx]
+// is done or [handler] fails.
+// Past logs are obtained from [rpcURL]. New ones are received by subscribing on [wsURL], or
+// by polling [rpcURL] if [wsURL] is empty. If the subscription fails, it reconnects and
+// catches up with the logs emitted while disconnected.
+func WatchLogs(
+	ctx context.Context,
+	rpcURL string,
+	wsURL string,
+	query interfaces.FilterQuery,
+	fromBlock uint64,
+	handler func([]types.Log) error,
+) error {
+	client, err := GetClient(rpcURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	var (
+		// next block to be requested by catch up
+		nextBlock = fromBlock
+		// position of the last log given to handler
+		lastBlock uint64
+		lastIndex uint
+		handled   bool
+	)
+	// gives handler the logs not already handled, so as to avoid duplicates
+	// among catch ups and subscriptions
+	deliver := func(logs []types.Log) error {
+		newLogs := []types.Log{}
+		for _, log := range logs {
+			if log.Removed || log.BlockNumber < fromBlock {
+				continue
+			}
+			if handled && (log.BlockNumber < lastBlock || (log.BlockNumber == lastBlock && log.Index <= lastIndex)) {
+				continue
+			}
+			newLogs = append(newLogs, log)
+			lastBlock, lastIndex, handled = log.BlockNumber, log.Index, true
+		}
+		if len(newLogs) == 0 {
+			return nil
+		}
+		if err := handler(newLogs); err != nil {
+			return handlerError{err}
+		}
+		return nil
+	}
+	// handler errors finish the watch. other ones are considered connectivity
+	// issues to be retried
+	checkErr := func(err error) error {
+		var hErr handlerError
+		if errors.As(err, &hErr) {
+			return hErr.err
+		}
+		ux.Logger.RedXToUser("failure obtaining logs from %s: %s. retrying", rpcURL, err)
+		return nil
+	}
+	catchUp := func() error {
+		if handled && lastBlock > nextBlock {
+			nextBlock = lastBlock
+		}
+		headBlock, err := GetBlockNumber(client)
+		if err != nil {
+			return err
+		}
+		if headBlock < nextBlock {
+			return nil
+		}
+		logs, err := GetLogsInRange(client, query, nextBlock, headBlock)
+		if err != nil {
+			return err
+		}
+		if err := deliver(logs); err != nil {
+			return err
+		}
+		nextBlock = headBlock + 1
+		return nil
+	}
+	for {
+		if wsURL == "" {
+			if err := catchUp(); err != nil {
+				if err := checkErr(err); err != nil {
+					return err
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(logsPollInterval):
+			}
+			continue
+		}
+		// subscribe before catching up, so no log is lost in between
+		wsClient, sub, logsCh, err := subscribeLogs(ctx, wsURL, query)
+		if err != nil {
+			ux.Logger.RedXToUser("failure subscribing to logs on %s: %s. retrying", wsURL, err)
+			if err := catchUp(); err != nil {
+				if err := checkErr(err); err != nil {
+					return err
+				}
+			}
+		} else {
+			err := func() error {
+				defer wsClient.Close()
+				defer sub.Unsubscribe()
+				if err := catchUp(); err != nil {
+					return err
+				}
+				for {
+					select {
+					case <-ctx.Done():
+						return nil
+					case err := <-sub.Err():
+						ux.Logger.RedXToUser("logs subscription on %s failed: %s. reconnecting", wsURL, err)
+						return nil
+					case log := <-logsCh:
+						if err := deliver([]types.Log{log}); err != nil {
+							return err
+						}
+					}
+				}
+			}()
+			if err != nil {
+				if err := checkErr(err); err != nil {
+					return err
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(logsReconnectInterval):
+		}
+	}
+}
+
+type handlerError struct {
+	err error
+}
+
+func (e handlerError) Error() string {
+	return e.err.Error()
+}
+
+func subscribeLogs(
+	ctx context.Context,
+	wsURL string,
+	query interfaces.FilterQuery,
+) (ethclient.Client, interfaces.Subscription, chan types.Log, error) {
+	dialCtx, cancel := utils.GetAPILargeContext()
+	defer cancel()
+	wsClient, err := ethclient.DialContext(dialCtx, wsURL)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	logsCh := make(chan types.Log)
+	query.FromBlock = nil
+	query.ToBlock = nil
+	sub, err := wsClient.SubscribeFilterLogs(ctx, query, logsCh)
+	if err != nil {
+		wsClient.Close()
+		return nil, nil, nil, err
+	}
+	return wsClient, sub, logsCh, nil
+}
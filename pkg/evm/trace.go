@@ -0,0 +1,173 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package evm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// JSON-RPC error code of reverted calls that return revert data
+const executionRevertedErrorCode = 3
+
+// CallFrame is a call as reported by debug_traceTransaction callTracer,
+// including its inner calls
+type CallFrame struct {
+	Type         string         `json:"type"`
+	From         common.Address `json:"from"`
+	To           common.Address `json:"to"`
+	Value        *hexutil.Big   `json:"value"`
+	Gas          hexutil.Uint64 `json:"gas"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	Input        hexutil.Bytes  `json:"input"`
+	Output       hexutil.Bytes  `json:"output"`
+	Error        string         `json:"error"`
+	RevertReason string         `json:"revertReason"`
+	Calls        []CallFrame    `json:"calls"`
+}
+
+// GetCallTrace returns the callTracer trace of [txID] as a call tree
+func GetCallTrace(rpcURL string, txID string) (*CallFrame, error) {
+	trace, err := GetTrace(rpcURL, txID)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := json.Marshal(trace)
+	if err != nil {
+		return nil, err
+	}
+	var frame CallFrame
+	if err := json.Unmarshal(bs, &frame); err != nil {
+		return nil, fmt.Errorf("failure parsing trace for tx %s: %w", txID, err)
+	}
+	return &frame, nil
+}
+
+func GetTransaction(
+	client ethclient.Client,
+	txHash common.Hash,
+) (*types.Transaction, error) {
+	var (
+		tx  *types.Transaction
+		err error
+	)
+	for i := 0; i < repeatsOnFailure; i++ {
+		ctx, cancel := utils.GetAPILargeContext()
+		defer cancel()
+		tx, _, err = client.TransactionByHash(ctx, txHash)
+		if err == nil {
+			break
+		}
+		err = fmt.Errorf("failure obtaining tx %s on %#v: %w", txHash, client, err)
+		ux.Logger.RedXToUser("%s", err)
+		time.Sleep(sleepBetweenRepeats)
+	}
+	return tx, err
+}
+
+func GetTransactionReceipt(
+	client ethclient.Client,
+	txHash common.Hash,
+) (*types.Receipt, error) {
+	var (
+		receipt *types.Receipt
+		err     error
+	)
+	for i := 0; i < repeatsOnFailure; i++ {
+		ctx, cancel := utils.GetAPILargeContext()
+		defer cancel()
+		receipt, err = client.TransactionReceipt(ctx, txHash)
+		if err == nil {
+			break
+		}
+		err = fmt.Errorf("failure obtaining receipt for tx %s on %#v: %w", txHash, client, err)
+		ux.Logger.RedXToUser("%s", err)
+		time.Sleep(sleepBetweenRepeats)
+	}
+	return receipt, err
+}
+
+// GetTransactionSender returns the address that signed [tx]
+func GetTransactionSender(tx *types.Transaction) (common.Address, error) {
+	var signer types.Signer = types.HomesteadSigner{}
+	if tx.Protected() {
+		signer = types.LatestSignerForChainID(tx.ChainId())
+	}
+	return types.Sender(signer, tx)
+}
+
+// ReplayTransaction executes [tx] as a call over the state previous to [blockNumber],
+// and returns the revert data obtained, if any.
+// This is an approximation to be used when debug tracing is not available, as the
+// txs preceding [tx] on its block are not taken into account
+func ReplayTransaction(
+	client ethclient.Client,
+	tx *types.Transaction,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	from, err := GetTransactionSender(tx)
+	if err != nil {
+		return nil, err
+	}
+	msg := interfaces.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	var parentBlock *big.Int
+	if blockNumber != nil && blockNumber.Sign() > 0 {
+		parentBlock = new(big.Int).Sub(blockNumber, big.NewInt(1))
+	}
+	ctx, cancel := utils.GetAPILargeContext()
+	defer cancel()
+	_, err = client.CallContract(ctx, msg, parentBlock)
+	if err == nil {
+		return nil, nil
+	}
+	if data, ok := GetRevertData(err); ok {
+		return data, nil
+	}
+	return nil, err
+}
+
+// IsExecutionReverted tells if [err] is the RPC error of a call or gas estimation
+// that reverted, as opposed to a failure to make the request
+func IsExecutionReverted(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == executionRevertedErrorCode {
+		return true
+	}
+	return err != nil && strings.Contains(err.Error(), "execution reverted")
+}
+
+// GetRevertData returns the revert data attached to an RPC execution error, if any
+func GetRevertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	data, ok := dataErr.ErrorData().(string)
+	if !ok || !strings.HasPrefix(data, "0x") {
+		return nil, false
+	}
+	bs, decodeErr := hexutil.Decode(data)
+	if decodeErr != nil {
+		return nil, false
+	}
+	return bs, true
+}
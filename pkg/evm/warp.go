@@ -0,0 +1,51 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package evm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/rpc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// WarpQuorumPercentage is the stake weight percentage a warp message needs to be signed
+// with to be accepted by default by the Warp precompile
+const WarpQuorumPercentage = 67
+
+// GetWarpMessageAggregateSignature asks the node at [client] to gather the validators
+// signatures of the warp message [messageID] of its blockchain. Returns the signed message
+func GetWarpMessageAggregateSignature(
+	client *rpc.Client,
+	messageID ids.ID,
+	quorumPercentage uint64,
+	subnetID string,
+) ([]byte, error) {
+	var (
+		signedMessage hexutil.Bytes
+		err           error
+	)
+	for i := 0; i < repeatsOnFailure; i++ {
+		ctx, cancel := utils.GetAPILargeContext()
+		defer cancel()
+		err = client.CallContext(
+			ctx,
+			&signedMessage,
+			"warp_getMessageAggregateSignature",
+			messageID,
+			quorumPercentage,
+			subnetID,
+		)
+		if err == nil {
+			break
+		}
+		err = fmt.Errorf("failure getting aggregate signature of warp message %s: %w", messageID, err)
+		ux.Logger.RedXToUser("%s", err)
+		time.Sleep(sleepBetweenRepeats)
+	}
+	return signedMessage, err
+}
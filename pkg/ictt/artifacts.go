@@ -0,0 +1,187 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package ictt
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+)
+
+const (
+	ERC20TokenHomeContract     = "ERC20TokenHome"
+	ERC20TokenRemoteContract   = "ERC20TokenRemote"
+	NativeTokenHomeContract    = "NativeTokenHome"
+	NativeTokenRemoteContract  = "NativeTokenRemote"
+	WrappedNativeTokenContract = "WrappedNativeToken"
+
+	embeddedContractsDir = "contracts"
+	checksumsFileName    = "SHA256SUMS"
+)
+
+// contracts deployed by CLI, whose artifacts are embedded for each supported version
+var artifactContracts = []string{
+	ERC20TokenHomeContract,
+	ERC20TokenRemoteContract,
+	NativeTokenHomeContract,
+	NativeTokenRemoteContract,
+	WrappedNativeTokenContract,
+}
+
+// ABI and bytecode of the supported ICTT releases, generated by scripts/build_ictt_contracts.sh
+//
+//go:embed contracts
+var embeddedContracts embed.FS
+
+var ErrArtifactsNotEmbedded = errors.New("prebuilt ICTT contracts not available")
+
+// Artifacts gives access to the compiled ICTT contracts, either embedded into CLI
+// or built from a local checkout of the ICTT repo
+type Artifacts struct {
+	version string
+	// embedded artifacts, keyed by file name
+	files map[string][]byte
+	// repo checkout dir, for artifacts built from source
+	srcDir string
+}
+
+// GetEmbeddedArtifacts returns the artifacts embedded for ICTT [version], verifying
+// them against their checksums. Returns ErrArtifactsNotEmbedded if there are none
+func GetEmbeddedArtifacts(version string) (Artifacts, error) {
+	fsys, err := fs.Sub(embeddedContracts, embeddedContractsDir)
+	if err != nil {
+		return Artifacts{}, err
+	}
+	return loadArtifacts(fsys, version)
+}
+
+// GetSourceArtifacts returns the artifacts built by foundry at the ICTT repo checkout [srcDir]
+func GetSourceArtifacts(srcDir string) Artifacts {
+	return Artifacts{
+		srcDir: utils.ExpandHome(srcDir),
+	}
+}
+
+// EmbeddedVersions returns the ICTT versions with artifacts embedded into CLI
+func EmbeddedVersions() []string {
+	versions := []string{}
+	fsys, err := fs.Sub(embeddedContracts, embeddedContractsDir)
+	if err != nil {
+		return versions
+	}
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return versions
+	}
+	for _, entry := range entries {
+		if _, err := loadArtifacts(fsys, entry.Name()); err == nil {
+			versions = append(versions, entry.Name())
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// parses a sha256sum output file
+func parseChecksums(content []byte) (map[string]string, error) {
+	checksums := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid checksum line %q", line)
+		}
+		checksums[strings.TrimPrefix(fields[1], "*")] = strings.ToLower(fields[0])
+	}
+	return checksums, scanner.Err()
+}
+
+func loadArtifacts(fsys fs.FS, version string) (Artifacts, error) {
+	checksumsContent, err := fs.ReadFile(fsys, path.Join(version, checksumsFileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Artifacts{}, fmt.Errorf("%w for version %s", ErrArtifactsNotEmbedded, version)
+	}
+	if err != nil {
+		return Artifacts{}, err
+	}
+	checksums, err := parseChecksums(checksumsContent)
+	if err != nil {
+		return Artifacts{}, fmt.Errorf("invalid checksums for ICTT %s: %w", version, err)
+	}
+	artifacts := Artifacts{
+		version: version,
+		files:   map[string][]byte{},
+	}
+	for _, contractName := range artifactContracts {
+		for _, fileName := range []string{contractName + ".bin", contractName + ".abi.json"} {
+			content, err := fs.ReadFile(fsys, path.Join(version, fileName))
+			if errors.Is(err, fs.ErrNotExist) || len(content) == 0 {
+				return Artifacts{}, fmt.Errorf(
+					"%w for version %s: %s has not been generated. generate it with scripts/build_ictt_contracts.sh",
+					ErrArtifactsNotEmbedded,
+					version,
+					fileName,
+				)
+			}
+			if err != nil {
+				return Artifacts{}, err
+			}
+			checksum, ok := checksums[fileName]
+			if !ok {
+				return Artifacts{}, fmt.Errorf("no checksum found for ICTT %s %s", version, fileName)
+			}
+			sum := sha256.Sum256(content)
+			if hex.EncodeToString(sum[:]) != checksum {
+				return Artifacts{}, fmt.Errorf("checksum mismatch for ICTT %s %s", version, fileName)
+			}
+			artifacts.files[fileName] = content
+		}
+	}
+	return artifacts, nil
+}
+
+// Bytecode returns the creation bytecode of [contractName]
+func (a Artifacts) Bytecode(contractName string) ([]byte, error) {
+	if a.files == nil {
+		binPath := filepath.Join(a.srcDir, "contracts", "out", contractName+".sol", contractName+".bin")
+		return os.ReadFile(binPath)
+	}
+	bytecode, ok := a.files[contractName+".bin"]
+	if !ok {
+		return nil, fmt.Errorf("contract %s not found on ICTT %s artifacts", contractName, a.version)
+	}
+	return bytecode, nil
+}
+
+// ABI returns the JSON ABI of [contractName]
+func (a Artifacts) ABI(contractName string) ([]byte, error) {
+	if a.files == nil {
+		artifact, err := contract.LoadFoundryArtifact(filepath.Join(a.srcDir, "contracts", "out", contractName+".sol", contractName+".json"))
+		if err != nil {
+			return nil, err
+		}
+		return artifact.ABI, nil
+	}
+	abi, ok := a.files[contractName+".abi.json"]
+	if !ok {
+		return nil, fmt.Errorf("contract %s not found on ICTT %s artifacts", contractName, a.version)
+	}
+	return abi, nil
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package ictt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+// builds a valid artifacts fs for [version], with the checksums file
+func newTestArtifactsFS(version string) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	checksums := strings.Builder{}
+	for _, contractName := range artifactContracts {
+		for _, fileName := range []string{contractName + ".bin", contractName + ".abi.json"} {
+			content := []byte("content of " + fileName)
+			fsys[version+"/"+fileName] = &fstest.MapFile{Data: content}
+			sum := sha256.Sum256(content)
+			checksums.WriteString(fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), fileName))
+		}
+	}
+	fsys[version+"/"+checksumsFileName] = &fstest.MapFile{Data: []byte(checksums.String())}
+	return fsys
+}
+
+func TestLoadArtifacts(t *testing.T) {
+	require := require.New(t)
+	fsys := newTestArtifactsFS("v1.0.0")
+	artifacts, err := loadArtifacts(fsys, "v1.0.0")
+	require.NoError(err)
+	bytecode, err := artifacts.Bytecode(ERC20TokenHomeContract)
+	require.NoError(err)
+	require.Equal([]byte("content of ERC20TokenHome.bin"), bytecode)
+	abi, err := artifacts.ABI(NativeTokenRemoteContract)
+	require.NoError(err)
+	require.Equal([]byte("content of NativeTokenRemote.abi.json"), abi)
+	_, err = artifacts.Bytecode("Unknown")
+	require.ErrorContains(err, "not found")
+
+	_, err = loadArtifacts(fsys, "v2.0.0")
+	require.ErrorIs(err, ErrArtifactsNotEmbedded)
+}
+
+func TestLoadArtifactsChecks(t *testing.T) {
+	require := require.New(t)
+
+	fsys := newTestArtifactsFS("v1.0.0")
+	fsys["v1.0.0/ERC20TokenRemote.bin"] = &fstest.MapFile{Data: []byte("tampered")}
+	_, err := loadArtifacts(fsys, "v1.0.0")
+	require.ErrorContains(err, "checksum mismatch")
+	require.NotErrorIs(err, ErrArtifactsNotEmbedded)
+
+	fsys = newTestArtifactsFS("v1.0.0")
+	fsys["v1.0.0/WrappedNativeToken.bin"] = &fstest.MapFile{Data: []byte{}}
+	_, err = loadArtifacts(fsys, "v1.0.0")
+	require.ErrorIs(err, ErrArtifactsNotEmbedded)
+
+	fsys = newTestArtifactsFS("v1.0.0")
+	delete(fsys, "v1.0.0/NativeTokenHome.abi.json")
+	_, err = loadArtifacts(fsys, "v1.0.0")
+	require.ErrorIs(err, ErrArtifactsNotEmbedded)
+
+	fsys = newTestArtifactsFS("v1.0.0")
+	fsys["v1.0.0/"+checksumsFileName] = &fstest.MapFile{Data: []byte("abcd  Other.bin\n")}
+	_, err = loadArtifacts(fsys, "v1.0.0")
+	require.ErrorContains(err, "no checksum found")
+
+	fsys["v1.0.0/"+checksumsFileName] = &fstest.MapFile{Data: []byte("invalid\n")}
+	_, err = loadArtifacts(fsys, "v1.0.0")
+	require.ErrorContains(err, "invalid checksum line")
+}
+
+// checks that every embedded ICTT version has all the artifacts generated by
+// scripts/build_ictt_contracts.sh, matching its SHA256SUMS
+func TestEmbeddedArtifacts(t *testing.T) {
+	entries, err := embeddedContracts.ReadDir(embeddedContractsDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+	for _, entry := range entries {
+		version := entry.Name()
+		t.Run(version, func(t *testing.T) {
+			require := require.New(t)
+			checksumsContent, err := embeddedContracts.ReadFile(path.Join(embeddedContractsDir, version, checksumsFileName))
+			require.NoError(err)
+			checksums, err := parseChecksums(checksumsContent)
+			require.NoError(err)
+			require.Len(checksums, 2*len(artifactContracts), "%s should list every artifact", checksumsFileName)
+			for _, contractName := range artifactContracts {
+				for _, fileName := range []string{contractName + ".bin", contractName + ".abi.json"} {
+					content, err := embeddedContracts.ReadFile(path.Join(embeddedContractsDir, version, fileName))
+					require.NoError(err)
+					require.NotEmpty(content, "%s has not been generated", fileName)
+					sum := sha256.Sum256(content)
+					require.Equal(checksums[fileName], hex.EncodeToString(sum[:]), "checksum of %s", fileName)
+				}
+			}
+			_, err = GetEmbeddedArtifacts(version)
+			require.NoError(err)
+		})
+	}
+	require.Len(t, EmbeddedVersions(), len(entries))
+}
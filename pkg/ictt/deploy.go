@@ -5,11 +5,8 @@ package ictt
 import (
 	_ "embed"
 	"math/big"
-	"os"
-	"path/filepath"
 
 	"github.com/ava-labs/avalanche-cli/pkg/contract"
-	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -46,7 +43,7 @@ func RegisterERC20Remote(
 }
 
 func DeployERC20Remote(
-	srcDir string,
+	artifacts Artifacts,
 	rpcURL string,
 	privateKey string,
 	teleporterRegistryAddress common.Address,
@@ -57,8 +54,7 @@ func DeployERC20Remote(
 	tokenSymbol string,
 	tokenDecimals uint8,
 ) (common.Address, error) {
-	binPath := filepath.Join(srcDir, "contracts/out/ERC20TokenRemote.sol/ERC20TokenRemote.bin")
-	binBytes, err := os.ReadFile(binPath)
+	binBytes, err := artifacts.Bytecode(ERC20TokenRemoteContract)
 	if err != nil {
 		return common.Address{}, err
 	}
@@ -83,7 +79,7 @@ func DeployERC20Remote(
 }
 
 func DeployNativeRemote(
-	srcDir string,
+	artifacts Artifacts,
 	rpcURL string,
 	privateKey string,
 	teleporterRegistryAddress common.Address,
@@ -95,8 +91,7 @@ func DeployNativeRemote(
 	initialReserveImbalance *big.Int,
 	burnedFeesReportingRewardPercentage *big.Int,
 ) (common.Address, error) {
-	binPath := filepath.Join(srcDir, "contracts/out/NativeTokenRemote.sol/NativeTokenRemote.bin")
-	binBytes, err := os.ReadFile(binPath)
+	binBytes, err := artifacts.Bytecode(NativeTokenRemoteContract)
 	if err != nil {
 		return common.Address{}, err
 	}
@@ -121,7 +116,7 @@ func DeployNativeRemote(
 }
 
 func DeployERC20Home(
-	srcDir string,
+	artifacts Artifacts,
 	rpcURL string,
 	privateKey string,
 	teleporterRegistryAddress common.Address,
@@ -129,8 +124,7 @@ func DeployERC20Home(
 	erc20TokenAddress common.Address,
 	erc20TokenDecimals uint8,
 ) (common.Address, error) {
-	binPath := filepath.Join(srcDir, "contracts/out/ERC20TokenHome.sol/ERC20TokenHome.bin")
-	binBytes, err := os.ReadFile(binPath)
+	binBytes, err := artifacts.Bytecode(ERC20TokenHomeContract)
 	if err != nil {
 		return common.Address{}, err
 	}
@@ -147,15 +141,14 @@ func DeployERC20Home(
 }
 
 func DeployNativeHome(
-	srcDir string,
+	artifacts Artifacts,
 	rpcURL string,
 	privateKey string,
 	teleporterRegistryAddress common.Address,
 	teleporterManagerAddress common.Address,
 	wrappedNativeTokenAddress common.Address,
 ) (common.Address, error) {
-	binPath := filepath.Join(srcDir, "contracts/out/NativeTokenHome.sol/NativeTokenHome.bin")
-	binBytes, err := os.ReadFile(binPath)
+	binBytes, err := artifacts.Bytecode(NativeTokenHomeContract)
 	if err != nil {
 		return common.Address{}, err
 	}
@@ -171,13 +164,12 @@ func DeployNativeHome(
 }
 
 func DeployWrappedNativeToken(
-	srcDir string,
+	artifacts Artifacts,
 	rpcURL string,
 	privateKey string,
 	tokenSymbol string,
 ) (common.Address, error) {
-	binPath := filepath.Join(utils.ExpandHome(srcDir), "contracts/out/WrappedNativeToken.sol/WrappedNativeToken.bin")
-	binBytes, err := os.ReadFile(binPath)
+	binBytes, err := artifacts.Bytecode(WrappedNativeTokenContract)
 	if err != nil {
 		return common.Address{}, err
 	}
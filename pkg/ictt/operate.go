@@ -9,6 +9,7 @@ import (
 
 	"github.com/ava-labs/avalanche-cli/pkg/contract"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -22,6 +23,91 @@ const (
 	NativeTokenRemote
 )
 
+const defaultSendRequiredGasLimit = 250000
+
+// SendOptions are the optional params of an ICTT send: the fee offered to relayers,
+// paid in [PrimaryFeeTokenAddress], and the gas limit required to process the send
+// at the destination.
+// Sends from a remote to another remote are routed through the home (multi-hop). On those,
+// [SecondaryFee] is the fee offered to relayers for the hop from the home, paid in the
+// transferred token, and [MultiHopFallback] receives the tokens on the home if that hop fails
+type SendOptions struct {
+	PrimaryFeeTokenAddress common.Address
+	PrimaryFee             *big.Int
+	RequiredGasLimit       *big.Int
+	SecondaryFee           *big.Int
+	MultiHopFallback       common.Address
+}
+
+func (o SendOptions) primaryFee() *big.Int {
+	if o.PrimaryFee == nil {
+		return big.NewInt(0)
+	}
+	return o.PrimaryFee
+}
+
+func (o SendOptions) secondaryFee() *big.Int {
+	if o.SecondaryFee == nil {
+		return big.NewInt(0)
+	}
+	return o.SecondaryFee
+}
+
+func (o SendOptions) primaryFeeTokenAddress(defaultAddress common.Address) common.Address {
+	if o.PrimaryFeeTokenAddress == (common.Address{}) {
+		return defaultAddress
+	}
+	return o.PrimaryFeeTokenAddress
+}
+
+func (o SendOptions) requiredGasLimit() *big.Int {
+	if o.RequiredGasLimit == nil {
+		return big.NewInt(defaultSendRequiredGasLimit)
+	}
+	return o.RequiredGasLimit
+}
+
+// approves [endpointAddress] to take [amount] of [tokenAddress] (if not native) and
+// the primary fee of [options] from the sender
+func approveSend(
+	rpcURL string,
+	privateKey string,
+	endpointAddress common.Address,
+	tokenAddress common.Address,
+	amount *big.Int,
+	options SendOptions,
+) error {
+	approvals := map[common.Address]*big.Int{}
+	if tokenAddress != (common.Address{}) {
+		approvals[tokenAddress] = new(big.Int).Set(amount)
+	}
+	if options.primaryFee().Sign() > 0 {
+		feeTokenAddress := options.primaryFeeTokenAddress(tokenAddress)
+		if feeTokenAddress == (common.Address{}) {
+			return fmt.Errorf("a fee token address is needed to pay the primary fee")
+		}
+		if approval, ok := approvals[feeTokenAddress]; ok {
+			approval.Add(approval, options.primaryFee())
+		} else {
+			approvals[feeTokenAddress] = options.primaryFee()
+		}
+	}
+	for approvedToken, approval := range approvals {
+		if _, _, err := contract.TxToMethod(
+			rpcURL,
+			privateKey,
+			approvedToken,
+			nil,
+			"approve(address, uint256)->(bool)",
+			endpointAddress,
+			approval,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func GetEndpointKind(
 	rpcURL string,
 	address common.Address,
@@ -164,6 +250,25 @@ func ERC20TokenRemoteGetTokenHomeAddress(
 	return tokenHubAddress, nil
 }
 
+func TokenRemoteGetTokenHomeBlockchainID(
+	rpcURL string,
+	address common.Address,
+) (ids.ID, error) {
+	out, err := contract.CallToMethod(
+		rpcURL,
+		address,
+		"tokenHomeBlockchainID()->(bytes32)",
+	)
+	if err != nil {
+		return ids.Empty, err
+	}
+	blockchainID, b := out[0].([32]byte)
+	if !b {
+		return ids.Empty, fmt.Errorf("error at tokenHomeBlockchainID call, expected [32]byte, got %T", out[0])
+	}
+	return blockchainID, nil
+}
+
 func NativeTokenRemoteGetTotalNativeAssetSupply(
 	rpcURL string,
 	address common.Address,
@@ -191,7 +296,8 @@ func ERC20TokenHomeSend(
 	destinationICTTEndpoint common.Address,
 	amountRecipient common.Address,
 	amount *big.Int,
-) error {
+	options SendOptions,
+) (*types.Receipt, error) {
 	type Params struct {
 		DestinationBlockchainID [32]byte
 		DestinationICTTEndpoint common.Address
@@ -204,30 +310,22 @@ func ERC20TokenHomeSend(
 	}
 	tokenAddress, err := ERC20TokenHomeGetTokenAddress(rpcURL, homeAddress)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if _, _, err := contract.TxToMethod(
-		rpcURL,
-		privateKey,
-		tokenAddress,
-		nil,
-		"approve(address, uint256)->(bool)",
-		homeAddress,
-		amount,
-	); err != nil {
-		return err
+	if err := approveSend(rpcURL, privateKey, homeAddress, tokenAddress, amount, options); err != nil {
+		return nil, err
 	}
 	params := Params{
 		DestinationBlockchainID: destinationBlockchainID,
 		DestinationICTTEndpoint: destinationICTTEndpoint,
 		AmountRecipient:         amountRecipient,
-		PrimaryFeeTokenAddress:  tokenAddress, // in theory this is optional
-		PrimaryFee:              big.NewInt(0),
-		SecondaryFee:            big.NewInt(0),
-		RequiredGasLimit:        big.NewInt(250000),
-		MultiHopFallback:        common.Address{},
+		PrimaryFeeTokenAddress:  options.primaryFeeTokenAddress(tokenAddress), // in theory this is optional
+		PrimaryFee:              options.primaryFee(),
+		SecondaryFee:            options.secondaryFee(),
+		RequiredGasLimit:        options.requiredGasLimit(),
+		MultiHopFallback:        options.MultiHopFallback,
 	}
-	_, _, err = contract.TxToMethod(
+	_, receipt, err := contract.TxToMethod(
 		rpcURL,
 		privateKey,
 		homeAddress,
@@ -236,7 +334,7 @@ func ERC20TokenHomeSend(
 		params,
 		amount,
 	)
-	return err
+	return receipt, err
 }
 
 func NativeTokenHomeSend(
@@ -247,7 +345,8 @@ func NativeTokenHomeSend(
 	destinationICTTEndpoint common.Address,
 	amountRecipient common.Address,
 	amount *big.Int,
-) error {
+	options SendOptions,
+) (*types.Receipt, error) {
 	type Params struct {
 		DestinationBlockchainID [32]byte
 		DestinationICTTEndpoint common.Address
@@ -260,19 +359,27 @@ func NativeTokenHomeSend(
 	}
 	tokenAddress, err := NativeTokenHomeGetTokenAddress(rpcURL, homeAddress)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if options.primaryFee().Sign() > 0 {
+		if err := approveSend(rpcURL, privateKey, homeAddress, common.Address{}, amount, SendOptions{
+			PrimaryFeeTokenAddress: options.primaryFeeTokenAddress(tokenAddress),
+			PrimaryFee:             options.PrimaryFee,
+		}); err != nil {
+			return nil, err
+		}
 	}
 	params := Params{
 		DestinationBlockchainID: destinationBlockchainID,
 		DestinationICTTEndpoint: destinationICTTEndpoint,
 		AmountRecipient:         amountRecipient,
-		PrimaryFeeTokenAddress:  tokenAddress, // in theory this is optional
-		PrimaryFee:              big.NewInt(0),
-		SecondaryFee:            big.NewInt(0),
-		RequiredGasLimit:        big.NewInt(250000),
-		MultiHopFallback:        common.Address{},
+		PrimaryFeeTokenAddress:  options.primaryFeeTokenAddress(tokenAddress), // in theory this is optional
+		PrimaryFee:              options.primaryFee(),
+		SecondaryFee:            options.secondaryFee(),
+		RequiredGasLimit:        options.requiredGasLimit(),
+		MultiHopFallback:        options.MultiHopFallback,
 	}
-	_, _, err = contract.TxToMethod(
+	_, receipt, err := contract.TxToMethod(
 		rpcURL,
 		privateKey,
 		homeAddress,
@@ -280,7 +387,7 @@ func NativeTokenHomeSend(
 		"send((bytes32, address, address, address, uint256, uint256, uint256, address))",
 		params,
 	)
-	return err
+	return receipt, err
 }
 
 func ERC20TokenRemoteSend(
@@ -291,17 +398,10 @@ func ERC20TokenRemoteSend(
 	destinationICTTEndpoint common.Address,
 	amountRecipient common.Address,
 	amount *big.Int,
-) error {
-	if _, _, err := contract.TxToMethod(
-		rpcURL,
-		privateKey,
-		remoteAddress,
-		nil,
-		"approve(address, uint256)->(bool)",
-		remoteAddress,
-		amount,
-	); err != nil {
-		return err
+	options SendOptions,
+) (*types.Receipt, error) {
+	if err := approveSend(rpcURL, privateKey, remoteAddress, remoteAddress, amount, options); err != nil {
+		return nil, err
 	}
 	type Params struct {
 		DestinationBlockchainID [32]byte
@@ -317,13 +417,13 @@ func ERC20TokenRemoteSend(
 		DestinationBlockchainID: destinationBlockchainID,
 		DestinationICTTEndpoint: destinationICTTEndpoint,
 		AmountRecipient:         amountRecipient,
-		PrimaryFeeTokenAddress:  common.Address{},
-		PrimaryFee:              big.NewInt(0),
-		SecondaryFee:            big.NewInt(0),
-		RequiredGasLimit:        big.NewInt(250000),
-		MultiHopFallback:        common.Address{},
+		PrimaryFeeTokenAddress:  options.primaryFeeTokenAddress(remoteAddress),
+		PrimaryFee:              options.primaryFee(),
+		SecondaryFee:            options.secondaryFee(),
+		RequiredGasLimit:        options.requiredGasLimit(),
+		MultiHopFallback:        options.MultiHopFallback,
 	}
-	_, _, err := contract.TxToMethod(
+	_, receipt, err := contract.TxToMethod(
 		rpcURL,
 		privateKey,
 		remoteAddress,
@@ -332,7 +432,7 @@ func ERC20TokenRemoteSend(
 		params,
 		amount,
 	)
-	return err
+	return receipt, err
 }
 
 func NativeTokenRemoteSend(
@@ -343,7 +443,8 @@ func NativeTokenRemoteSend(
 	destinationICTTEndpoint common.Address,
 	amountRecipient common.Address,
 	amount *big.Int,
-) error {
+	options SendOptions,
+) (*types.Receipt, error) {
 	type Params struct {
 		DestinationBlockchainID [32]byte
 		DestinationICTTEndpoint common.Address
@@ -354,17 +455,25 @@ func NativeTokenRemoteSend(
 		RequiredGasLimit        *big.Int
 		MultiHopFallback        common.Address
 	}
+	if options.primaryFee().Sign() > 0 {
+		if err := approveSend(rpcURL, privateKey, remoteAddress, common.Address{}, amount, SendOptions{
+			PrimaryFeeTokenAddress: options.primaryFeeTokenAddress(remoteAddress),
+			PrimaryFee:             options.PrimaryFee,
+		}); err != nil {
+			return nil, err
+		}
+	}
 	params := Params{
 		DestinationBlockchainID: destinationBlockchainID,
 		DestinationICTTEndpoint: destinationICTTEndpoint,
 		AmountRecipient:         amountRecipient,
-		PrimaryFeeTokenAddress:  remoteAddress, // in theory this is optional
-		PrimaryFee:              big.NewInt(0),
-		SecondaryFee:            big.NewInt(0),
-		RequiredGasLimit:        big.NewInt(250000),
-		MultiHopFallback:        common.Address{},
+		PrimaryFeeTokenAddress:  options.primaryFeeTokenAddress(remoteAddress), // in theory this is optional
+		PrimaryFee:              options.primaryFee(),
+		SecondaryFee:            options.secondaryFee(),
+		RequiredGasLimit:        options.requiredGasLimit(),
+		MultiHopFallback:        options.MultiHopFallback,
 	}
-	_, _, err := contract.TxToMethod(
+	_, receipt, err := contract.TxToMethod(
 		rpcURL,
 		privateKey,
 		remoteAddress,
@@ -372,7 +481,7 @@ func NativeTokenRemoteSend(
 		"send((bytes32, address, address, address, uint256, uint256, uint256, address))",
 		params,
 	)
-	return err
+	return receipt, err
 }
 
 func NativeTokenHomeAddCollateral(
@@ -481,13 +590,14 @@ func Send(
 	destinationAddress common.Address,
 	amountRecipient common.Address,
 	amount *big.Int,
-) error {
+	options SendOptions,
+) (*types.Receipt, error) {
 	endpointKind, err := GetEndpointKind(
 		rpcURL,
 		address,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	switch endpointKind {
 	case ERC20TokenRemote:
@@ -499,6 +609,7 @@ func Send(
 			destinationAddress,
 			amountRecipient,
 			amount,
+			options,
 		)
 	case ERC20TokenHome:
 		return ERC20TokenHomeSend(
@@ -509,6 +620,7 @@ func Send(
 			destinationAddress,
 			amountRecipient,
 			amount,
+			options,
 		)
 	case NativeTokenHome:
 		return NativeTokenHomeSend(
@@ -519,6 +631,7 @@ func Send(
 			destinationAddress,
 			amountRecipient,
 			amount,
+			options,
 		)
 	case NativeTokenRemote:
 		return NativeTokenRemoteSend(
@@ -529,7 +642,122 @@ func Send(
 			destinationAddress,
 			amountRecipient,
 			amount,
+			options,
 		)
 	}
-	return fmt.Errorf("unknown ictt endpoint")
+	return nil, fmt.Errorf("unknown ictt endpoint")
+}
+
+// CallOptions are the params of an ICTT sendAndCall specific to the call made at the
+// destination: [RecipientContract] is called with [RecipientPayload] and the transferred
+// tokens, with a gas limit of [RecipientGasLimit]. If the call fails, the tokens are sent
+// to [FallbackRecipient]
+type CallOptions struct {
+	RecipientContract common.Address
+	RecipientPayload  []byte
+	RecipientGasLimit *big.Int
+	FallbackRecipient common.Address
+}
+
+type sendAndCallParams struct {
+	DestinationBlockchainID            [32]byte
+	DestinationTokenTransferrerAddress common.Address
+	RecipientContract                  common.Address
+	RecipientPayload                   []byte
+	RequiredGasLimit                   *big.Int
+	RecipientGasLimit                  *big.Int
+	MultiHopFallback                   common.Address
+	FallbackRecipient                  common.Address
+	PrimaryFeeTokenAddress             common.Address
+	PrimaryFee                         *big.Int
+	SecondaryFee                       *big.Int
+}
+
+const sendAndCallParamsEsp = "(bytes32, address, address, bytes, uint256, uint256, address, address, address, uint256, uint256)"
+
+// SendAndCall sends [amount] from the Home or Remote at [address] to the Home or Remote
+// [destinationAddress] at [destinationBlockchainID], where it is transferred to the
+// recipient contract of [callOptions] together with a call to it
+func SendAndCall(
+	rpcURL string,
+	address common.Address,
+	privateKey string,
+	destinationBlockchainID ids.ID,
+	destinationAddress common.Address,
+	amount *big.Int,
+	callOptions CallOptions,
+	options SendOptions,
+) (*types.Receipt, error) {
+	if callOptions.RecipientGasLimit == nil {
+		return nil, fmt.Errorf("a recipient gas limit is needed for sendAndCall")
+	}
+	if options.requiredGasLimit().Cmp(callOptions.RecipientGasLimit) <= 0 {
+		return nil, fmt.Errorf("the required gas limit %s must be greater than the recipient gas limit %s", options.requiredGasLimit(), callOptions.RecipientGasLimit)
+	}
+	endpointKind, err := GetEndpointKind(
+		rpcURL,
+		address,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// ERC20 remotes are the token themselves
+	tokenAddress := address
+	switch endpointKind {
+	case ERC20TokenHome:
+		tokenAddress, err = ERC20TokenHomeGetTokenAddress(rpcURL, address)
+	case NativeTokenHome:
+		tokenAddress, err = NativeTokenHomeGetTokenAddress(rpcURL, address)
+	case ERC20TokenRemote, NativeTokenRemote:
+	default:
+		return nil, fmt.Errorf("unknown ictt endpoint")
+	}
+	if err != nil {
+		return nil, err
+	}
+	native := endpointKind == NativeTokenHome || endpointKind == NativeTokenRemote
+	approvedToken := tokenAddress
+	if native {
+		approvedToken = common.Address{}
+	}
+	if err := approveSend(rpcURL, privateKey, address, approvedToken, amount, SendOptions{
+		PrimaryFeeTokenAddress: options.primaryFeeTokenAddress(tokenAddress),
+		PrimaryFee:             options.PrimaryFee,
+	}); err != nil {
+		return nil, err
+	}
+	params := sendAndCallParams{
+		DestinationBlockchainID:            destinationBlockchainID,
+		DestinationTokenTransferrerAddress: destinationAddress,
+		RecipientContract:                  callOptions.RecipientContract,
+		RecipientPayload:                   callOptions.RecipientPayload,
+		RequiredGasLimit:                   options.requiredGasLimit(),
+		RecipientGasLimit:                  callOptions.RecipientGasLimit,
+		MultiHopFallback:                   options.MultiHopFallback,
+		FallbackRecipient:                  callOptions.FallbackRecipient,
+		PrimaryFeeTokenAddress:             options.primaryFeeTokenAddress(tokenAddress),
+		PrimaryFee:                         options.primaryFee(),
+		SecondaryFee:                       options.secondaryFee(),
+	}
+	if native {
+		_, receipt, err := contract.TxToMethod(
+			rpcURL,
+			privateKey,
+			address,
+			amount,
+			"sendAndCall("+sendAndCallParamsEsp+")",
+			params,
+		)
+		return receipt, err
+	}
+	_, receipt, err := contract.TxToMethod(
+		rpcURL,
+		privateKey,
+		address,
+		nil,
+		"sendAndCall("+sendAndCallParamsEsp+", uint256)",
+		params,
+		amount,
+	)
+	return receipt, err
 }
@@ -0,0 +1,34 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package ictt
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackSendAndCall(t *testing.T) {
+	require := require.New(t)
+	params := sendAndCallParams{
+		DestinationTokenTransferrerAddress: common.HexToAddress("0x01"),
+		RecipientContract:                  common.HexToAddress("0x02"),
+		RecipientPayload:                   []byte{1, 2, 3},
+		RequiredGasLimit:                   big.NewInt(250000),
+		RecipientGasLimit:                  big.NewInt(100000),
+		PrimaryFee:                         big.NewInt(0),
+		SecondaryFee:                       big.NewInt(0),
+	}
+	data, err := contract.PackMethodCall("sendAndCall("+sendAndCallParamsEsp+", uint256)", params, big.NewInt(10))
+	require.NoError(err)
+	selector := crypto.Keccak256([]byte("sendAndCall((bytes32,address,address,bytes,uint256,uint256,address,address,address,uint256,uint256),uint256)"))[:4]
+	require.Equal(selector, data[:4])
+	data, err = contract.PackMethodCall("sendAndCall("+sendAndCallParamsEsp+")", params)
+	require.NoError(err)
+	selector = crypto.Keccak256([]byte("sendAndCall((bytes32,address,address,bytes,uint256,uint256,address,address,address,uint256,uint256))"))[:4]
+	require.Equal(selector, data[:4])
+}
@@ -0,0 +1,163 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package ictt
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func (k EndpointKind) String() string {
+	switch k {
+	case ERC20TokenHome:
+		return "ERC20 Home"
+	case NativeTokenHome:
+		return "Native Home"
+	case ERC20TokenRemote:
+		return "ERC20 Remote"
+	case NativeTokenRemote:
+		return "Native Remote"
+	}
+	return "Undefined"
+}
+
+// loads the Token Transferrers deployed by CLI, on every network
+func loadDeployments(app *application.Avalanche) ([]models.ICTTDeployment, error) {
+	registryPath := app.GetICTTRegistryPath()
+	if !utils.FileExists(registryPath) {
+		return []models.ICTTDeployment{}, nil
+	}
+	bs, err := os.ReadFile(registryPath)
+	if err != nil {
+		return nil, err
+	}
+	deployments := []models.ICTTDeployment{}
+	if err := json.Unmarshal(bs, &deployments); err != nil {
+		return nil, fmt.Errorf("invalid token transferrers registry %s: %w", registryPath, err)
+	}
+	return deployments, nil
+}
+
+func saveDeployments(app *application.Avalanche, deployments []models.ICTTDeployment) error {
+	registryPath := app.GetICTTRegistryPath()
+	if err := os.MkdirAll(filepath.Dir(registryPath), constants.DefaultPerms755); err != nil {
+		return err
+	}
+	bs, err := json.MarshalIndent(deployments, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(registryPath, bs, constants.WriteReadReadPerms)
+}
+
+// GetDeployments returns the Token Transferrers deployed by CLI on [network]
+func GetDeployments(
+	app *application.Avalanche,
+	network models.Network,
+) ([]models.ICTTDeployment, error) {
+	deployments, err := loadDeployments(app)
+	if err != nil {
+		return nil, err
+	}
+	return utils.Filter(deployments, func(d models.ICTTDeployment) bool {
+		return d.Network == network.Name()
+	}), nil
+}
+
+// GetDeployment looks for the Token Transferrer named [name] on [network]
+func GetDeployment(
+	app *application.Avalanche,
+	network models.Network,
+	name string,
+) (models.ICTTDeployment, error) {
+	deployments, err := GetDeployments(app, network)
+	if err != nil {
+		return models.ICTTDeployment{}, err
+	}
+	for _, deployment := range deployments {
+		if strings.EqualFold(deployment.Name, name) {
+			return deployment, nil
+		}
+	}
+	return models.ICTTDeployment{}, fmt.Errorf("token transferrer %q not found on %s", name, network.Name())
+}
+
+// GetDeploymentEndpointAddress returns the address of the Home or Remote of the Token
+// Transferrer named [name] on [blockchainID]
+func GetDeploymentEndpointAddress(
+	app *application.Avalanche,
+	network models.Network,
+	name string,
+	blockchainID ids.ID,
+) (common.Address, error) {
+	deployment, err := GetDeployment(app, network, name)
+	if err != nil {
+		return common.Address{}, err
+	}
+	endpoint, ok := deployment.GetEndpoint(blockchainID)
+	if !ok {
+		return common.Address{}, fmt.Errorf("token transferrer %q has no endpoint on blockchain %s", name, blockchainID)
+	}
+	return common.HexToAddress(endpoint.Address), nil
+}
+
+// RecordDeployment saves [deployment] into the registry of [network]. If a deployment with the
+// same home already exists, the remotes of [deployment] are added to it, keeping its name.
+// Otherwise the deployment is recorded with its name, or with the token symbol if empty,
+// made unique among the deployments of [network]. Returns the recorded deployment
+func RecordDeployment(
+	app *application.Avalanche,
+	network models.Network,
+	deployment models.ICTTDeployment,
+) (models.ICTTDeployment, error) {
+	deployments, err := loadDeployments(app)
+	if err != nil {
+		return models.ICTTDeployment{}, err
+	}
+	deployment.Network = network.Name()
+	for i, d := range deployments {
+		if d.Network != deployment.Network ||
+			d.Home.BlockchainID != deployment.Home.BlockchainID ||
+			common.HexToAddress(d.Home.Address) != common.HexToAddress(deployment.Home.Address) {
+			continue
+		}
+		if deployment.Name != "" && !strings.EqualFold(deployment.Name, d.Name) {
+			return models.ICTTDeployment{}, fmt.Errorf("the home of token transferrer %q is already registered as %q", deployment.Name, d.Name)
+		}
+		for _, remote := range deployment.Remotes {
+			d.Remotes = utils.Filter(d.Remotes, func(r models.ICTTEndpoint) bool {
+				return r.BlockchainID != remote.BlockchainID || common.HexToAddress(r.Address) != common.HexToAddress(remote.Address)
+			})
+			d.Remotes = append(d.Remotes, remote)
+		}
+		deployments[i] = d
+		return d, saveDeployments(app, deployments)
+	}
+	nameTaken := func(name string) bool {
+		return utils.Any(deployments, func(d models.ICTTDeployment) bool {
+			return d.Network == deployment.Network && strings.EqualFold(d.Name, name)
+		})
+	}
+	if deployment.Name != "" {
+		if nameTaken(deployment.Name) {
+			return models.ICTTDeployment{}, fmt.Errorf("there already is a token transferrer named %q on %s", deployment.Name, network.Name())
+		}
+	} else {
+		deployment.Name = deployment.TokenSymbol
+		for i := 2; nameTaken(deployment.Name); i++ {
+			deployment.Name = fmt.Sprintf("%s-%d", deployment.TokenSymbol, i)
+		}
+	}
+	deployments = append(deployments, deployment)
+	return deployment, saveDeployments(app, deployments)
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package ictt
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDeployment(symbol string, homeAddress string) models.ICTTDeployment {
+	return models.ICTTDeployment{
+		TokenSymbol: symbol,
+		Home: models.ICTTEndpoint{
+			Blockchain:   "c-chain",
+			BlockchainID: ids.ID{1},
+			Address:      homeAddress,
+			Kind:         ERC20TokenHome.String(),
+		},
+		Remotes: []models.ICTTEndpoint{
+			{
+				Blockchain:   "subnet1",
+				BlockchainID: ids.ID{2},
+				Address:      "0x0000000000000000000000000000000000000002",
+				Kind:         ERC20TokenRemote.String(),
+			},
+		},
+	}
+}
+
+func TestRecordDeployment(t *testing.T) {
+	require := require.New(t)
+	app := application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, nil, nil)
+	network := models.NewLocalNetwork()
+
+	deployment, err := RecordDeployment(app, network, newTestDeployment("TOK", "0x0000000000000000000000000000000000000001"))
+	require.NoError(err)
+	require.Equal("TOK", deployment.Name)
+	require.Equal(network.Name(), deployment.Network)
+
+	// same token symbol on another home gets a unique name
+	deployment, err = RecordDeployment(app, network, newTestDeployment("TOK", "0x0000000000000000000000000000000000000003"))
+	require.NoError(err)
+	require.Equal("TOK-2", deployment.Name)
+
+	// explicit names must be unique
+	d := newTestDeployment("OTHER", "0x0000000000000000000000000000000000000004")
+	d.Name = "tok"
+	_, err = RecordDeployment(app, network, d)
+	require.Error(err)
+
+	// a new remote for an existing home is added to it
+	d = newTestDeployment("TOK", "0x0000000000000000000000000000000000000001")
+	d.Remotes[0].Blockchain = "subnet2"
+	d.Remotes[0].BlockchainID = ids.ID{3}
+	deployment, err = RecordDeployment(app, network, d)
+	require.NoError(err)
+	require.Equal("TOK", deployment.Name)
+	require.Len(deployment.Remotes, 2)
+
+	deployments, err := GetDeployments(app, network)
+	require.NoError(err)
+	require.Len(deployments, 2)
+	deployments, err = GetDeployments(app, models.NewFujiNetwork())
+	require.NoError(err)
+	require.Empty(deployments)
+
+	address, err := GetDeploymentEndpointAddress(app, network, "tok", ids.ID{3})
+	require.NoError(err)
+	require.Equal("0x0000000000000000000000000000000000000002", address.Hex())
+	_, err = GetDeploymentEndpointAddress(app, network, "tok", ids.ID{4})
+	require.Error(err)
+	_, err = GetDeployment(app, network, "unknown")
+	require.Error(err)
+}
@@ -0,0 +1,311 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package ictt
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const remoteRegisteredEventEsp = "RemoteRegistered(bytes32,address,uint256,uint8)"
+
+// TokenHomeRemoteRegistered is the event emitted by a Token Home when it registers a remote
+type TokenHomeRemoteRegistered struct {
+	RemoteBlockchainID            [32]byte
+	RemoteTokenTransferrerAddress common.Address
+	InitialCollateralNeeded       *big.Int
+	TokenDecimals                 uint8
+}
+
+// RemoteStatus is the live state of a remote registered on a Token Home
+type RemoteStatus struct {
+	BlockchainID ids.ID
+	Address      common.Address
+	Kind         EndpointKind
+	// decimals of the remote token, as registered on the home
+	TokenDecimals uint8
+	RegisteredRemote
+	// the remote considers itself collateralized
+	Collateralized bool
+	// amount sent to the remote and not yet sent back, as accounted by the home, in home units
+	TransferredBalance *big.Int
+	// supply minted by the remote from transfers, in remote units. nil if the remote could not be queried
+	BridgedSupply *big.Int
+	Issues        []string
+}
+
+// HomeStatus is the live state of a Token Home and of its registered remotes
+type HomeStatus struct {
+	Address       common.Address
+	Kind          EndpointKind
+	TokenAddress  common.Address
+	TokenSymbol   string
+	TokenDecimals uint8
+	// token balance held by the home, backing the transfers and the remotes collateral
+	LockedBalance *big.Int
+	Remotes       []*RemoteStatus
+	Issues        []string
+}
+
+// ParseRemoteRegistered decodes a RemoteRegistered event log
+func ParseRemoteRegistered(log types.Log) (*TokenHomeRemoteRegistered, error) {
+	event := new(TokenHomeRemoteRegistered)
+	if err := contract.UnpackLog(
+		remoteRegisteredEventEsp,
+		[]int{0, 1},
+		log,
+		event,
+	); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ScaleToHome converts [amount] from remote token units to home token units, given
+// the registration [multiplier] of the remote
+func ScaleToHome(amount *big.Int, multiplier *big.Int, multiplyOnRemote bool) *big.Int {
+	if multiplier == nil || multiplier.Sign() == 0 {
+		return new(big.Int).Set(amount)
+	}
+	if multiplyOnRemote {
+		return new(big.Int).Div(amount, multiplier)
+	}
+	return new(big.Int).Mul(amount, multiplier)
+}
+
+// sets the issues found on each remote of [home], and on [home] as a whole
+func setStatusIssues(home *HomeStatus) {
+	totalTransferred := big.NewInt(0)
+	for _, remote := range home.Remotes {
+		remote.Issues = nil
+		if !remote.Registered {
+			remote.Issues = append(remote.Issues, "registration is not yet received by the home")
+			continue
+		}
+		totalTransferred.Add(totalTransferred, remote.TransferredBalance)
+		if remote.CollateralNeeded.Sign() > 0 {
+			remote.Issues = append(remote.Issues, fmt.Sprintf("needs %s more collateral on the home", remote.CollateralNeeded))
+		}
+		if remote.BridgedSupply == nil {
+			remote.Issues = append(remote.Issues, "bridged supply could not be obtained from the remote")
+			continue
+		}
+		if remote.CollateralNeeded.Sign() == 0 && !remote.Collateralized {
+			remote.Issues = append(remote.Issues, "collateralized on the home, but the remote is not yet aware of it")
+		}
+		bridgedSupply := ScaleToHome(remote.BridgedSupply, remote.TokenMultiplier, remote.MultiplyOnRemote)
+		if bridgedSupply.Cmp(remote.TransferredBalance) != 0 {
+			remote.Issues = append(remote.Issues, fmt.Sprintf(
+				"bridged supply %s (home units) differs from the %s transferred by the home (messages may be in flight)",
+				bridgedSupply,
+				remote.TransferredBalance,
+			))
+		}
+	}
+	home.Issues = nil
+	if home.LockedBalance.Cmp(totalTransferred) < 0 {
+		home.Issues = append(home.Issues, fmt.Sprintf(
+			"locked balance %s is lower than the %s transferred to the remotes",
+			home.LockedBalance,
+			totalTransferred,
+		))
+	}
+}
+
+// GetRegisteredRemotes looks for the remotes that asked to be registered on the Token Home at [homeAddress]
+func GetRegisteredRemotes(
+	rpcURL string,
+	homeAddress common.Address,
+) ([]*TokenHomeRemoteRegistered, error) {
+	topic, err := contract.GetEventTopic(remoteRegisteredEventEsp, []int{0, 1})
+	if err != nil {
+		return nil, err
+	}
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	toBlock, err := evm.GetBlockNumber(client)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := evm.GetLogsInRange(
+		client,
+		interfaces.FilterQuery{
+			Addresses: []common.Address{homeAddress},
+			Topics:    [][]common.Hash{{topic}},
+		},
+		0,
+		toBlock,
+	)
+	if err != nil {
+		return nil, err
+	}
+	remotes := []*TokenHomeRemoteRegistered{}
+	for _, log := range logs {
+		event, err := ParseRemoteRegistered(log)
+		if err != nil {
+			return nil, err
+		}
+		remotes = append(remotes, event)
+	}
+	return remotes, nil
+}
+
+func TokenHomeGetTransferredBalance(
+	rpcURL string,
+	address common.Address,
+	remoteBlockchainID [32]byte,
+	remoteAddress common.Address,
+) (*big.Int, error) {
+	out, err := contract.CallToMethod(
+		rpcURL,
+		address,
+		"transferredBalances(bytes32, address)->(uint256)",
+		remoteBlockchainID,
+		remoteAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+	balance, b := out[0].(*big.Int)
+	if !b {
+		return nil, fmt.Errorf("error at transferredBalances call, expected *big.Int, got %T", out[0])
+	}
+	return balance, nil
+}
+
+func NativeTokenRemoteGetInitialReserveImbalance(
+	rpcURL string,
+	address common.Address,
+) (*big.Int, error) {
+	out, err := contract.CallToMethod(
+		rpcURL,
+		address,
+		"initialReserveImbalance()->(uint256)",
+	)
+	if err != nil {
+		return nil, err
+	}
+	imbalance, b := out[0].(*big.Int)
+	if !b {
+		return nil, fmt.Errorf("error at initialReserveImbalance call, expected *big.Int, got %T", out[0])
+	}
+	return imbalance, nil
+}
+
+func getERC20Amount(
+	rpcURL string,
+	tokenAddress common.Address,
+	methodEsp string,
+	params ...interface{},
+) (*big.Int, error) {
+	out, err := contract.CallToMethod(
+		rpcURL,
+		tokenAddress,
+		methodEsp,
+		params...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	amount, b := out[0].(*big.Int)
+	if !b {
+		return nil, fmt.Errorf("error at %s call, expected *big.Int, got %T", methodEsp, out[0])
+	}
+	return amount, nil
+}
+
+// gets the supply minted by the remote at [address] from transfers, in remote units
+func getRemoteBridgedSupply(
+	rpcURL string,
+	address common.Address,
+) (EndpointKind, *big.Int, error) {
+	if supply, err := NativeTokenRemoteGetTotalNativeAssetSupply(rpcURL, address); err == nil {
+		// the initial reserve imbalance is minted on genesis and is backed by collateral
+		imbalance, err := NativeTokenRemoteGetInitialReserveImbalance(rpcURL, address)
+		if err != nil {
+			return NativeTokenRemote, nil, err
+		}
+		return NativeTokenRemote, new(big.Int).Sub(supply, imbalance), nil
+	}
+	// ERC20 remotes are the token themselves
+	supply, err := getERC20Amount(rpcURL, address, "totalSupply()->(uint256)")
+	if err != nil {
+		return Undefined, nil, err
+	}
+	return ERC20TokenRemote, supply, nil
+}
+
+// GetHomeStatus gathers the live state of the Token Home at [homeAddress], and of every remote
+// registered on it, flagging inconsistencies. Remotes are accessed at the endpoints given
+// by [getRemoteRPCURL]
+func GetHomeStatus(
+	homeRPCURL string,
+	homeAddress common.Address,
+	getRemoteRPCURL func(blockchainID ids.ID) string,
+) (*HomeStatus, error) {
+	kind, err := GetEndpointKind(homeRPCURL, homeAddress)
+	if err != nil {
+		return nil, err
+	}
+	status := HomeStatus{
+		Address: homeAddress,
+		Kind:    kind,
+	}
+	switch kind {
+	case ERC20TokenHome:
+		status.TokenAddress, err = ERC20TokenHomeGetTokenAddress(homeRPCURL, homeAddress)
+	case NativeTokenHome:
+		status.TokenAddress, err = NativeTokenHomeGetTokenAddress(homeRPCURL, homeAddress)
+	default:
+		return nil, fmt.Errorf("%s is not a token home, but a %s", homeAddress, kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	status.TokenSymbol, _, status.TokenDecimals, err = GetTokenParams(homeRPCURL, status.TokenAddress.Hex())
+	if err != nil {
+		return nil, err
+	}
+	status.LockedBalance, err = getERC20Amount(homeRPCURL, status.TokenAddress, "balanceOf(address)->(uint256)", homeAddress)
+	if err != nil {
+		return nil, err
+	}
+	registrations, err := GetRegisteredRemotes(homeRPCURL, homeAddress)
+	if err != nil {
+		return nil, err
+	}
+	for _, registration := range registrations {
+		remote := RemoteStatus{
+			BlockchainID:  ids.ID(registration.RemoteBlockchainID),
+			Address:       registration.RemoteTokenTransferrerAddress,
+			TokenDecimals: registration.TokenDecimals,
+		}
+		remote.RegisteredRemote, err = TokenHomeGetRegisteredRemote(homeRPCURL, homeAddress, remote.BlockchainID, remote.Address)
+		if err != nil {
+			return nil, err
+		}
+		remote.TransferredBalance, err = TokenHomeGetTransferredBalance(homeRPCURL, homeAddress, remote.BlockchainID, remote.Address)
+		if err != nil {
+			return nil, err
+		}
+		// unreachable remotes are reported as issues, not failing the whole status
+		remoteRPCURL := getRemoteRPCURL(remote.BlockchainID)
+		remote.Kind, remote.BridgedSupply, err = getRemoteBridgedSupply(remoteRPCURL, remote.Address)
+		if err == nil {
+			remote.Collateralized, _ = TokenRemoteIsCollateralized(remoteRPCURL, remote.Address)
+		}
+		status.Remotes = append(status.Remotes, &remote)
+	}
+	setStatusIssues(&status)
+	return &status, nil
+}
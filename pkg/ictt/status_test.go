@@ -0,0 +1,73 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package ictt
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaleToHome(t *testing.T) {
+	require := require.New(t)
+	require.Equal(big.NewInt(5), ScaleToHome(big.NewInt(5), big.NewInt(1), true))
+	require.Equal(big.NewInt(5), ScaleToHome(big.NewInt(5000), big.NewInt(1000), true))
+	require.Equal(big.NewInt(5000), ScaleToHome(big.NewInt(5), big.NewInt(1000), false))
+	require.Equal(big.NewInt(5), ScaleToHome(big.NewInt(5), nil, false))
+}
+
+func TestSetStatusIssues(t *testing.T) {
+	require := require.New(t)
+	consistent := func() *RemoteStatus {
+		return &RemoteStatus{
+			RegisteredRemote: RegisteredRemote{
+				Registered:       true,
+				CollateralNeeded: big.NewInt(0),
+				TokenMultiplier:  big.NewInt(1000),
+				MultiplyOnRemote: true,
+			},
+			Collateralized:     true,
+			TransferredBalance: big.NewInt(10),
+			BridgedSupply:      big.NewInt(10000),
+		}
+	}
+	home := &HomeStatus{
+		LockedBalance: big.NewInt(20),
+		Remotes:       []*RemoteStatus{consistent(), consistent()},
+	}
+	setStatusIssues(home)
+	require.Empty(home.Issues)
+	for _, remote := range home.Remotes {
+		require.Empty(remote.Issues)
+	}
+
+	// not enough locked for the transfers
+	home.LockedBalance = big.NewInt(19)
+	setStatusIssues(home)
+	require.Len(home.Issues, 1)
+
+	// unregistered remotes do not count as transferred
+	home.Remotes[1].Registered = false
+	setStatusIssues(home)
+	require.Empty(home.Issues)
+	require.Len(home.Remotes[1].Issues, 1)
+
+	// collateral pending
+	home.Remotes[0].CollateralNeeded = big.NewInt(3)
+	home.Remotes[0].Collateralized = false
+	setStatusIssues(home)
+	require.Len(home.Remotes[0].Issues, 1)
+
+	// collateralized on the home, but not on the remote, and supply mismatch
+	home.Remotes[0].CollateralNeeded = big.NewInt(0)
+	home.Remotes[0].BridgedSupply = big.NewInt(9000)
+	setStatusIssues(home)
+	require.Len(home.Remotes[0].Issues, 2)
+
+	// unreachable remote
+	home.Remotes[0] = consistent()
+	home.Remotes[0].BridgedSupply = nil
+	setStatusIssues(home)
+	require.Len(home.Remotes[0].Issues, 1)
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package ictt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/teleporter"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+)
+
+const transferCheckInterval = 2 * time.Second
+
+// TransferHop is a teleporter message carrying a transfer, and its delivery state
+type TransferHop struct {
+	MessageID               ids.ID
+	DestinationBlockchainID ids.ID
+	Delivery                teleporter.MessageDelivery
+}
+
+// TransferTracker follows the teleporter messages of a transfer until the tokens
+// arrive at the final blockchain
+type TransferTracker struct {
+	getRPCURL func(blockchainID ids.ID) string
+	// heights of the blockchains the transfer goes through, taken before sending,
+	// to look for the delivery events from
+	heights map[ids.ID]uint64
+}
+
+// NewTransferTracker creates a tracker for a transfer to be sent through [blockchainIDs], whose
+// endpoints are given by [getRPCURL]. It must be created before sending the transfer
+func NewTransferTracker(
+	getRPCURL func(blockchainID ids.ID) string,
+	blockchainIDs ...ids.ID,
+) (*TransferTracker, error) {
+	tracker := TransferTracker{
+		getRPCURL: getRPCURL,
+		heights:   map[ids.ID]uint64{},
+	}
+	for _, blockchainID := range blockchainIDs {
+		client, err := evm.GetClient(getRPCURL(blockchainID))
+		if err != nil {
+			return nil, err
+		}
+		height, err := evm.GetBlockNumber(client)
+		client.Close()
+		if err != nil {
+			return nil, err
+		}
+		tracker.heights[blockchainID] = height
+	}
+	return &tracker, nil
+}
+
+// Wait follows the transfer sent on [receipt] until it is executed at [finalBlockchainID],
+// going through the home on multi-hop transfers. [onProgress] is called on every state change
+// of a hop. Fails if a hop is not executed after [timeout]
+func (t *TransferTracker) Wait(
+	receipt *types.Receipt,
+	finalBlockchainID ids.ID,
+	timeout time.Duration,
+	onProgress func(hop TransferHop),
+) error {
+	for {
+		messages, err := teleporter.GetSentMessages(receipt)
+		if err != nil {
+			return err
+		}
+		if len(messages) == 0 {
+			return fmt.Errorf("no teleporter message found on transfer tx %s", receipt.TxHash)
+		}
+		// on multi-hop, the home sends the transfer on together with any message of its own
+		message := messages[0]
+		for _, m := range messages {
+			if ids.ID(m.Event.DestinationBlockchainID) == finalBlockchainID {
+				message = m
+			}
+		}
+		hop := TransferHop{
+			MessageID:               ids.ID(message.Event.MessageID),
+			DestinationBlockchainID: ids.ID(message.Event.DestinationBlockchainID),
+		}
+		onProgress(hop)
+		rpcURL := t.getRPCURL(hop.DestinationBlockchainID)
+		t0 := time.Now()
+		for {
+			delivery, err := teleporter.GetMessageDelivery(
+				rpcURL,
+				message.MessengerAddress,
+				hop.MessageID,
+				t.heights[hop.DestinationBlockchainID],
+			)
+			if err != nil {
+				return err
+			}
+			if delivery != hop.Delivery {
+				hop.Delivery = delivery
+				onProgress(hop)
+			}
+			if delivery.ExecutionFailed {
+				return fmt.Errorf("execution of transfer message %s failed on %s", hop.MessageID, hop.DestinationBlockchainID)
+			}
+			if delivery.Executed {
+				break
+			}
+			if time.Since(t0) > timeout {
+				return fmt.Errorf("timeout waiting for delivery of transfer message %s to %s", hop.MessageID, hop.DestinationBlockchainID)
+			}
+			time.Sleep(transferCheckInterval)
+		}
+		if hop.DestinationBlockchainID == finalBlockchainID {
+			return nil
+		}
+		client, err := evm.GetClient(rpcURL)
+		if err != nil {
+			return err
+		}
+		receipt, err = evm.GetTransactionReceipt(client, hop.Delivery.DeliveryTx)
+		client.Close()
+		if err != nil {
+			return err
+		}
+	}
+}
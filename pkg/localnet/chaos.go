@@ -0,0 +1,168 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package localnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"golang.org/x/exp/maps"
+)
+
+type ChaosFault string
+
+const (
+	ChaosRestart ChaosFault = "restart"
+	ChaosStop    ChaosFault = "stop"
+	ChaosPause   ChaosFault = "pause"
+
+	chaosRecoveryTimeout = 2 * time.Minute
+)
+
+// period of the chaos loop checks
+var chaosTick = 100 * time.Millisecond
+
+// NodeFaulter is the set of node operations used by chaos mode. Implemented by Nodes
+type NodeFaulter interface {
+	Restart(ctx context.Context, nodeName string) error
+	Stop(ctx context.Context, nodeName string) error
+	Pause(ctx context.Context, nodeName string) error
+	Resume(ctx context.Context, nodeName string) error
+}
+
+type ChaosOptions struct {
+	// nodes to inject faults into
+	Nodes []string
+	// faults to randomly pick from
+	Faults []ChaosFault
+	// time between faults
+	Interval time.Duration
+	// time a stopped or paused node is kept down
+	Downtime time.Duration
+	// max number of nodes down at the same time
+	MaxDown int
+	// total chaos time. zero means until ctx is done
+	Duration time.Duration
+	Seed     int64
+}
+
+// ChaosEvent reports a fault injected into a node, or the node recovery from it
+type ChaosEvent struct {
+	Time      time.Time
+	Node      string
+	Fault     ChaosFault
+	Recovered bool
+	Err       error
+}
+
+func (o ChaosOptions) validate() error {
+	if len(o.Nodes) == 0 {
+		return fmt.Errorf("no nodes to inject faults into")
+	}
+	if len(o.Faults) == 0 {
+		return fmt.Errorf("no faults to inject")
+	}
+	for _, fault := range o.Faults {
+		switch fault {
+		case ChaosRestart, ChaosStop, ChaosPause:
+		default:
+			return fmt.Errorf("invalid fault %q. expected one of %s, %s, %s", fault, ChaosRestart, ChaosStop, ChaosPause)
+		}
+	}
+	if o.Interval <= 0 {
+		return fmt.Errorf("fault interval must be positive")
+	}
+	if o.MaxDown < 1 || o.MaxDown > len(o.Nodes) {
+		return fmt.Errorf("max nodes down must be between 1 and %d", len(o.Nodes))
+	}
+	return nil
+}
+
+// RunChaos randomly injects faults into the given nodes, one each interval, until the
+// chaos duration elapses or [ctx] is done. Stopped and paused nodes are brought back after
+// the downtime, and at the end of the run. [onEvent] is called for each fault and recovery
+func RunChaos(
+	ctx context.Context,
+	nodes NodeFaulter,
+	options ChaosOptions,
+	onEvent func(ChaosEvent),
+) error {
+	if err := options.validate(); err != nil {
+		return err
+	}
+	if options.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Duration)
+		defer cancel()
+	}
+	rng := rand.New(rand.NewSource(options.Seed)) //nolint:gosec
+	// nodes currently stopped or paused, with their fault and recovery time
+	type downNode struct {
+		fault ChaosFault
+		until time.Time
+	}
+	down := map[string]downNode{}
+	recoverNode := func(ctx context.Context, nodeName string) error {
+		err := nodes.Resume(ctx, nodeName)
+		onEvent(ChaosEvent{Time: time.Now(), Node: nodeName, Fault: down[nodeName].fault, Recovered: true, Err: err})
+		delete(down, nodeName)
+		return err
+	}
+	ticker := time.NewTicker(chaosTick)
+	defer ticker.Stop()
+	nextFault := time.Now().Add(options.Interval)
+	for {
+		select {
+		case <-ctx.Done():
+			recoveryCtx, cancel := context.WithTimeout(context.Background(), chaosRecoveryTimeout)
+			defer cancel()
+			var errs []error
+			downNodes := maps.Keys(down)
+			sort.Strings(downNodes)
+			for _, nodeName := range downNodes {
+				if err := recoverNode(recoveryCtx, nodeName); err != nil {
+					errs = append(errs, fmt.Errorf("failed to recover node %s: %w", nodeName, err))
+				}
+			}
+			return errors.Join(errs...)
+		case now := <-ticker.C:
+			for nodeName, d := range down {
+				if !now.Before(d.until) {
+					_ = recoverNode(ctx, nodeName)
+				}
+			}
+			if now.Before(nextFault) {
+				continue
+			}
+			nextFault = now.Add(options.Interval)
+			if len(down) >= options.MaxDown {
+				continue
+			}
+			candidates := []string{}
+			for _, nodeName := range options.Nodes {
+				if _, ok := down[nodeName]; !ok {
+					candidates = append(candidates, nodeName)
+				}
+			}
+			nodeName := candidates[rng.Intn(len(candidates))]
+			fault := options.Faults[rng.Intn(len(options.Faults))]
+			var err error
+			switch fault {
+			case ChaosRestart:
+				err = nodes.Restart(ctx, nodeName)
+			case ChaosStop:
+				err = nodes.Stop(ctx, nodeName)
+			case ChaosPause:
+				err = nodes.Pause(ctx, nodeName)
+			}
+			onEvent(ChaosEvent{Time: time.Now(), Node: nodeName, Fault: fault, Err: err})
+			if err == nil && fault != ChaosRestart {
+				down[nodeName] = downNode{fault: fault, until: time.Now().Add(options.Downtime)}
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package localnet
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeNodes struct {
+	down    map[string]string
+	maxDown int
+	calls   []string
+}
+
+func newFakeNodes() *fakeNodes {
+	return &fakeNodes{down: map[string]string{}}
+}
+
+func (f *fakeNodes) fault(nodeName string, status string) error {
+	f.calls = append(f.calls, status+" "+nodeName)
+	if _, ok := f.down[nodeName]; ok {
+		return fmt.Errorf("node %s is already down", nodeName)
+	}
+	f.down[nodeName] = status
+	if len(f.down) > f.maxDown {
+		f.maxDown = len(f.down)
+	}
+	return nil
+}
+
+func (f *fakeNodes) Restart(_ context.Context, nodeName string) error {
+	f.calls = append(f.calls, "restart "+nodeName)
+	if _, ok := f.down[nodeName]; ok {
+		return fmt.Errorf("node %s is down", nodeName)
+	}
+	return nil
+}
+
+func (f *fakeNodes) Stop(_ context.Context, nodeName string) error {
+	return f.fault(nodeName, NodeStatusStopped)
+}
+
+func (f *fakeNodes) Pause(_ context.Context, nodeName string) error {
+	return f.fault(nodeName, NodeStatusPaused)
+}
+
+func (f *fakeNodes) Resume(_ context.Context, nodeName string) error {
+	f.calls = append(f.calls, "resume "+nodeName)
+	if _, ok := f.down[nodeName]; !ok {
+		return fmt.Errorf("node %s is not down", nodeName)
+	}
+	delete(f.down, nodeName)
+	return nil
+}
+
+func TestRunChaosValidation(t *testing.T) {
+	valid := ChaosOptions{
+		Nodes:    []string{"node1", "node2"},
+		Faults:   []ChaosFault{ChaosRestart},
+		Interval: time.Second,
+		MaxDown:  1,
+	}
+	tests := []struct {
+		name   string
+		modify func(*ChaosOptions)
+		errMsg string
+	}{
+		{"no nodes", func(o *ChaosOptions) { o.Nodes = nil }, "no nodes"},
+		{"no faults", func(o *ChaosOptions) { o.Faults = nil }, "no faults"},
+		{"invalid fault", func(o *ChaosOptions) { o.Faults = []ChaosFault{"partition"} }, "invalid fault"},
+		{"no interval", func(o *ChaosOptions) { o.Interval = 0 }, "interval"},
+		{"no max down", func(o *ChaosOptions) { o.MaxDown = 0 }, "between 1 and 2"},
+		{"max down too big", func(o *ChaosOptions) { o.MaxDown = 3 }, "between 1 and 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := valid
+			tt.modify(&options)
+			err := RunChaos(context.Background(), newFakeNodes(), options, func(ChaosEvent) {})
+			require.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
+
+func TestRunChaos(t *testing.T) {
+	chaosTick = time.Millisecond
+	defer func() { chaosTick = 100 * time.Millisecond }()
+	nodes := newFakeNodes()
+	events := []ChaosEvent{}
+	err := RunChaos(
+		context.Background(),
+		nodes,
+		ChaosOptions{
+			Nodes:    []string{"node1", "node2", "node3"},
+			Faults:   []ChaosFault{ChaosRestart, ChaosStop, ChaosPause},
+			Interval: 5 * time.Millisecond,
+			Downtime: 20 * time.Millisecond,
+			MaxDown:  2,
+			Duration: 300 * time.Millisecond,
+			Seed:     1,
+		},
+		func(event ChaosEvent) { events = append(events, event) },
+	)
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	for _, event := range events {
+		require.NoError(t, event.Err)
+	}
+	// never more nodes down than allowed, and all of them back at the end
+	require.LessOrEqual(t, nodes.maxDown, 2)
+	require.Empty(t, nodes.down)
+	faults, recoveries := 0, 0
+	for _, event := range events {
+		switch {
+		case event.Recovered:
+			recoveries++
+		case event.Fault != ChaosRestart:
+			faults++
+		}
+	}
+	require.Equal(t, faults, recoveries)
+}
+
+func TestNextNodeName(t *testing.T) {
+	require.Equal(t, "node1", nextNodeName(nil))
+	require.Equal(t, "node4", nextNodeName([]string{"node1", "node2", "node3"}))
+	require.Equal(t, "node2", nextNodeName([]string{"node1", "node3"}))
+}
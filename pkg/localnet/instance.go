@@ -0,0 +1,69 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package localnet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-network-runner/network"
+	"github.com/ava-labs/avalanche-network-runner/network/node"
+	"github.com/ava-labs/avalanchego/config"
+)
+
+// SetSnapshotNodesPorts assigns the nodes of snapshot [snapshotName] the port range of the
+// current local network instance, so named local networks don't clash with each other.
+// The default local network keeps the ports saved into the snapshot
+func SetSnapshotNodesPorts(snapshotsDir string, snapshotName string) error {
+	instance := models.GetLocalNetworkInstance()
+	if instance.IsDefault() {
+		return nil
+	}
+	networkConfigPath := filepath.Join(GetSnapshotPath(snapshotsDir, snapshotName), snapshotNetworkConfigFile)
+	bs, err := os.ReadFile(networkConfigPath)
+	if err != nil {
+		return err
+	}
+	var cfg network.Config
+	if err := json.Unmarshal(bs, &cfg); err != nil {
+		return err
+	}
+	setNodesPorts(cfg.NodeConfigs, instance.NodesBasePort())
+	bs, err = json.MarshalIndent(cfg, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(networkConfigPath, bs, constants.WriteReadReadPerms)
+}
+
+// gives the nodes consecutive API and staking port pairs starting at [basePort], in
+// node<i> order, so node1 API port is always [basePort]
+func setNodesPorts(nodeConfigs []node.Config, basePort int) {
+	indexes := make([]int, len(nodeConfigs))
+	for i := range nodeConfigs {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		nameI, nameJ := nodeConfigs[indexes[i]].Name, nodeConfigs[indexes[j]].Name
+		indexI, errI := nodeIndex(nameI, 0)
+		indexJ, errJ := nodeIndex(nameJ, 0)
+		if errI == nil && errJ == nil {
+			return indexI < indexJ
+		}
+		if errI == nil || errJ == nil {
+			return errI == nil
+		}
+		return nameI < nameJ
+	})
+	for i, index := range indexes {
+		if nodeConfigs[index].Flags == nil {
+			nodeConfigs[index].Flags = map[string]interface{}{}
+		}
+		nodeConfigs[index].Flags[config.HTTPPortKey] = basePort + 2*i
+		nodeConfigs[index].Flags[config.StakingPortKey] = basePort + 2*i + 1
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package localnet
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanche-network-runner/network/node"
+	"github.com/ava-labs/avalanchego/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetNodesPorts(t *testing.T) {
+	nodeConfigs := []node.Config{
+		{Name: "node10"},
+		{Name: "node2", Flags: map[string]interface{}{config.HTTPPortKey: 9652.0, "log-level": "info"}},
+		{Name: "node1", Flags: map[string]interface{}{config.HTTPPortKey: 9650.0}},
+	}
+	setNodesPorts(nodeConfigs, 9750)
+	require.Equal(t, 9750, nodeConfigs[2].Flags[config.HTTPPortKey])
+	require.Equal(t, 9751, nodeConfigs[2].Flags[config.StakingPortKey])
+	require.Equal(t, 9752, nodeConfigs[1].Flags[config.HTTPPortKey])
+	require.Equal(t, 9753, nodeConfigs[1].Flags[config.StakingPortKey])
+	require.Equal(t, "info", nodeConfigs[1].Flags["log-level"])
+	require.Equal(t, 9754, nodeConfigs[0].Flags[config.HTTPPortKey])
+	require.Equal(t, 9755, nodeConfigs[0].Flags[config.StakingPortKey])
+}
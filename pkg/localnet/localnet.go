@@ -7,14 +7,19 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/ava-labs/avalanche-cli/pkg/binutils"
 	"github.com/ava-labs/avalanche-cli/pkg/constants"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-cli/pkg/ux"
+	"github.com/ava-labs/avalanche-network-runner/client"
 	"github.com/ava-labs/avalanche-network-runner/rpcpb"
 )
 
+const restartSnapshotTimestampFormat = "20060102150405"
+
 func GetClusterInfo() (*rpcpb.ClusterInfo, error) {
 	cli, err := binutils.NewGRPCClient(
 		binutils.WithDialTimeout(constants.FastGRPCDialTimeout),
@@ -92,3 +97,54 @@ func Deployed(subnetName string) (bool, error) {
 	}
 	return true, nil
 }
+
+// RestartWithChainConfigs restarts the local network so its nodes use the given chain
+// configs, indexed by blockchain ID. The network state is kept by saving it into a
+// temporary snapshot, named after [snapshotPrefix], and loading it back. The temporary
+// snapshot is removed once the network is healthy, unless [autoSave] is set. In that
+// case the network runs in place out of the snapshot, so it holds the live node data
+func RestartWithChainConfigs(
+	snapshotPrefix string,
+	chainConfigs map[string]string,
+	autoSave bool,
+) error {
+	cli, err := binutils.NewGRPCClient()
+	if err != nil {
+		return err
+	}
+	defer cli.Close()
+	return restartWithChainConfigs(cli, snapshotPrefix, chainConfigs, autoSave)
+}
+
+func restartWithChainConfigs(
+	cli client.Client,
+	snapshotPrefix string,
+	chainConfigs map[string]string,
+	autoSave bool,
+) error {
+	ctx, cancel := utils.GetANRContext()
+	defer cancel()
+	snapshotName := snapshotPrefix + "-tmp-" + time.Now().Format(restartSnapshotTimestampFormat)
+	if _, err := cli.SaveSnapshot(ctx, snapshotName, false); err != nil {
+		return err
+	}
+	if _, err := cli.LoadSnapshot(
+		ctx,
+		snapshotName,
+		autoSave,
+		client.WithChainConfigs(chainConfigs),
+	); err != nil {
+		return err
+	}
+	waitCancel := make(chan struct{})
+	defer close(waitCancel)
+	go ux.PrintWait(waitCancel)
+	if _, err := cli.WaitForHealthy(ctx); err != nil {
+		return err
+	}
+	if autoSave {
+		return nil
+	}
+	_, err := cli.RemoveSnapshot(ctx, snapshotName)
+	return err
+}
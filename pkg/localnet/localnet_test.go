@@ -0,0 +1,38 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package localnet
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/internal/mocks"
+	"github.com/ava-labs/avalanche-network-runner/rpcpb"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newRestartTestClient() *mocks.Client {
+	cli := &mocks.Client{}
+	cli.On("SaveSnapshot", mock.Anything, mock.Anything, false).Return(&rpcpb.SaveSnapshotResponse{}, nil)
+	cli.On("LoadSnapshot", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&rpcpb.LoadSnapshotResponse{}, nil)
+	cli.On("WaitForHealthy", mock.Anything).Return(&rpcpb.WaitForHealthyResponse{ClusterInfo: &rpcpb.ClusterInfo{}}, nil)
+	cli.On("RemoveSnapshot", mock.Anything, mock.Anything).Return(&rpcpb.RemoveSnapshotResponse{}, nil)
+	return cli
+}
+
+func TestRestartWithChainConfigs(t *testing.T) {
+	chainConfigs := map[string]string{"blockchainID": "{}"}
+
+	cli := newRestartTestClient()
+	require.NoError(t, restartWithChainConfigs(cli, "alpha", chainConfigs, false))
+	cli.AssertCalled(t, "LoadSnapshot", mock.Anything, mock.Anything, false, mock.Anything)
+	snapshotName := cli.Calls[0].Arguments.String(1)
+	require.Contains(t, snapshotName, "alpha-tmp-")
+	cli.AssertCalled(t, "RemoveSnapshot", mock.Anything, snapshotName)
+
+	// with auto save the network runs in place out of the temporary snapshot
+	cli = newRestartTestClient()
+	require.NoError(t, restartWithChainConfigs(cli, "alpha", chainConfigs, true))
+	cli.AssertCalled(t, "LoadSnapshot", mock.Anything, mock.Anything, true, mock.Anything)
+	cli.AssertNotCalled(t, "RemoveSnapshot", mock.Anything, mock.Anything)
+}
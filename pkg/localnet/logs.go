@@ -0,0 +1,321 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package localnet
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-network-runner/rpcpb"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	// avalanchego plain log format time prefix, which lacks the year
+	plainLogTimeLayout = "01-02|15:04:05.000"
+	// avalanchego JSON log format time
+	jsonLogTimeLayout = "2006-01-02T15:04:05.000Z0700"
+
+	mainLogName = "main"
+	logFileExt  = ".log"
+)
+
+var (
+	plainLogLineRegex = regexp.MustCompile(`^\[(\d{2}-\d{2}\|\d{2}:\d{2}:\d{2}\.\d{3})\]\s+(\w+)\s?(.*)$`)
+
+	primaryChainAliases = []string{"P", "X", "C"}
+
+	// period of the log files checks while following them
+	logsFollowTick = 500 * time.Millisecond
+)
+
+// LogSource is a log file of a local network node, for either the node itself or one of
+// its chains
+type LogSource struct {
+	Node string
+	// blockchain name or alias, or main for the node log
+	Chain string
+	Path  string
+}
+
+// LogEntry is a log line parsed from a log source. Lines without a timestamp, such as
+// stack traces, are appended to the previous entry message
+type LogEntry struct {
+	Time    time.Time `json:"time"`
+	Node    string    `json:"node"`
+	Chain   string    `json:"chain"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
+}
+
+type LogFilter struct {
+	// only entries at this level or above. empty means all levels
+	Level string
+	// only entries after this time. zero means all entries
+	Since time.Time
+	// only entries matching this expression. nil means all entries
+	Grep *regexp.Regexp
+}
+
+func (f LogFilter) matches(entry LogEntry, minLevel logging.Level) bool {
+	if f.Level != "" {
+		level, err := logging.ToLevel(entry.Level)
+		if err == nil && level < minLevel {
+			return false
+		}
+	}
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if f.Grep != nil && !f.Grep.MatchString(entry.Message) {
+		return false
+	}
+	return true
+}
+
+// GetLogSources locates the log files of the nodes of [clusterInfo]. If [nodeNames] is
+// given, only those nodes are considered. If [blockchain] is given, only the logs of that
+// chain are returned. It can be a deployed blockchain name, P, X, C or main
+func GetLogSources(clusterInfo *rpcpb.ClusterInfo, nodeNames []string, blockchain string) ([]LogSource, error) {
+	// chain log files are named after the chain ID
+	chainNames := map[string]string{}
+	for blockchainID, chainInfo := range clusterInfo.GetCustomChains() {
+		chainNames[blockchainID] = chainInfo.GetChainName()
+	}
+	if blockchain != "" && blockchain != mainLogName && !isPrimaryChainAlias(blockchain) {
+		found := false
+		for _, chainName := range chainNames {
+			if chainName == blockchain {
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("blockchain %s is not deployed to the local network", blockchain)
+		}
+	}
+	if len(nodeNames) == 0 {
+		nodeNames = clusterInfo.GetNodeNames()
+	}
+	sources := []LogSource{}
+	for _, nodeName := range nodeNames {
+		nodeInfo, ok := clusterInfo.GetNodeInfos()[nodeName]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s. available nodes: %s", ErrNodeNotFound, nodeName, strings.Join(clusterInfo.GetNodeNames(), ", "))
+		}
+		logPaths, err := filepath.Glob(filepath.Join(nodeInfo.GetLogDir(), "*"+logFileExt))
+		if err != nil {
+			return nil, err
+		}
+		for _, logPath := range logPaths {
+			chain := strings.TrimSuffix(filepath.Base(logPath), logFileExt)
+			if chainName, ok := chainNames[chain]; ok {
+				chain = chainName
+			}
+			if blockchain != "" && chain != blockchain {
+				continue
+			}
+			sources = append(sources, LogSource{
+				Node:  nodeName,
+				Chain: chain,
+				Path:  logPath,
+			})
+		}
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if sources[i].Node != sources[j].Node {
+			return sources[i].Node < sources[j].Node
+		}
+		return sources[i].Chain < sources[j].Chain
+	})
+	return sources, nil
+}
+
+func isPrimaryChainAlias(chain string) bool {
+	for _, alias := range primaryChainAliases {
+		if chain == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// parses a log line in either avalanchego plain or JSON format. plain lines lack the
+// year, so it is taken from [now], assuming the logs are not older than a year
+func parseLogLine(line string, now time.Time) (LogEntry, bool) {
+	if strings.HasPrefix(line, "{") {
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &fields); err != nil {
+			return LogEntry{}, false
+		}
+		timestamp, _ := fields["timestamp"].(string)
+		t, err := time.Parse(jsonLogTimeLayout, timestamp)
+		if err != nil {
+			return LogEntry{}, false
+		}
+		level, _ := fields["level"].(string)
+		msg, _ := fields["msg"].(string)
+		for _, key := range []string{"timestamp", "level", "msg"} {
+			delete(fields, key)
+		}
+		if len(fields) > 0 {
+			extra, err := json.Marshal(fields)
+			if err == nil {
+				msg += " " + string(extra)
+			}
+		}
+		return LogEntry{Time: t, Level: strings.ToUpper(level), Message: msg}, true
+	}
+	matches := plainLogLineRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return LogEntry{}, false
+	}
+	t, err := time.ParseInLocation(plainLogTimeLayout, matches[1], now.Location())
+	if err != nil {
+		return LogEntry{}, false
+	}
+	t = t.AddDate(now.Year(), 0, 0)
+	if t.After(now.Add(24 * time.Hour)) {
+		t = t.AddDate(-1, 0, 0)
+	}
+	return LogEntry{Time: t, Level: matches[2], Message: matches[3]}, true
+}
+
+// reads the log entries found in [r], appending lines that can't be parsed to the
+// previous entry message
+func readLogEntries(r io.Reader, source LogSource, now time.Time) ([]LogEntry, error) {
+	entries := []LogEntry{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		entry, ok := parseLogLine(line, now)
+		if !ok {
+			if len(entries) > 0 {
+				entries[len(entries)-1].Message += "\n" + line
+			}
+			continue
+		}
+		entry.Node = source.Node
+		entry.Chain = source.Chain
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+func filterAndSortLogEntries(entries []LogEntry, filter LogFilter) ([]LogEntry, error) {
+	minLevel := logging.Verbo
+	if filter.Level != "" {
+		var err error
+		minLevel, err = logging.ToLevel(filter.Level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", filter.Level, err)
+		}
+	}
+	filtered := []LogEntry{}
+	for _, entry := range entries {
+		if filter.matches(entry, minLevel) {
+			filtered = append(filtered, entry)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Time.Before(filtered[j].Time)
+	})
+	return filtered, nil
+}
+
+// ReadLogs returns the entries of all [sources] matching [filter], merged by time
+func ReadLogs(sources []LogSource, filter LogFilter) ([]LogEntry, error) {
+	now := time.Now()
+	entries := []LogEntry{}
+	for _, source := range sources {
+		bs, err := os.ReadFile(source.Path)
+		if err != nil {
+			return nil, err
+		}
+		sourceEntries, err := readLogEntries(bytes.NewReader(bs), source, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading %s: %w", source.Path, err)
+		}
+		entries = append(entries, sourceEntries...)
+	}
+	return filterAndSortLogEntries(entries, filter)
+}
+
+// FollowLogs calls [onEntry] for the entries of all [sources] matching [filter], first
+// for the existing ones, and then for the ones being appended, until [ctx] is done.
+// Entries appended at about the same time are merged by time
+func FollowLogs(ctx context.Context, sources []LogSource, filter LogFilter, onEntry func(LogEntry)) error {
+	offsets := make([]int64, len(sources))
+	for {
+		now := time.Now()
+		entries := []LogEntry{}
+		for i, source := range sources {
+			sourceEntries, offset, err := readNewLogEntries(source, offsets[i], now)
+			if err != nil {
+				return err
+			}
+			offsets[i] = offset
+			entries = append(entries, sourceEntries...)
+		}
+		entries, err := filterAndSortLogEntries(entries, filter)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			onEntry(entry)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(logsFollowTick):
+		}
+	}
+}
+
+// reads the complete lines appended to [source] after [offset], returning the entries
+// found and the offset to continue from
+func readNewLogEntries(source LogSource, offset int64, now time.Time) ([]LogEntry, int64, error) {
+	f, err := os.Open(source.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, offset, nil
+		}
+		return nil, offset, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, offset, err
+	}
+	if info.Size() < offset {
+		// file was rotated
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, err
+	}
+	bs, err := io.ReadAll(f)
+	if err != nil {
+		return nil, offset, err
+	}
+	// leave an incomplete last line for the next read
+	end := bytes.LastIndexByte(bs, '\n') + 1
+	entries, err := readLogEntries(bytes.NewReader(bs[:end]), source, now)
+	if err != nil {
+		return nil, offset, err
+	}
+	return entries, offset + int64(end), nil
+}
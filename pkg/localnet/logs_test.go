@@ -0,0 +1,145 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package localnet
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanche-network-runner/rpcpb"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogLine(t *testing.T) {
+	now := time.Date(2024, 6, 13, 18, 0, 0, 0, time.UTC)
+
+	entry, ok := parseLogLine(`[06-13|17:39:05.123] INFO <C Chain> snowman/transitive.go:123 consensus started {"height": 2}`, now)
+	require.True(t, ok)
+	require.Equal(t, time.Date(2024, 6, 13, 17, 39, 5, 123000000, time.UTC), entry.Time)
+	require.Equal(t, "INFO", entry.Level)
+	require.Equal(t, `<C Chain> snowman/transitive.go:123 consensus started {"height": 2}`, entry.Message)
+
+	// plain lines from late last year
+	entry, ok = parseLogLine("[12-31|23:59:59.000] WARN message", now)
+	require.True(t, ok)
+	require.Equal(t, 2023, entry.Time.Year())
+
+	entry, ok = parseLogLine(`{"level":"error","timestamp":"2024-06-13T17:39:05.123Z","msg":"failed","error":"boom"}`, now)
+	require.True(t, ok)
+	require.Equal(t, time.Date(2024, 6, 13, 17, 39, 5, 123000000, time.UTC), entry.Time.UTC())
+	require.Equal(t, "ERROR", entry.Level)
+	require.Equal(t, `failed {"error":"boom"}`, entry.Message)
+
+	_, ok = parseLogLine("goroutine 1 [running]:", now)
+	require.False(t, ok)
+}
+
+func writeLogFile(t *testing.T, path string, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestReadLogs(t *testing.T) {
+	dir := t.TempDir()
+	year := time.Now().Year()
+	node1Main := filepath.Join(dir, "node1", "main.log")
+	node2Chain := filepath.Join(dir, "node2", "chainID.log")
+	writeLogFile(t, node1Main, "[01-01|00:00:01.000] INFO first\n[01-01|00:00:03.000] ERROR third\npanic: boom\n")
+	writeLogFile(t, node2Chain, "[01-01|00:00:02.000] DEBUG second\n[01-01|00:00:04.000] WARN fourth\n")
+	sources := []LogSource{
+		{Node: "node1", Chain: "main", Path: node1Main},
+		{Node: "node2", Chain: "mychain", Path: node2Chain},
+	}
+
+	entries, err := ReadLogs(sources, LogFilter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	messages := []string{}
+	for _, entry := range entries {
+		messages = append(messages, entry.Message)
+	}
+	require.Equal(t, []string{"first", "second", "third\npanic: boom", "fourth"}, messages)
+	require.Equal(t, "node2", entries[1].Node)
+	require.Equal(t, "mychain", entries[1].Chain)
+
+	entries, err = ReadLogs(sources, LogFilter{Level: "warn"})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "ERROR", entries[0].Level)
+	require.Equal(t, "WARN", entries[1].Level)
+
+	entries, err = ReadLogs(sources, LogFilter{Since: time.Date(year, 1, 1, 0, 0, 2, 500000000, time.Local)})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	entries, err = ReadLogs(sources, LogFilter{Grep: regexp.MustCompile("boom")})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "node1", entries[0].Node)
+
+	_, err = ReadLogs(sources, LogFilter{Level: "loud"})
+	require.ErrorContains(t, err, "invalid log level")
+}
+
+func TestReadNewLogEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.log")
+	source := LogSource{Node: "node1", Chain: "main", Path: path}
+	writeLogFile(t, path, "[01-01|00:00:01.000] INFO first\n[01-01|00:00:02.000] INFO sec")
+	entries, offset, err := readNewLogEntries(source, 0, time.Now())
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	// the incomplete line is left for the next read
+	writeLogFile(t, path, "[01-01|00:00:01.000] INFO first\n[01-01|00:00:02.000] INFO second\n")
+	entries, _, err = readNewLogEntries(source, offset, time.Now())
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "second", entries[0].Message)
+}
+
+func TestGetLogSources(t *testing.T) {
+	dir := t.TempDir()
+	for _, path := range []string{
+		"node1/logs/main.log",
+		"node1/logs/C.log",
+		"node1/logs/chainID.log",
+		"node1/logs/main.log.1.gz",
+		"node2/logs/main.log",
+	} {
+		writeLogFile(t, filepath.Join(dir, path), "")
+	}
+	clusterInfo := &rpcpb.ClusterInfo{
+		NodeNames: []string{"node1", "node2"},
+		NodeInfos: map[string]*rpcpb.NodeInfo{
+			"node1": {Name: "node1", LogDir: filepath.Join(dir, "node1", "logs")},
+			"node2": {Name: "node2", LogDir: filepath.Join(dir, "node2", "logs")},
+		},
+		CustomChains: map[string]*rpcpb.CustomChainInfo{
+			"chainID": {ChainName: "mychain"},
+		},
+	}
+
+	sources, err := GetLogSources(clusterInfo, nil, "")
+	require.NoError(t, err)
+	require.Equal(t, []LogSource{
+		{Node: "node1", Chain: "C", Path: filepath.Join(dir, "node1/logs/C.log")},
+		{Node: "node1", Chain: "main", Path: filepath.Join(dir, "node1/logs/main.log")},
+		{Node: "node1", Chain: "mychain", Path: filepath.Join(dir, "node1/logs/chainID.log")},
+		{Node: "node2", Chain: "main", Path: filepath.Join(dir, "node2/logs/main.log")},
+	}, sources)
+
+	sources, err = GetLogSources(clusterInfo, []string{"node2"}, "main")
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+
+	sources, err = GetLogSources(clusterInfo, nil, "mychain")
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+
+	_, err = GetLogSources(clusterInfo, nil, "otherchain")
+	require.ErrorContains(t, err, "not deployed")
+	_, err = GetLogSources(clusterInfo, []string{"node3"}, "")
+	require.ErrorIs(t, err, ErrNodeNotFound)
+}
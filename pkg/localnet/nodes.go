@@ -0,0 +1,319 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package localnet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/binutils"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-network-runner/client"
+	"github.com/ava-labs/avalanche-network-runner/rpcpb"
+	"github.com/ava-labs/avalanchego/config"
+	"github.com/shirou/gopsutil/process"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	// status reported by the OS for a process stopped by SIGSTOP
+	suspendedProcessStatus = "T"
+
+	NodeStatusRunning = "running"
+	// node process terminated, but kept on the network to be resumed
+	NodeStatusStopped = "stopped"
+	// node process frozen, so it keeps its connections open but doesn't answer
+	NodeStatusPaused = "paused"
+)
+
+var ErrNodeNotFound = errors.New("node not found")
+
+// Nodes manages the nodes of the running local network, to inject faults into them
+type Nodes struct {
+	cli client.Client
+}
+
+func NewNodes() (*Nodes, error) {
+	cli, err := binutils.NewGRPCClient(
+		binutils.WithDialTimeout(constants.FastGRPCDialTimeout),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Nodes{cli: cli}, nil
+}
+
+func (n *Nodes) ClusterInfo(ctx context.Context) (*rpcpb.ClusterInfo, error) {
+	resp, err := n.cli.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetClusterInfo(), nil
+}
+
+func (n *Nodes) getNodeInfo(ctx context.Context, nodeName string) (*rpcpb.ClusterInfo, *rpcpb.NodeInfo, error) {
+	clusterInfo, err := n.ClusterInfo(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	nodeInfo, ok := clusterInfo.GetNodeInfos()[nodeName]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s. available nodes: %s", ErrNodeNotFound, nodeName, strings.Join(clusterInfo.GetNodeNames(), ", "))
+	}
+	return clusterInfo, nodeInfo, nil
+}
+
+// finds the avalanchego process of [nodeName], by looking for the config file
+// ANR writes into the node dir
+func getNodeProcess(rootDataDir string, nodeName string) (*process.Process, error) {
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+	configFileFlag := fmt.Sprintf("--%s=%s%c", config.ConfigFileKey, filepath.Join(rootDataDir, nodeName), filepath.Separator)
+	for _, p := range procs {
+		args, err := p.CmdlineSlice()
+		if err != nil {
+			// ignore errors for processes that just died (macos implementation)
+			continue
+		}
+		for _, arg := range args {
+			if strings.HasPrefix(arg, configFileFlag) {
+				return p, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("process for node %s not found", nodeName)
+}
+
+func isSuspended(p *process.Process) (bool, error) {
+	status, err := p.Status()
+	if err != nil {
+		return false, err
+	}
+	return strings.HasPrefix(status, suspendedProcessStatus), nil
+}
+
+// Status returns the status of [nodeName]: running, stopped or paused
+func (n *Nodes) Status(ctx context.Context, nodeName string) (string, error) {
+	clusterInfo, nodeInfo, err := n.getNodeInfo(ctx, nodeName)
+	if err != nil {
+		return "", err
+	}
+	return nodeStatus(clusterInfo, nodeInfo)
+}
+
+func nodeStatus(clusterInfo *rpcpb.ClusterInfo, nodeInfo *rpcpb.NodeInfo) (string, error) {
+	if nodeInfo.GetPaused() {
+		return NodeStatusStopped, nil
+	}
+	p, err := getNodeProcess(clusterInfo.GetRootDataDir(), nodeInfo.GetName())
+	if err != nil {
+		return "", err
+	}
+	suspended, err := isSuspended(p)
+	if err != nil {
+		return "", err
+	}
+	if suspended {
+		return NodeStatusPaused, nil
+	}
+	return NodeStatusRunning, nil
+}
+
+// unfreezes the process of [nodeName] if it is paused, so it can be gracefully stopped
+func unfreeze(clusterInfo *rpcpb.ClusterInfo, nodeInfo *rpcpb.NodeInfo) error {
+	if nodeInfo.GetPaused() {
+		return nil
+	}
+	p, err := getNodeProcess(clusterInfo.GetRootDataDir(), nodeInfo.GetName())
+	if err != nil {
+		return err
+	}
+	suspended, err := isSuspended(p)
+	if err != nil {
+		return err
+	}
+	if suspended {
+		return p.Resume()
+	}
+	return nil
+}
+
+// Stop gracefully stops [nodeName], keeping it on the network so it can be resumed
+func (n *Nodes) Stop(ctx context.Context, nodeName string) error {
+	clusterInfo, nodeInfo, err := n.getNodeInfo(ctx, nodeName)
+	if err != nil {
+		return err
+	}
+	if nodeInfo.GetPaused() {
+		return fmt.Errorf("node %s is already stopped", nodeName)
+	}
+	if err := unfreeze(clusterInfo, nodeInfo); err != nil {
+		return err
+	}
+	_, err = n.cli.PauseNode(ctx, nodeName)
+	return err
+}
+
+// Pause freezes the process of [nodeName] with SIGSTOP. Unlike a stopped node, its
+// peers keep their connections to it open, as with a hung or partitioned node
+func (n *Nodes) Pause(ctx context.Context, nodeName string) error {
+	clusterInfo, nodeInfo, err := n.getNodeInfo(ctx, nodeName)
+	if err != nil {
+		return err
+	}
+	status, err := nodeStatus(clusterInfo, nodeInfo)
+	if err != nil {
+		return err
+	}
+	if status != NodeStatusRunning {
+		return fmt.Errorf("node %s is %s", nodeName, status)
+	}
+	p, err := getNodeProcess(clusterInfo.GetRootDataDir(), nodeName)
+	if err != nil {
+		return err
+	}
+	return p.Suspend()
+}
+
+// Resume brings back [nodeName] after being stopped or paused
+func (n *Nodes) Resume(ctx context.Context, nodeName string) error {
+	clusterInfo, nodeInfo, err := n.getNodeInfo(ctx, nodeName)
+	if err != nil {
+		return err
+	}
+	status, err := nodeStatus(clusterInfo, nodeInfo)
+	if err != nil {
+		return err
+	}
+	switch status {
+	case NodeStatusStopped:
+		_, err = n.cli.ResumeNode(ctx, nodeName)
+		return err
+	case NodeStatusPaused:
+		return unfreeze(clusterInfo, nodeInfo)
+	default:
+		return fmt.Errorf("node %s is not stopped or paused", nodeName)
+	}
+}
+
+// Restart restarts [nodeName] with its current config, resuming it if stopped or paused
+func (n *Nodes) Restart(ctx context.Context, nodeName string) error {
+	clusterInfo, nodeInfo, err := n.getNodeInfo(ctx, nodeName)
+	if err != nil {
+		return err
+	}
+	if nodeInfo.GetPaused() {
+		_, err = n.cli.ResumeNode(ctx, nodeName)
+		return err
+	}
+	if err := unfreeze(clusterInfo, nodeInfo); err != nil {
+		return err
+	}
+	_, err = n.cli.RestartNode(ctx, nodeName)
+	return err
+}
+
+// Add adds a new non validator node to the network, tracking all its subnets, using the same
+// avalanchego binary as the existing nodes. If [nodeName] is empty, the next free
+// node<i> name is used. Returns the name of the new node
+func (n *Nodes) Add(ctx context.Context, nodeName string, pluginDir string) (string, error) {
+	clusterInfo, err := n.ClusterInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	nodeNames := clusterInfo.GetNodeNames()
+	if nodeName == "" {
+		nodeName = nextNodeName(nodeNames)
+	}
+	if _, ok := clusterInfo.GetNodeInfos()[nodeName]; ok {
+		return "", fmt.Errorf("node %s already exists", nodeName)
+	}
+	if len(nodeNames) == 0 {
+		return "", fmt.Errorf("no nodes found on the network")
+	}
+	execPath := clusterInfo.GetNodeInfos()[nodeNames[0]].GetExecPath()
+	subnetIDs := maps.Keys(clusterInfo.GetSubnets())
+	sort.Strings(subnetIDs)
+	nodeConfig, err := json.Marshal(map[string]interface{}{
+		config.TrackSubnetsKey: strings.Join(subnetIDs, ","),
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := n.cli.AddNode(
+		ctx,
+		nodeName,
+		execPath,
+		client.WithPluginDir(pluginDir),
+		client.WithGlobalNodeConfig(string(nodeConfig)),
+	); err != nil {
+		return "", err
+	}
+	return nodeName, nil
+}
+
+func nextNodeName(nodeNames []string) string {
+	used := map[string]bool{}
+	for _, nodeName := range nodeNames {
+		used[nodeName] = true
+	}
+	for i := 0; ; i++ {
+		if !used[NodeName(i)] {
+			return NodeName(i)
+		}
+	}
+}
+
+// Remove stops [nodeName] and removes it from the network
+func (n *Nodes) Remove(ctx context.Context, nodeName string) error {
+	clusterInfo, nodeInfo, err := n.getNodeInfo(ctx, nodeName)
+	if err != nil {
+		return err
+	}
+	if err := unfreeze(clusterInfo, nodeInfo); err != nil {
+		return err
+	}
+	_, err = n.cli.RemoveNode(ctx, nodeName)
+	return err
+}
+
+// Validators returns the nodes validating [blockchainName]. If empty, all network nodes
+// are returned
+func (n *Nodes) Validators(ctx context.Context, blockchainName string) ([]string, error) {
+	clusterInfo, err := n.ClusterInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if blockchainName == "" {
+		return clusterInfo.GetNodeNames(), nil
+	}
+	for _, chainInfo := range clusterInfo.GetCustomChains() {
+		if chainInfo.GetChainName() != blockchainName {
+			continue
+		}
+		subnetInfo, ok := clusterInfo.GetSubnets()[chainInfo.GetSubnetId()]
+		if !ok {
+			return nil, fmt.Errorf("subnet %s of blockchain %s not found", chainInfo.GetSubnetId(), blockchainName)
+		}
+		nodeNames := subnetInfo.GetSubnetParticipants().GetNodeNames()
+		sort.Strings(nodeNames)
+		return nodeNames, nil
+	}
+	return nil, fmt.Errorf("blockchain %s is not deployed to the local network", blockchainName)
+}
+
+func (n *Nodes) WaitForHealthy(ctx context.Context) error {
+	_, err := n.cli.WaitForHealthy(ctx)
+	return err
+}
+
+func (n *Nodes) Close() error {
+	return n.cli.Close()
+}
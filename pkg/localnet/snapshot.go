@@ -0,0 +1,498 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package localnet
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-network-runner/network"
+)
+
+const (
+	// ANR stores each snapshot at the snapshots dir, under a dir with this prefix
+	snapshotPrefix            = "anr-snapshot-"
+	snapshotNetworkConfigFile = "network.json"
+	snapshotNetworkStateFile  = "state.json"
+
+	// layout of a snapshot archive
+	snapshotArchiveMetadataFile   = "metadata.json"
+	snapshotArchiveSnapshotDir    = "snapshot"
+	snapshotArchiveBlockchainsDir = "blockchains"
+	snapshotArchivePluginsDir     = "plugins"
+
+	SnapshotArchiveExtension  = ".tar.gz"
+	SnapshotChecksumExtension = ".sha256"
+)
+
+var (
+	ErrSnapshotNotFound = errors.New("snapshot not found")
+
+	avagoVersionInPathRegex = regexp.MustCompile(`avalanchego-(v\d+\.\d+\.\d+[^/\\]*)`)
+)
+
+// SnapshotBlockchain is a blockchain deployed into a snapshot
+type SnapshotBlockchain struct {
+	Name         string `json:"name"`
+	BlockchainID string `json:"blockchainID"`
+	// set if the blockchain config found on CLI corresponds to this deployment
+	TeleporterMessengerAddress string `json:"teleporterMessengerAddress,omitempty"`
+}
+
+// Snapshot describes a saved local network
+type Snapshot struct {
+	Name               string               `json:"name"`
+	Path               string               `json:"-"`
+	CreatedAt          time.Time            `json:"createdAt"`
+	AvalancheGoVersion string               `json:"avalancheGoVersion"`
+	NumNodes           int                  `json:"numNodes"`
+	Size               int64                `json:"size"`
+	Blockchains        []SnapshotBlockchain `json:"blockchains"`
+	// C-Chain Teleporter deployed by CLI
+	CChainTeleporterMessengerAddress string `json:"cChainTeleporterMessengerAddress,omitempty"`
+}
+
+// SnapshotImport is the outcome of importing a snapshot archive
+type SnapshotImport struct {
+	Snapshot Snapshot
+	// blockchain configs installed from the archive
+	ImportedBlockchains []string
+	// blockchain configs already present on CLI, left untouched
+	SkippedBlockchains []string
+}
+
+// dynamic network data saved by ANR along the snapshot
+type snapshotNetworkState struct {
+	BlockchainAliases map[string][]string `json:"blockchainAliases"`
+}
+
+func GetSnapshotPath(snapshotsDir string, snapshotName string) string {
+	return filepath.Join(snapshotsDir, snapshotPrefix+snapshotName)
+}
+
+// GetSnapshotNames returns the names of the snapshots saved at [snapshotsDir]
+func GetSnapshotNames(snapshotsDir string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(snapshotsDir, snapshotPrefix+"*"))
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, match := range matches {
+		if utils.DirectoryExists(match) {
+			names = append(names, strings.TrimPrefix(filepath.Base(match), snapshotPrefix))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// GetSnapshot gathers the details of snapshot [snapshotName] saved at [snapshotsDir].
+// Blockchain names are the ones given on deploy, and if [app] has a local deployment
+// config for them, it is used to complement the details
+func GetSnapshot(app *application.Avalanche, snapshotsDir string, snapshotName string) (Snapshot, error) {
+	snapshotPath := GetSnapshotPath(snapshotsDir, snapshotName)
+	if !utils.DirectoryExists(snapshotPath) {
+		return Snapshot{}, fmt.Errorf("%w: %s", ErrSnapshotNotFound, snapshotName)
+	}
+	snapshot := Snapshot{
+		Name:        snapshotName,
+		Path:        snapshotPath,
+		Blockchains: []SnapshotBlockchain{},
+	}
+	networkConfigPath := filepath.Join(snapshotPath, snapshotNetworkConfigFile)
+	info, err := os.Stat(networkConfigPath)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("invalid snapshot %s: %w", snapshotName, err)
+	}
+	snapshot.CreatedAt = info.ModTime()
+	networkConfigBytes, err := os.ReadFile(networkConfigPath)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	var networkConfig network.Config
+	if err := json.Unmarshal(networkConfigBytes, &networkConfig); err != nil {
+		return Snapshot{}, fmt.Errorf("invalid network config on snapshot %s: %w", snapshotName, err)
+	}
+	snapshot.NumNodes = len(networkConfig.NodeConfigs)
+	snapshot.AvalancheGoVersion = avalancheGoVersionFromPath(networkConfig.BinaryPath)
+	if bs, err := os.ReadFile(filepath.Join(snapshotPath, snapshotNetworkStateFile)); err == nil {
+		var state snapshotNetworkState
+		if err := json.Unmarshal(bs, &state); err != nil {
+			return Snapshot{}, fmt.Errorf("invalid network state on snapshot %s: %w", snapshotName, err)
+		}
+		for blockchainID, aliases := range state.BlockchainAliases {
+			blockchain := SnapshotBlockchain{
+				BlockchainID: blockchainID,
+			}
+			if len(aliases) > 0 {
+				blockchain.Name = aliases[0]
+			}
+			if sc, ok := getDeploymentSidecar(app, blockchain); ok {
+				blockchain.TeleporterMessengerAddress = sc.Networks[models.NewLocalNetwork().Name()].TeleporterMessengerAddress
+			}
+			snapshot.Blockchains = append(snapshot.Blockchains, blockchain)
+		}
+		sort.Slice(snapshot.Blockchains, func(i, j int) bool {
+			return snapshot.Blockchains[i].Name < snapshot.Blockchains[j].Name
+		})
+	}
+	if bs, err := os.ReadFile(filepath.Join(snapshotPath, constants.ExtraLocalNetworkDataFilename)); err == nil {
+		var extraLocalNetworkData ExtraLocalNetworkData
+		if err := json.Unmarshal(bs, &extraLocalNetworkData); err == nil {
+			snapshot.CChainTeleporterMessengerAddress = extraLocalNetworkData.CChainTeleporterMessengerAddress
+		}
+	}
+	snapshot.Size, err = utils.SizeInKB(snapshotPath)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+// DeleteSnapshot removes snapshot [snapshotName] from [snapshotsDir]
+func DeleteSnapshot(snapshotsDir string, snapshotName string) error {
+	snapshotPath := GetSnapshotPath(snapshotsDir, snapshotName)
+	if !utils.DirectoryExists(snapshotPath) {
+		return fmt.Errorf("%w: %s", ErrSnapshotNotFound, snapshotName)
+	}
+	return os.RemoveAll(snapshotPath)
+}
+
+// ExportSnapshot writes snapshot [snapshotName] into the archive [archivePath], together
+// with the CLI configs and VM binaries of its blockchains, so it can be imported
+// on another machine. A sha256sum file is written next to the archive. Returns the
+// archive checksum
+func ExportSnapshot(
+	app *application.Avalanche,
+	snapshotName string,
+	archivePath string,
+) (string, error) {
+	snapshot, err := GetSnapshot(app, app.GetSnapshotsDir(), snapshotName)
+	if err != nil {
+		return "", err
+	}
+	metadataBytes, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	archiveFile, err := os.Create(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer archiveFile.Close()
+	hasher := sha256.New()
+	gzipWriter := gzip.NewWriter(io.MultiWriter(archiveFile, hasher))
+	tarWriter := tar.NewWriter(gzipWriter)
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Name:    snapshotArchiveMetadataFile,
+		Mode:    int64(constants.WriteReadReadPerms),
+		Size:    int64(len(metadataBytes)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return "", err
+	}
+	if _, err := tarWriter.Write(metadataBytes); err != nil {
+		return "", err
+	}
+	if err := addDirToTar(tarWriter, snapshot.Path, snapshotArchiveSnapshotDir); err != nil {
+		return "", err
+	}
+	for _, blockchain := range snapshot.Blockchains {
+		sc, ok := getDeploymentSidecar(app, blockchain)
+		if !ok {
+			continue
+		}
+		if err := addDirToTar(
+			tarWriter,
+			filepath.Join(app.GetSubnetDir(), sc.Name),
+			filepath.Join(snapshotArchiveBlockchainsDir, sc.Name),
+		); err != nil {
+			return "", err
+		}
+		vmID, err := sc.GetVMID()
+		if err != nil {
+			return "", err
+		}
+		vmPath := filepath.Join(app.GetPluginsDir(), vmID)
+		if utils.FileExists(vmPath) {
+			if err := addFileToTar(tarWriter, vmPath, filepath.Join(snapshotArchivePluginsDir, vmID)); err != nil {
+				return "", err
+			}
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return "", err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return "", err
+	}
+	checksum := hex.EncodeToString(hasher.Sum(nil))
+	checksumContent := fmt.Sprintf("%s  %s\n", checksum, filepath.Base(archivePath))
+	if err := os.WriteFile(archivePath+SnapshotChecksumExtension, []byte(checksumContent), constants.WriteReadReadPerms); err != nil {
+		return "", err
+	}
+	return checksum, nil
+}
+
+// ImportSnapshot installs the snapshot exported into [archivePath], after verifying it against
+// its sha256sum file. The snapshot is saved as [snapshotName], or under its original name if empty.
+// Blockchain configs and VM binaries included in the archive are installed if not already present
+func ImportSnapshot(
+	app *application.Avalanche,
+	archivePath string,
+	snapshotName string,
+	force bool,
+) (SnapshotImport, error) {
+	if err := VerifySnapshotArchive(archivePath); err != nil {
+		return SnapshotImport{}, err
+	}
+	snapshotsDir := app.GetSnapshotsDir()
+	if err := os.MkdirAll(snapshotsDir, constants.DefaultPerms755); err != nil {
+		return SnapshotImport{}, err
+	}
+	// extract next to the snapshots, so they can be moved into place
+	tmpDir, err := os.MkdirTemp(snapshotsDir, "import-")
+	if err != nil {
+		return SnapshotImport{}, err
+	}
+	defer os.RemoveAll(tmpDir)
+	if err := extractTarGz(archivePath, tmpDir); err != nil {
+		return SnapshotImport{}, fmt.Errorf("failed extracting snapshot archive: %w", err)
+	}
+	metadataBytes, err := os.ReadFile(filepath.Join(tmpDir, snapshotArchiveMetadataFile))
+	if err != nil {
+		return SnapshotImport{}, fmt.Errorf("invalid snapshot archive: %w", err)
+	}
+	var metadata Snapshot
+	if err := json.Unmarshal(metadataBytes, &metadata); err != nil {
+		return SnapshotImport{}, fmt.Errorf("invalid snapshot archive metadata: %w", err)
+	}
+	if snapshotName == "" {
+		snapshotName = metadata.Name
+	}
+	snapshotPath := GetSnapshotPath(snapshotsDir, snapshotName)
+	if utils.DirectoryExists(snapshotPath) {
+		if !force {
+			return SnapshotImport{}, fmt.Errorf("snapshot %s already exists", snapshotName)
+		}
+		if err := os.RemoveAll(snapshotPath); err != nil {
+			return SnapshotImport{}, err
+		}
+	}
+	if err := os.Rename(filepath.Join(tmpDir, snapshotArchiveSnapshotDir), snapshotPath); err != nil {
+		return SnapshotImport{}, fmt.Errorf("invalid snapshot archive: %w", err)
+	}
+	result := SnapshotImport{}
+	blockchainEntries, err := os.ReadDir(filepath.Join(tmpDir, snapshotArchiveBlockchainsDir))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return SnapshotImport{}, err
+	}
+	for _, entry := range blockchainEntries {
+		blockchainName := entry.Name()
+		if app.SidecarExists(blockchainName) {
+			result.SkippedBlockchains = append(result.SkippedBlockchains, blockchainName)
+			continue
+		}
+		if err := os.MkdirAll(app.GetSubnetDir(), constants.DefaultPerms755); err != nil {
+			return SnapshotImport{}, err
+		}
+		if err := os.Rename(
+			filepath.Join(tmpDir, snapshotArchiveBlockchainsDir, blockchainName),
+			filepath.Join(app.GetSubnetDir(), blockchainName),
+		); err != nil {
+			return SnapshotImport{}, err
+		}
+		result.ImportedBlockchains = append(result.ImportedBlockchains, blockchainName)
+	}
+	pluginEntries, err := os.ReadDir(filepath.Join(tmpDir, snapshotArchivePluginsDir))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return SnapshotImport{}, err
+	}
+	for _, entry := range pluginEntries {
+		vmPath := filepath.Join(app.GetPluginsDir(), entry.Name())
+		if utils.FileExists(vmPath) {
+			continue
+		}
+		if err := os.MkdirAll(app.GetPluginsDir(), constants.DefaultPerms755); err != nil {
+			return SnapshotImport{}, err
+		}
+		if err := os.Rename(filepath.Join(tmpDir, snapshotArchivePluginsDir, entry.Name()), vmPath); err != nil {
+			return SnapshotImport{}, err
+		}
+	}
+	result.Snapshot, err = GetSnapshot(app, snapshotsDir, snapshotName)
+	if err != nil {
+		return SnapshotImport{}, err
+	}
+	return result, nil
+}
+
+// VerifySnapshotArchive checks [archivePath] against the sha256sum file written on export
+func VerifySnapshotArchive(archivePath string) error {
+	checksumPath := archivePath + SnapshotChecksumExtension
+	checksumContent, err := os.ReadFile(checksumPath)
+	if err != nil {
+		return fmt.Errorf("failed reading snapshot archive checksum file %s: %w", checksumPath, err)
+	}
+	fields := strings.Fields(string(checksumContent))
+	if len(fields) == 0 {
+		return fmt.Errorf("invalid checksum file %s", checksumPath)
+	}
+	archiveFile, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer archiveFile.Close()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, archiveFile); err != nil {
+		return err
+	}
+	if hex.EncodeToString(hasher.Sum(nil)) != strings.ToLower(fields[0]) {
+		return fmt.Errorf("checksum mismatch for snapshot archive %s", archivePath)
+	}
+	return nil
+}
+
+// gets the CLI config of [blockchain], if it corresponds to the snapshot deployment
+func getDeploymentSidecar(app *application.Avalanche, blockchain SnapshotBlockchain) (models.Sidecar, bool) {
+	if app == nil || blockchain.Name == "" || !app.SidecarExists(blockchain.Name) {
+		return models.Sidecar{}, false
+	}
+	sc, err := app.LoadSidecar(blockchain.Name)
+	if err != nil {
+		return models.Sidecar{}, false
+	}
+	if sc.Networks[models.NewLocalNetwork().Name()].BlockchainID.String() != blockchain.BlockchainID {
+		return models.Sidecar{}, false
+	}
+	return sc, true
+}
+
+// avalanchego binaries are installed by CLI under a dir named after their version
+func avalancheGoVersionFromPath(binaryPath string) string {
+	matches := avagoVersionInPathRegex.FindStringSubmatch(binaryPath)
+	if len(matches) != 2 {
+		return ""
+	}
+	return matches[1]
+}
+
+func addDirToTar(tarWriter *tar.Writer, srcDir string, archiveDir string) error {
+	return filepath.WalkDir(srcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		archivePath := filepath.ToSlash(filepath.Join(archiveDir, relPath))
+		if d.IsDir() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			return tarWriter.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeDir,
+				Name:     archivePath + "/",
+				Mode:     int64(info.Mode().Perm()),
+				ModTime:  info.ModTime(),
+			})
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		return addFileToTar(tarWriter, path, archivePath)
+	})
+}
+
+func addFileToTar(tarWriter *tar.Writer, srcPath string, archivePath string) error {
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		return err
+	}
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(archivePath)
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	f, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(tarWriter, f)
+	return err
+}
+
+func extractTarGz(archivePath string, dstDir string) error {
+	archiveFile, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer archiveFile.Close()
+	gzipReader, err := gzip.NewReader(archiveFile)
+	if err != nil {
+		return err
+	}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		// check for zip slip
+		target := filepath.Join(dstDir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dstDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("content filepath is tainted: %s", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, constants.DefaultPerms755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), constants.DefaultPerms755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode).Perm())
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(f, tarReader); err != nil { //nolint:gosec
+				_ = f.Close()
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+			// snapshot creation time is taken from its files
+			if err := os.Chtimes(target, header.ModTime, header.ModTime); err != nil {
+				return err
+			}
+		}
+	}
+}
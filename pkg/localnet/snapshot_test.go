@@ -0,0 +1,134 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package localnet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-network-runner/network"
+	"github.com/ava-labs/avalanche-network-runner/network/node"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestApp(t *testing.T) *application.Avalanche {
+	app := application.New()
+	app.Setup(t.TempDir(), logging.NoLog{}, nil, nil, nil)
+	return app
+}
+
+// creates snapshot [snapshotName] with blockchain [blockchainName] deployed, together
+// with its CLI config and VM binary
+func createTestSnapshot(t *testing.T, app *application.Avalanche, snapshotName string, blockchainName string) ids.ID {
+	require := require.New(t)
+	blockchainID := ids.GenerateTestID()
+	snapshotPath := GetSnapshotPath(app.GetSnapshotsDir(), snapshotName)
+	require.NoError(os.MkdirAll(filepath.Join(snapshotPath, "node1", "db"), constants.DefaultPerms755))
+	require.NoError(os.WriteFile(filepath.Join(snapshotPath, "node1", "db", "data"), []byte("db data"), constants.WriteReadReadPerms))
+	networkConfig, err := json.Marshal(network.Config{
+		BinaryPath:  "/home/user/.avalanche-cli/bin/avalanchego/avalanchego-v1.11.7/avalanchego",
+		NodeConfigs: []node.Config{{Name: "node1"}, {Name: "node2"}},
+	})
+	require.NoError(err)
+	require.NoError(os.WriteFile(filepath.Join(snapshotPath, snapshotNetworkConfigFile), networkConfig, constants.WriteReadReadPerms))
+	state, err := json.Marshal(snapshotNetworkState{
+		BlockchainAliases: map[string][]string{blockchainID.String(): {blockchainName}},
+	})
+	require.NoError(err)
+	require.NoError(os.WriteFile(filepath.Join(snapshotPath, snapshotNetworkStateFile), state, constants.WriteReadReadPerms))
+	sc := models.Sidecar{
+		Name: blockchainName,
+		VM:   models.SubnetEvm,
+		Networks: map[string]models.NetworkData{
+			models.Local.String(): {
+				BlockchainID:               blockchainID,
+				TeleporterMessengerAddress: "0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf",
+			},
+		},
+	}
+	require.NoError(app.CreateSidecar(&sc))
+	vmID, err := sc.GetVMID()
+	require.NoError(err)
+	require.NoError(os.MkdirAll(app.GetPluginsDir(), constants.DefaultPerms755))
+	require.NoError(os.WriteFile(filepath.Join(app.GetPluginsDir(), vmID), []byte("vm binary"), constants.DefaultPerms755))
+	return blockchainID
+}
+
+func TestGetSnapshot(t *testing.T) {
+	require := require.New(t)
+	app := newTestApp(t)
+	blockchainID := createTestSnapshot(t, app, "snap1", "chain1")
+
+	names, err := GetSnapshotNames(app.GetSnapshotsDir())
+	require.NoError(err)
+	require.Equal([]string{"snap1"}, names)
+
+	snapshot, err := GetSnapshot(app, app.GetSnapshotsDir(), "snap1")
+	require.NoError(err)
+	require.Equal("v1.11.7", snapshot.AvalancheGoVersion)
+	require.Equal(2, snapshot.NumNodes)
+	require.Len(snapshot.Blockchains, 1)
+	require.Equal("chain1", snapshot.Blockchains[0].Name)
+	require.Equal(blockchainID.String(), snapshot.Blockchains[0].BlockchainID)
+	require.NotEmpty(snapshot.Blockchains[0].TeleporterMessengerAddress)
+
+	_, err = GetSnapshot(app, app.GetSnapshotsDir(), "snap2")
+	require.ErrorIs(err, ErrSnapshotNotFound)
+
+	require.NoError(DeleteSnapshot(app.GetSnapshotsDir(), "snap1"))
+	names, err = GetSnapshotNames(app.GetSnapshotsDir())
+	require.NoError(err)
+	require.Empty(names)
+	require.ErrorIs(DeleteSnapshot(app.GetSnapshotsDir(), "snap1"), ErrSnapshotNotFound)
+}
+
+func TestExportImportSnapshot(t *testing.T) {
+	require := require.New(t)
+	srcApp := newTestApp(t)
+	blockchainID := createTestSnapshot(t, srcApp, "snap1", "chain1")
+	archivePath := filepath.Join(t.TempDir(), "snap1"+SnapshotArchiveExtension)
+	checksum, err := ExportSnapshot(srcApp, "snap1", archivePath)
+	require.NoError(err)
+	require.Len(checksum, 64)
+	require.NoError(VerifySnapshotArchive(archivePath))
+
+	dstApp := newTestApp(t)
+	result, err := ImportSnapshot(dstApp, archivePath, "", false)
+	require.NoError(err)
+	require.Equal("snap1", result.Snapshot.Name)
+	require.Equal([]string{"chain1"}, result.ImportedBlockchains)
+	require.Empty(result.SkippedBlockchains)
+	require.Len(result.Snapshot.Blockchains, 1)
+	require.Equal(blockchainID.String(), result.Snapshot.Blockchains[0].BlockchainID)
+	data, err := os.ReadFile(filepath.Join(result.Snapshot.Path, "node1", "db", "data"))
+	require.NoError(err)
+	require.Equal("db data", string(data))
+	sc, err := dstApp.LoadSidecar("chain1")
+	require.NoError(err)
+	require.Equal(blockchainID, sc.Networks[models.Local.String()].BlockchainID)
+	vmID, err := sc.GetVMID()
+	require.NoError(err)
+	require.FileExists(filepath.Join(dstApp.GetPluginsDir(), vmID))
+
+	// existing snapshots are not overwritten unless forced
+	_, err = ImportSnapshot(dstApp, archivePath, "", false)
+	require.ErrorContains(err, "already exists")
+	result, err = ImportSnapshot(dstApp, archivePath, "", true)
+	require.NoError(err)
+	require.Equal([]string{"chain1"}, result.SkippedBlockchains)
+	result, err = ImportSnapshot(dstApp, archivePath, "snap2", false)
+	require.NoError(err)
+	require.Equal("snap2", result.Snapshot.Name)
+
+	// tampered archives are rejected
+	require.NoError(os.WriteFile(archivePath+SnapshotChecksumExtension, []byte("0000  snap1.tar.gz\n"), constants.WriteReadReadPerms))
+	_, err = ImportSnapshot(dstApp, archivePath, "snap3", false)
+	require.ErrorContains(err, "checksum mismatch")
+}
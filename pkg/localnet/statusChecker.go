@@ -7,7 +7,7 @@ import (
 	"errors"
 	"strings"
 
-	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanchego/api/info"
 )
 
@@ -23,7 +23,7 @@ func NewStatusChecker() StatusChecker {
 
 func (networkStatusChecker) GetCurrentNetworkVersion() (string, int, bool, error) {
 	ctx := context.Background()
-	infoClient := info.NewClient(constants.LocalAPIEndpoint)
+	infoClient := info.NewClient(models.NewLocalNetwork().Endpoint)
 	versionResponse, err := infoClient.GetNodeVersion(ctx)
 	if err != nil {
 		// not actually an error, network just not running
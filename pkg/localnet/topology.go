@@ -0,0 +1,298 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package localnet
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanche-network-runner/local"
+	"github.com/ava-labs/avalanche-network-runner/network"
+	anrutils "github.com/ava-labs/avalanche-network-runner/utils"
+	anrconstants "github.com/ava-labs/avalanche-network-runner/utils/constants"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	nodeNamePrefix = "node"
+
+	// avalanchego file names for the node staking keys
+	stakingKeyFileName  = "staker.key"
+	stakingCertFileName = "staker.crt"
+	signerKeyFileName   = "signer.key"
+)
+
+// Topology describes a local network created with a custom set of nodes, instead of
+// loaded from the bootstrap snapshot. It is saved into the network root dir, so it
+// is persisted into the snapshots of the network
+type Topology struct {
+	NumNodes int `json:"numNodes"`
+	// nodes using user provided staking keys
+	CustomStakingKeys []string `json:"customStakingKeys,omitempty"`
+	// avalanchego flags applied to all nodes
+	NodeConfig map[string]interface{} `json:"nodeConfig,omitempty"`
+	// avalanchego flags applied to specific nodes, by node name
+	PerNodeConfig map[string]map[string]interface{} `json:"perNodeConfig,omitempty"`
+}
+
+// TopologyOptions are the user inputs to create a local network topology
+type TopologyOptions struct {
+	// zero means default number of nodes, or the number of nodes given configs or keys
+	NumNodes uint32
+	// avalanchego config file for all nodes, or its JSON content
+	NodeConfig string
+	// dir with a <nodeName>.json avalanchego config file for each node to customize
+	PerNodeConfigDir string
+	// dir with a <nodeName> subdir, containing staker.key, staker.crt and signer.key,
+	// for each node to be given custom keys
+	StakingKeysDir string
+}
+
+// IsSet returns true if any custom topology option is given
+func (o TopologyOptions) IsSet() bool {
+	return o.NumNodes != 0 || o.NodeConfig != "" || o.PerNodeConfigDir != "" || o.StakingKeysDir != ""
+}
+
+func NodeName(i int) string {
+	return nodeNamePrefix + strconv.Itoa(i+1)
+}
+
+// gets the index of [nodeName] on a network of [numNodes] nodes. if [numNodes] is
+// zero, only the name format is checked
+func nodeIndex(nodeName string, numNodes int) (int, error) {
+	i, err := strconv.Atoi(strings.TrimPrefix(nodeName, nodeNamePrefix))
+	if !strings.HasPrefix(nodeName, nodeNamePrefix) || err != nil || i < 1 {
+		return 0, fmt.Errorf("invalid node name %q. expected %s<i>", nodeName, nodeNamePrefix)
+	}
+	if numNodes != 0 && i > numNodes {
+		return 0, fmt.Errorf("node %s not found on a network of %d nodes", nodeName, numNodes)
+	}
+	return i - 1, nil
+}
+
+// parses a JSON avalanchego config given either by file path or by content
+func loadNodeConfig(nodeConfig string) (map[string]interface{}, error) {
+	configBytes := []byte(nodeConfig)
+	if utils.FileExists(utils.ExpandHome(nodeConfig)) {
+		var err error
+		configBytes, err = os.ReadFile(utils.ExpandHome(nodeConfig))
+		if err != nil {
+			return nil, err
+		}
+	}
+	var flags map[string]interface{}
+	if err := json.Unmarshal(configBytes, &flags); err != nil {
+		return nil, fmt.Errorf("invalid node config: %w", err)
+	}
+	return flags, nil
+}
+
+func loadPerNodeConfig(dir string) (map[string]map[string]interface{}, error) {
+	entries, err := os.ReadDir(utils.ExpandHome(dir))
+	if err != nil {
+		return nil, fmt.Errorf("failed reading per node config dir: %w", err)
+	}
+	perNodeConfig := map[string]map[string]interface{}{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		nodeName := strings.TrimSuffix(entry.Name(), ".json")
+		if _, err := nodeIndex(nodeName, 0); err != nil {
+			return nil, err
+		}
+		flags, err := loadNodeConfig(filepath.Join(utils.ExpandHome(dir), entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		perNodeConfig[nodeName] = flags
+	}
+	return perNodeConfig, nil
+}
+
+func loadStakingKeys(dir string) (map[string]*anrutils.NodeKeys, error) {
+	entries, err := os.ReadDir(utils.ExpandHome(dir))
+	if err != nil {
+		return nil, fmt.Errorf("failed reading staking keys dir: %w", err)
+	}
+	stakingKeys := map[string]*anrutils.NodeKeys{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		nodeName := entry.Name()
+		if _, err := nodeIndex(nodeName, 0); err != nil {
+			return nil, err
+		}
+		nodeDir := filepath.Join(utils.ExpandHome(dir), nodeName)
+		keys := anrutils.NodeKeys{}
+		for fileName, field := range map[string]*[]byte{
+			stakingKeyFileName:  &keys.StakingKey,
+			stakingCertFileName: &keys.StakingCert,
+			signerKeyFileName:   &keys.BlsKey,
+		} {
+			*field, err = os.ReadFile(filepath.Join(nodeDir, fileName))
+			if err != nil {
+				return nil, fmt.Errorf("failed reading %s staking keys: %w", nodeName, err)
+			}
+		}
+		if _, err := anrutils.ToNodeID(keys.StakingKey, keys.StakingCert); err != nil {
+			return nil, fmt.Errorf("invalid %s staking key: %w", nodeName, err)
+		}
+		stakingKeys[nodeName] = &keys
+	}
+	return stakingKeys, nil
+}
+
+// NewTopologyNetworkConfig creates the ANR config of a local network with the nodes and flags
+// given by [options], all of them genesis validators. [defaultNumNodes] is used if the number
+// of nodes can't be inferred from the options
+func NewTopologyNetworkConfig(
+	binaryPath string,
+	options TopologyOptions,
+	defaultNumNodes uint32,
+) (network.Config, Topology, error) {
+	topology := Topology{
+		PerNodeConfig: map[string]map[string]interface{}{},
+	}
+	var err error
+	if options.NodeConfig != "" {
+		topology.NodeConfig, err = loadNodeConfig(options.NodeConfig)
+		if err != nil {
+			return network.Config{}, Topology{}, err
+		}
+	}
+	if options.PerNodeConfigDir != "" {
+		topology.PerNodeConfig, err = loadPerNodeConfig(options.PerNodeConfigDir)
+		if err != nil {
+			return network.Config{}, Topology{}, err
+		}
+	}
+	stakingKeys := map[string]*anrutils.NodeKeys{}
+	if options.StakingKeysDir != "" {
+		stakingKeys, err = loadStakingKeys(options.StakingKeysDir)
+		if err != nil {
+			return network.Config{}, Topology{}, err
+		}
+	}
+	// infer the number of nodes from the nodes being customized
+	numNodes := int(options.NumNodes)
+	if numNodes == 0 {
+		nodeNames := append(maps.Keys(topology.PerNodeConfig), maps.Keys(stakingKeys)...)
+		for _, nodeName := range nodeNames {
+			i, _ := nodeIndex(nodeName, 0)
+			if i+1 > numNodes {
+				numNodes = i + 1
+			}
+		}
+		if numNodes == 0 {
+			numNodes = int(defaultNumNodes)
+		}
+	}
+	for nodeName := range topology.PerNodeConfig {
+		if _, err := nodeIndex(nodeName, numNodes); err != nil {
+			return network.Config{}, Topology{}, err
+		}
+	}
+	for nodeName := range stakingKeys {
+		if _, err := nodeIndex(nodeName, numNodes); err != nil {
+			return network.Config{}, Topology{}, err
+		}
+	}
+	topology.NumNodes = numNodes
+	cfg, err := local.NewDefaultConfigNNodes(binaryPath, uint32(numNodes))
+	if err != nil {
+		return network.Config{}, Topology{}, err
+	}
+	// replace the generated keys with the custom ones, and make them all genesis validators
+	nodeKeys := []*anrutils.NodeKeys{}
+	for i := range cfg.NodeConfigs {
+		nodeName := NodeName(i)
+		cfg.NodeConfigs[i].Name = nodeName
+		keys, ok := stakingKeys[nodeName]
+		if ok {
+			encodedKeys := anrutils.EncodeNodeKeys(keys)
+			cfg.NodeConfigs[i].StakingKey = encodedKeys.StakingKey
+			cfg.NodeConfigs[i].StakingCert = encodedKeys.StakingCert
+			cfg.NodeConfigs[i].StakingSigningKey = encodedKeys.BlsKey
+			topology.CustomStakingKeys = append(topology.CustomStakingKeys, nodeName)
+		} else {
+			blsKey, err := base64.StdEncoding.DecodeString(cfg.NodeConfigs[i].StakingSigningKey)
+			if err != nil {
+				return network.Config{}, Topology{}, err
+			}
+			keys = &anrutils.NodeKeys{
+				StakingKey:  []byte(cfg.NodeConfigs[i].StakingKey),
+				StakingCert: []byte(cfg.NodeConfigs[i].StakingCert),
+				BlsKey:      blsKey,
+			}
+		}
+		nodeKeys = append(nodeKeys, keys)
+		for k, v := range topology.PerNodeConfig[nodeName] {
+			cfg.NodeConfigs[i].Flags[k] = v
+		}
+	}
+	sort.Strings(topology.CustomStakingKeys)
+	genesis, err := anrutils.GenerateGenesis(anrconstants.DefaultNetworkID, nodeKeys)
+	if err != nil {
+		return network.Config{}, Topology{}, err
+	}
+	cfg.Genesis = string(genesis)
+	for k, v := range topology.NodeConfig {
+		cfg.Flags[k] = v
+	}
+	return cfg, topology, nil
+}
+
+// CreateTopologySnapshot saves [cfg] as snapshot [snapshotName], to start a new network from it
+func CreateTopologySnapshot(
+	snapshotsDir string,
+	snapshotName string,
+	cfg network.Config,
+	topology Topology,
+) error {
+	snapshotPath := GetSnapshotPath(snapshotsDir, snapshotName)
+	if err := os.RemoveAll(snapshotPath); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(snapshotPath, constants.DefaultPerms755); err != nil {
+		return err
+	}
+	cfgBytes, err := json.MarshalIndent(cfg, "", "    ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(snapshotPath, snapshotNetworkConfigFile), cfgBytes, constants.WriteReadReadPerms); err != nil {
+		return err
+	}
+	topologyBytes, err := json.MarshalIndent(topology, "", "    ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(snapshotPath, constants.LocalNetworkTopologyFilename), topologyBytes, constants.WriteReadReadPerms)
+}
+
+// GetTopology returns the custom topology of the network at [rootDir], if any
+func GetTopology(rootDir string) (bool, Topology, error) {
+	topologyPath := filepath.Join(rootDir, constants.LocalNetworkTopologyFilename)
+	if !utils.FileExists(topologyPath) {
+		return false, Topology{}, nil
+	}
+	bs, err := os.ReadFile(topologyPath)
+	if err != nil {
+		return false, Topology{}, err
+	}
+	var topology Topology
+	if err := json.Unmarshal(bs, &topology); err != nil {
+		return false, Topology{}, err
+	}
+	return true, topology, nil
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package localnet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	anrutils "github.com/ava-labs/avalanche-network-runner/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTopologyNetworkConfig(t *testing.T) {
+	require := require.New(t)
+
+	perNodeConfigDir := t.TempDir()
+	require.NoError(os.WriteFile(filepath.Join(perNodeConfigDir, "node3.json"), []byte(`{"log-level":"debug"}`), constants.WriteReadReadPerms))
+	stakingKeysDir := t.TempDir()
+	keys, err := anrutils.GenerateKeysForNodes(1)
+	require.NoError(err)
+	nodeDir := filepath.Join(stakingKeysDir, "node2")
+	require.NoError(os.MkdirAll(nodeDir, constants.DefaultPerms755))
+	require.NoError(os.WriteFile(filepath.Join(nodeDir, stakingKeyFileName), keys[0].StakingKey, constants.WriteReadReadPerms))
+	require.NoError(os.WriteFile(filepath.Join(nodeDir, stakingCertFileName), keys[0].StakingCert, constants.WriteReadReadPerms))
+	require.NoError(os.WriteFile(filepath.Join(nodeDir, signerKeyFileName), keys[0].BlsKey, constants.WriteReadReadPerms))
+	nodeID, err := anrutils.ToNodeID(keys[0].StakingKey, keys[0].StakingCert)
+	require.NoError(err)
+
+	cfg, topology, err := NewTopologyNetworkConfig("avalanchego", TopologyOptions{
+		NumNodes:         7,
+		NodeConfig:       `{"throttler-inbound-disk-validator-alloc":1000}`,
+		PerNodeConfigDir: perNodeConfigDir,
+		StakingKeysDir:   stakingKeysDir,
+	}, 5)
+	require.NoError(err)
+	require.Equal(7, topology.NumNodes)
+	require.Equal([]string{"node2"}, topology.CustomStakingKeys)
+	require.Len(cfg.NodeConfigs, 7)
+	require.Equal("node7", cfg.NodeConfigs[6].Name)
+	require.Equal("debug", cfg.NodeConfigs[2].Flags["log-level"])
+	require.NotContains(cfg.NodeConfigs[1].Flags, "log-level")
+	require.Equal(string(keys[0].StakingCert), cfg.NodeConfigs[1].StakingCert)
+	require.EqualValues(1000, cfg.Flags["throttler-inbound-disk-validator-alloc"])
+	// custom keys are genesis validators
+	require.Contains(cfg.Genesis, nodeID.String())
+
+	// number of nodes is inferred from the customized nodes
+	_, topology, err = NewTopologyNetworkConfig("avalanchego", TopologyOptions{PerNodeConfigDir: perNodeConfigDir}, 5)
+	require.NoError(err)
+	require.Equal(3, topology.NumNodes)
+	_, topology, err = NewTopologyNetworkConfig("avalanchego", TopologyOptions{NodeConfig: "{}"}, 1)
+	require.NoError(err)
+	require.Equal(1, topology.NumNodes)
+
+	// customized nodes must be part of the network
+	_, _, err = NewTopologyNetworkConfig("avalanchego", TopologyOptions{NumNodes: 2, PerNodeConfigDir: perNodeConfigDir}, 5)
+	require.ErrorContains(err, "node3 not found")
+	_, _, err = NewTopologyNetworkConfig("avalanchego", TopologyOptions{NodeConfig: "{invalid"}, 5)
+	require.ErrorContains(err, "invalid node config")
+}
+
+func TestCreateTopologySnapshot(t *testing.T) {
+	require := require.New(t)
+	snapshotsDir := t.TempDir()
+	cfg, topology, err := NewTopologyNetworkConfig("/bin/avalanchego/avalanchego-v1.11.7/avalanchego", TopologyOptions{NumNodes: 2}, 5)
+	require.NoError(err)
+	require.NoError(CreateTopologySnapshot(snapshotsDir, "topo", cfg, topology))
+	snapshot, err := GetSnapshot(nil, snapshotsDir, "topo")
+	require.NoError(err)
+	require.Equal(2, snapshot.NumNodes)
+	require.Equal("v1.11.7", snapshot.AvalancheGoVersion)
+	found, savedTopology, err := GetTopology(snapshot.Path)
+	require.NoError(err)
+	require.True(found)
+	require.Equal(2, savedTopology.NumNodes)
+	found, _, err = GetTopology(t.TempDir())
+	require.NoError(err)
+	require.False(found)
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package models
+
+import "github.com/ava-labs/avalanchego/ids"
+
+// ICTTEndpoint is a Home or a Remote of a Token Transferrer deployed by CLI
+type ICTTEndpoint struct {
+	// blockchain name, or c-chain
+	Blockchain   string
+	BlockchainID ids.ID
+	Address      string
+	// ERC20 Home, Native Home, ERC20 Remote or Native Remote
+	Kind string
+	// remotes only: the home holds enough collateral for the remote supply
+	Collateralized bool `json:",omitempty"`
+}
+
+// ICTTDeployment is a Token Transferrer deployed by CLI, to be referred to by name
+type ICTTDeployment struct {
+	Name string
+	// network name, as given by Network.Name()
+	Network       string
+	Version       string
+	TokenAddress  string
+	TokenName     string
+	TokenSymbol   string
+	TokenDecimals uint8
+	Home          ICTTEndpoint
+	Remotes       []ICTTEndpoint
+}
+
+// GetEndpoint returns the home or remote endpoint of the deployment on [blockchainID]
+func (d ICTTDeployment) GetEndpoint(blockchainID ids.ID) (ICTTEndpoint, bool) {
+	if d.Home.BlockchainID == blockchainID {
+		return d.Home, true
+	}
+	for _, remote := range d.Remotes {
+		if remote.BlockchainID == blockchainID {
+			return remote, true
+		}
+	}
+	return ICTTEndpoint{}, false
+}
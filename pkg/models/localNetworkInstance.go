@@ -0,0 +1,93 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package models
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+)
+
+var localNetworkNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// LocalNetworkInstance identifies one of the local networks that can run side by side
+// on the machine, each one with its own backend, ports and data dirs. The default local
+// network has no name and uses slot zero
+type LocalNetworkInstance struct {
+	Name string `json:"name"`
+	Slot int    `json:"slot"`
+}
+
+// local network instance all commands operate on
+var currentLocalNetworkInstance LocalNetworkInstance
+
+func GetLocalNetworkInstance() LocalNetworkInstance {
+	return currentLocalNetworkInstance
+}
+
+func SetLocalNetworkInstance(instance LocalNetworkInstance) {
+	currentLocalNetworkInstance = instance
+}
+
+func ValidateLocalNetworkName(name string) error {
+	if !localNetworkNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid local network name %q. only letters, digits, '-' and '_' are allowed", name)
+	}
+	return nil
+}
+
+func (i LocalNetworkInstance) IsDefault() bool {
+	return i.Name == ""
+}
+
+func (i LocalNetworkInstance) DisplayName() string {
+	if i.IsDefault() {
+		return "default"
+	}
+	return i.Name
+}
+
+// NetworkName is the name of the local network instance, under which the deployments
+// into it are kept on the sidecars. The default local network keeps the "Local Network" name
+func (i LocalNetworkInstance) NetworkName() string {
+	if i.IsDefault() {
+		return Local.String()
+	}
+	return Local.String() + " " + i.Name
+}
+
+func (i LocalNetworkInstance) GRPCServerPort() int {
+	return constants.GRPCServerPort + 2*i.Slot
+}
+
+func (i LocalNetworkInstance) GRPCGatewayPort() int {
+	return constants.GRPCGatewayPort + 2*i.Slot
+}
+
+// RelayerMetricsPort is the metrics port of the relayer of the local network
+func (i LocalNetworkInstance) RelayerMetricsPort() int {
+	return constants.AWMRelayerMetricsPort + 2*i.Slot
+}
+
+// RelayerAPIPort is the API port of the relayer of the local network. It is zero for
+// the default local network, whose relayer uses the relayer default API port
+func (i LocalNetworkInstance) RelayerAPIPort() int {
+	if i.IsDefault() {
+		return 0
+	}
+	return constants.AWMRelayerAPIPort + 2*i.Slot
+}
+
+// NodesBasePort is the API port of the first node. Node i uses [NodesBasePort+2i] as API
+// port and [NodesBasePort+2i+1] as staking port
+func (i LocalNetworkInstance) NodesBasePort() int {
+	return constants.AvalanchegoAPIPort + constants.LocalNetworkNodesPortsRange*i.Slot
+}
+
+func (i LocalNetworkInstance) APIEndpoint() string {
+	if i.IsDefault() {
+		return constants.LocalAPIEndpoint
+	}
+	return fmt.Sprintf("http://127.0.0.1:%d", i.NodesBasePort())
+}
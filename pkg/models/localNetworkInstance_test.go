@@ -0,0 +1,42 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package models
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalNetworkInstanceNetworkName(t *testing.T) {
+	require := require.New(t)
+	require.Equal(Local.String(), LocalNetworkInstance{}.NetworkName())
+	require.Equal("Local Network dev", LocalNetworkInstance{Name: "dev", Slot: 1}.NetworkName())
+
+	defer SetLocalNetworkInstance(GetLocalNetworkInstance())
+	SetLocalNetworkInstance(LocalNetworkInstance{Name: "dev", Slot: 1})
+	require.Equal("Local Network dev", NewLocalNetwork().Name())
+}
+
+func TestLocalNetworkInstancePorts(t *testing.T) {
+	require := require.New(t)
+	defaultInstance := LocalNetworkInstance{}
+	require.Equal(constants.AWMRelayerMetricsPort, defaultInstance.RelayerMetricsPort())
+	require.Zero(defaultInstance.RelayerAPIPort())
+
+	used := map[int]bool{}
+	for slot := 1; slot <= constants.MaxLocalNetworkInstances; slot++ {
+		instance := LocalNetworkInstance{Name: "dev", Slot: slot}
+		for _, port := range []int{
+			instance.GRPCServerPort(),
+			instance.GRPCGatewayPort(),
+			instance.RelayerAPIPort(),
+			instance.RelayerMetricsPort(),
+		} {
+			require.False(used[port], "port %d used twice", port)
+			require.Less(port, constants.AvalanchegoAPIPort)
+			used[port] = true
+		}
+	}
+}
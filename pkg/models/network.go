@@ -55,7 +55,7 @@ func NewNetwork(kind NetworkKind, id uint32, endpoint string, clusterName string
 }
 
 func NewLocalNetwork() Network {
-	return NewNetwork(Local, constants.LocalNetworkID, constants.LocalAPIEndpoint, "")
+	return NewNetwork(Local, constants.LocalNetworkID, GetLocalNetworkInstance().APIEndpoint(), "")
 }
 
 func NewDevnetNetwork(endpoint string, id uint32) Network {
@@ -100,6 +100,9 @@ func (n Network) Name() string {
 	if n.ClusterName != "" && n.Kind == Devnet {
 		return "Cluster " + n.ClusterName
 	}
+	if n.Kind == Local {
+		return GetLocalNetworkInstance().NetworkName()
+	}
 	name := n.Kind.String()
 	if n.Kind == Devnet {
 		name += " " + n.Endpoint
@@ -159,6 +162,6 @@ func (n *Network) HandlePublicNetworkSimulation() {
 	if os.Getenv(constants.SimulatePublicNetwork) != "" {
 		n.Kind = Local
 		n.ID = constants.LocalNetworkID
-		n.Endpoint = constants.LocalAPIEndpoint
+		n.Endpoint = GetLocalNetworkInstance().APIEndpoint()
 	}
 }
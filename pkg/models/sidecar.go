@@ -3,6 +3,8 @@
 package models
 
 import (
+	"math/big"
+
 	"github.com/ava-labs/avalanche-network-runner/utils"
 	"github.com/ava-labs/avalanchego/ids"
 )
@@ -14,6 +16,39 @@ type NetworkData struct {
 	RPCVersion                  int
 	TeleporterMessengerAddress  string
 	TeleporterRegistryAddress   string
+	// ERC20 tokens deployed by CLI into the blockchain
+	ERC20Tokens []ERC20Token `json:",omitempty"`
+}
+
+// IsEmpty tells if no deploy information has been set on [nd]
+func (nd NetworkData) IsEmpty() bool {
+	return nd.SubnetID == ids.Empty &&
+		nd.TransferSubnetOwnershipTxID == ids.Empty &&
+		nd.BlockchainID == ids.Empty &&
+		nd.RPCVersion == 0 &&
+		nd.TeleporterMessengerAddress == "" &&
+		nd.TeleporterRegistryAddress == "" &&
+		len(nd.ERC20Tokens) == 0
+}
+
+// ERC20Features describes the optional behaviours of a CLI deployed ERC20 token
+type ERC20Features struct {
+	Decimals uint8
+	Mintable bool
+	Minter   string `json:",omitempty"`
+	Burnable bool
+	// max supply in whole tokens. nil or zero means uncapped
+	Cap      *big.Int `json:",omitempty"`
+	Pausable bool
+	Permit   bool
+}
+
+// ERC20Token is an ERC20 token deployed by CLI, to be referred to by name
+type ERC20Token struct {
+	Name     string
+	Symbol   string
+	Address  string
+	Features ERC20Features
 }
 
 type Sidecar struct {
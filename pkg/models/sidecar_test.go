@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/ava-labs/avalanche-network-runner/utils"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/stretchr/testify/require"
 )
 
@@ -38,3 +39,11 @@ func TestGetVMID_derived(t *testing.T) {
 	assert.NoError(err)
 	assert.Equal(expectedVMID.String(), vmid)
 }
+
+func TestNetworkDataIsEmpty(t *testing.T) {
+	assert := require.New(t)
+	assert.True(NetworkData{}.IsEmpty())
+	assert.True(NetworkData{ERC20Tokens: []ERC20Token{}}.IsEmpty())
+	assert.False(NetworkData{BlockchainID: ids.GenerateTestID()}.IsEmpty())
+	assert.False(NetworkData{ERC20Tokens: []ERC20Token{{}}}.IsEmpty())
+}
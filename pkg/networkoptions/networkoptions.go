@@ -3,6 +3,7 @@
 package networkoptions
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -98,13 +99,19 @@ func AddNetworkFlagsToCmd(cmd *cobra.Command, networkFlags *NetworkFlags, addEnd
 	}
 }
 
+// ErrOtherLocalNetwork is returned for sidecar deployments into a local network other
+// than the one CLI operates on
+var ErrOtherLocalNetwork = errors.New("deployment into another local network")
+
 func GetNetworkFromSidecarNetworkName(
 	app *application.Avalanche,
 	networkName string,
 ) (models.Network, error) {
 	switch {
-	case networkName == models.Local.String():
+	case networkName == models.NewLocalNetwork().Name():
 		return models.NewLocalNetwork(), nil
+	case strings.HasPrefix(networkName, models.Local.String()):
+		return models.UndefinedNetwork, fmt.Errorf("%w: %s", ErrOtherLocalNetwork, networkName)
 	case strings.HasPrefix(networkName, Cluster.String()):
 		parts := strings.Split(networkName, " ")
 		if len(parts) != 2 {
@@ -132,7 +139,12 @@ func GetSupportedNetworkOptionsForSubnet(
 	for _, networkOption := range supportedNetworkOptions {
 		isInSidecar := false
 		for networkName := range sc.Networks {
-			if strings.HasPrefix(networkName, networkOption.String()) {
+			if networkOption == Local {
+				// other local networks keep their own deployments
+				if networkName == models.NewLocalNetwork().Name() {
+					isInSidecar = true
+				}
+			} else if strings.HasPrefix(networkName, networkOption.String()) {
 				isInSidecar = true
 			}
 			if os.Getenv(constants.SimulatePublicNetwork) != "" {
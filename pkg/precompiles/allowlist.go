@@ -99,3 +99,33 @@ func ReadAllowList(
 	}
 	return role, nil
 }
+
+// ReadAllowListRoles is as ReadAllowList for each one of [toQuery], batching
+// all the queries into one request if Multicall3 is available
+func ReadAllowListRoles(
+	rpcURL string,
+	precompile common.Address,
+	toQuery []common.Address,
+) ([]*big.Int, error) {
+	calls := []contract.Call{}
+	for _, address := range toQuery {
+		calls = append(calls, contract.Call{
+			Target:    precompile,
+			MethodEsp: "readAllowList(address)->(uint256)",
+			Params:    []interface{}{address},
+		})
+	}
+	results, err := contract.MulticallRead(rpcURL, calls)
+	if err != nil {
+		return nil, err
+	}
+	roles := []*big.Int{}
+	for _, result := range results {
+		role, b := result.Values[0].(*big.Int)
+		if !b {
+			return nil, fmt.Errorf("error at readAllowList, expected *big.Int, got %T", result.Values[0])
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
@@ -120,7 +120,7 @@ func (d *LocalDeployer) StartServer() error {
 }
 
 func GetCurrentSupply(subnetID ids.ID) error {
-	api := constants.LocalAPIEndpoint
+	api := models.NewLocalNetwork().Endpoint
 	pClient := platformvm.NewClient(api)
 	ctx, cancel := utils.GetAPIContext()
 	defer cancel()
@@ -251,19 +251,35 @@ func (d *LocalDeployer) doDeploy(chain string, genesisPath string, teleporterEsp
 
 	subnetIDs := maps.Keys(clusterInfo.Subnets)
 
-	// in order to make subnet deploy faster, a set of validated subnet IDs is preloaded
-	// in the bootstrap snapshot
-	// we select one to be used for creating the next blockchain, for that we use the
-	// number of currently created blockchains as the index to select the next subnet ID,
-	// so we get incremental selection
-	sort.Strings(subnetIDs)
-	if len(subnetIDs) == 0 {
-		return nil, errors.New("the network has not preloaded subnet IDs")
+	// networks started with a custom topology have no preloaded subnets: a new one is
+	// created for each blockchain, validated by all the network nodes
+	hasTopology, _, err := localnet.GetTopology(clusterInfo.GetRootDataDir())
+	if err != nil {
+		return nil, err
 	}
 
-	// If not set via argument, deploy to the next available subnet
-	if subnetIDStr == "" {
+	var targetSubnetID *string
+	switch {
+	case subnetIDStr != "":
+		targetSubnetID = &subnetIDStr
+	case !hasTopology:
+		// in order to make subnet deploy faster, a set of validated subnet IDs is preloaded
+		// in the bootstrap snapshot
+		// we select one to be used for creating the next blockchain, for that we use the
+		// number of currently created blockchains as the index to select the next subnet ID,
+		// so we get incremental selection
+		sort.Strings(subnetIDs)
+		if len(subnetIDs) == 0 {
+			return nil, errors.New("the network has not preloaded subnet IDs")
+		}
+		// If not set via argument, deploy to the next available subnet
 		subnetIDStr = subnetIDs[numBlockchains%len(subnetIDs)]
+		targetSubnetID = &subnetIDStr
+	}
+	var participants []string
+	if targetSubnetID == nil {
+		participants = clusterInfo.NodeNames
+		sort.Strings(participants)
 	}
 
 	// if a chainConfig has been configured
@@ -300,8 +316,9 @@ func (d *LocalDeployer) doDeploy(chain string, genesisPath string, teleporterEsp
 		{
 			VmName:   chain,
 			Genesis:  genesisPath,
-			SubnetId: &subnetIDStr,
+			SubnetId: targetSubnetID,
 			SubnetSpec: &rpcpb.SubnetSpec{
+				Participants: participants,
 				SubnetConfig: subnetConfig,
 			},
 			ChainConfig:        chainConfig,
@@ -483,6 +500,10 @@ func (d *LocalDeployer) doDeploy(chain string, genesisPath string, teleporterEsp
 	for _, info := range clusterInfo.CustomChains {
 		if info.VmId == chainVMID.String() {
 			blockchainID, _ = ids.FromString(info.ChainId)
+			if targetSubnetID == nil {
+				// the blockchain was deployed into a new subnet
+				subnetID, _ = ids.FromString(info.SubnetId)
+			}
 		}
 	}
 	return &DeployInfo{
@@ -804,6 +825,10 @@ func (d *LocalDeployer) startNetwork(
 		loadSnapshotOpts = append(loadSnapshotOpts, client.WithGlobalNodeConfig(configStr))
 	}
 
+	if err := localnet.SetSnapshotNodesPorts(d.app.GetSnapshotsDir(), constants.DefaultSnapshotName); err != nil {
+		return err
+	}
+
 	ux.Logger.PrintToUser("")
 	ux.Logger.PrintToUser("Booting Network. Wait until healthy...")
 	resp, err := cli.LoadSnapshot(
@@ -846,7 +871,7 @@ func GetLocallyDeployedSubnets() (map[string]struct{}, error) {
 
 func IssueRemoveSubnetValidatorTx(kc keychain.Keychain, subnetID ids.ID, nodeID ids.NodeID) (ids.ID, error) {
 	ctx := context.Background()
-	api := constants.LocalAPIEndpoint
+	api := models.NewLocalNetwork().Endpoint
 	wallet, err := primary.MakeWallet(
 		ctx,
 		&primary.WalletConfig{
@@ -865,7 +890,7 @@ func IssueRemoveSubnetValidatorTx(kc keychain.Keychain, subnetID ids.ID, nodeID
 }
 
 func GetSubnetValidators(subnetID ids.ID) ([]platformvm.ClientPermissionlessValidator, error) {
-	api := constants.LocalAPIEndpoint
+	api := models.NewLocalNetwork().Endpoint
 	pClient := platformvm.NewClient(api)
 	ctx, cancel := utils.GetAPIContext()
 	defer cancel()
@@ -874,7 +899,7 @@ func GetSubnetValidators(subnetID ids.ID) ([]platformvm.ClientPermissionlessVali
 }
 
 func CheckNodeIsInSubnetValidators(subnetID ids.ID, nodeID string) (bool, error) {
-	api := constants.LocalAPIEndpoint
+	api := models.NewLocalNetwork().Endpoint
 	pClient := platformvm.NewClient(api)
 	ctx, cancel := utils.GetAPIContext()
 	defer cancel()
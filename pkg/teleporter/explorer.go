@@ -0,0 +1,272 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type MessageStatus string
+
+const (
+	MessagePending   MessageStatus = "Pending"
+	MessageDelivered MessageStatus = "Delivered"
+	// delivered, but the execution at the destination address failed
+	MessageFailed MessageStatus = "Failed"
+)
+
+// Messenger is the teleporter messenger of a blockchain
+type Messenger struct {
+	BlockchainID     ids.ID
+	RPCURL           string
+	MessengerAddress common.Address
+}
+
+// MessageTrace pairs the send of a teleporter message with its delivery
+type MessageTrace struct {
+	MessageID               ids.ID
+	SourceBlockchainID      ids.ID
+	DestinationBlockchainID ids.ID
+	Nonce                   *big.Int
+	SendTx                  common.Hash
+	SendTime                time.Time
+	Status                  MessageStatus
+	// delivery info, only set if the message is not pending
+	DeliveryTx   common.Hash
+	DeliveryTime time.Time
+	Deliverer    common.Address
+}
+
+// Latency returns the time elapsed between the send and the delivery of the
+// message, or zero if it is still pending
+func (t MessageTrace) Latency() time.Duration {
+	if t.Status == MessagePending {
+		return 0
+	}
+	return t.DeliveryTime.Sub(t.SendTime)
+}
+
+// delivery events of a message found at its destination
+type messageDeliveryLogs struct {
+	receive        *types.Log
+	receiveEvent   *TeleporterMessengerReceiveCrossChainMessage
+	executed       bool
+	executionFails bool
+}
+
+// block timestamps obtained while exploring messages, cached by rpc url and block
+// number. One client is kept open for each rpc url
+type blockTimestamps struct {
+	clients    map[string]ethclient.Client
+	timestamps map[string]map[uint64]time.Time
+}
+
+func newBlockTimestamps() *blockTimestamps {
+	return &blockTimestamps{
+		clients:    map[string]ethclient.Client{},
+		timestamps: map[string]map[uint64]time.Time{},
+	}
+}
+
+// gets the timestamp of [blockNumber] on the blockchain at [rpcURL]
+func (b *blockTimestamps) get(rpcURL string, blockNumber uint64) (time.Time, error) {
+	if timestamp, ok := b.timestamps[rpcURL][blockNumber]; ok {
+		return timestamp, nil
+	}
+	client, ok := b.clients[rpcURL]
+	if !ok {
+		var err error
+		client, err = evm.GetClient(rpcURL)
+		if err != nil {
+			return time.Time{}, err
+		}
+		b.clients[rpcURL] = client
+	}
+	timestamp, err := evm.GetBlockTimestamp(client, blockNumber)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if _, ok := b.timestamps[rpcURL]; !ok {
+		b.timestamps[rpcURL] = map[uint64]time.Time{}
+	}
+	b.timestamps[rpcURL][blockNumber] = timestamp
+	return timestamp, nil
+}
+
+func (b *blockTimestamps) close() {
+	for _, client := range b.clients {
+		client.Close()
+	}
+}
+
+// ExploreMessages scans the messages sent by [sources] to any of [destinations], and
+// pairs them with their deliveries. Events are looked for starting at [fromBlock] on
+// every blockchain, that can be evm.FromRecentBlocks to only look at the recent ones.
+// Traces are returned sorted by send time
+func ExploreMessages(
+	sources []Messenger,
+	destinations []Messenger,
+	fromBlock uint64,
+) ([]MessageTrace, error) {
+	timestamps := newBlockTimestamps()
+	defer timestamps.close()
+	destinationsByID := map[ids.ID]Messenger{}
+	for _, destination := range destinations {
+		destinationsByID[destination.BlockchainID] = destination
+	}
+	traces := []MessageTrace{}
+	for _, source := range sources {
+		logs, err := getMessengerLogs(
+			source.RPCURL,
+			source.MessengerAddress,
+			fromBlock,
+			map[string][]int{sendCrossChainMessageEventEsp: {0, 1}},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failure getting messages sent from %s: %w", source.BlockchainID, err)
+		}
+		sourceTraces, err := getSendTraces(source, logs, destinationsByID, timestamps)
+		if err != nil {
+			return nil, err
+		}
+		traces = append(traces, sourceTraces...)
+	}
+	deliveries := map[ids.ID]*messageDeliveryLogs{}
+	for _, destination := range destinations {
+		logs, err := getMessengerLogs(
+			destination.RPCURL,
+			destination.MessengerAddress,
+			fromBlock,
+			deliveryEventEsps,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failure getting messages delivered to %s: %w", destination.BlockchainID, err)
+		}
+		if err := collectDeliveryLogs(logs, deliveries); err != nil {
+			return nil, err
+		}
+	}
+	if err := pairMessageTraces(traces, deliveries, destinationsByID, timestamps); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(traces, func(i, j int) bool {
+		return traces[i].SendTime.Before(traces[j].SendTime)
+	})
+	return traces, nil
+}
+
+// builds the traces of the messages sent at [logs] to any of [destinations]
+func getSendTraces(
+	source Messenger,
+	logs []types.Log,
+	destinations map[ids.ID]Messenger,
+	timestamps *blockTimestamps,
+) ([]MessageTrace, error) {
+	traces := []MessageTrace{}
+	for _, log := range logs {
+		event, err := ParseSendCrossChainMessage(log)
+		if err != nil {
+			return nil, err
+		}
+		destinationBlockchainID := ids.ID(event.DestinationBlockchainID)
+		if _, ok := destinations[destinationBlockchainID]; !ok {
+			continue
+		}
+		sendTime, err := timestamps.get(source.RPCURL, log.BlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		traces = append(traces, MessageTrace{
+			MessageID:               ids.ID(event.MessageID),
+			SourceBlockchainID:      source.BlockchainID,
+			DestinationBlockchainID: destinationBlockchainID,
+			Nonce:                   event.Message.MessageNonce,
+			SendTx:                  log.TxHash,
+			SendTime:                sendTime,
+			Status:                  MessagePending,
+		})
+	}
+	return traces, nil
+}
+
+// groups the delivery events at [logs] by message ID into [deliveries]
+func collectDeliveryLogs(
+	logs []types.Log,
+	deliveries map[ids.ID]*messageDeliveryLogs,
+) error {
+	receiveTopic, err := contract.GetEventTopic(receiveCrossChainMessageEventEsp, []int{0, 1, 2})
+	if err != nil {
+		return err
+	}
+	executedTopic, err := contract.GetEventTopic(messageExecutedEventEsp, []int{0, 1})
+	if err != nil {
+		return err
+	}
+	for i := range logs {
+		log := logs[i]
+		if len(log.Topics) < 2 {
+			continue
+		}
+		messageID := ids.ID(log.Topics[1])
+		delivery, ok := deliveries[messageID]
+		if !ok {
+			delivery = &messageDeliveryLogs{}
+			deliveries[messageID] = delivery
+		}
+		switch log.Topics[0] {
+		case receiveTopic:
+			event, err := ParseReceiveCrossChainMessage(log)
+			if err != nil {
+				return err
+			}
+			delivery.receive = &log
+			delivery.receiveEvent = event
+		case executedTopic:
+			delivery.executed = true
+		default:
+			delivery.executionFails = true
+		}
+	}
+	return nil
+}
+
+// sets the delivery info of [traces] from [deliveries]. A message is considered
+// failed if its execution failed and was not successfully retried afterwards
+func pairMessageTraces(
+	traces []MessageTrace,
+	deliveries map[ids.ID]*messageDeliveryLogs,
+	destinations map[ids.ID]Messenger,
+	timestamps *blockTimestamps,
+) error {
+	for i := range traces {
+		delivery, ok := deliveries[traces[i].MessageID]
+		if !ok || delivery.receive == nil {
+			continue
+		}
+		deliveryTime, err := timestamps.get(
+			destinations[traces[i].DestinationBlockchainID].RPCURL,
+			delivery.receive.BlockNumber,
+		)
+		if err != nil {
+			return err
+		}
+		traces[i].DeliveryTx = delivery.receive.TxHash
+		traces[i].DeliveryTime = deliveryTime
+		traces[i].Deliverer = delivery.receiveEvent.Deliverer
+		traces[i].Status = MessageDelivered
+		if delivery.executionFails && !delivery.executed {
+			traces[i].Status = MessageFailed
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectDeliveryLogs(t *testing.T) {
+	require := require.New(t)
+	executedTopic, err := contract.GetEventTopic(messageExecutedEventEsp, []int{0, 1})
+	require.NoError(err)
+	failedTopic, err := contract.GetEventTopic(messageExecutionFailedEventEsp, []int{0, 1})
+	require.NoError(err)
+	retriedID := ids.GenerateTestID()
+	failedID := ids.GenerateTestID()
+	logs := []types.Log{
+		{Topics: []common.Hash{failedTopic, common.Hash(retriedID)}},
+		{Topics: []common.Hash{executedTopic, common.Hash(retriedID)}},
+		{Topics: []common.Hash{failedTopic, common.Hash(failedID)}},
+		{Topics: []common.Hash{executedTopic}},
+	}
+	deliveries := map[ids.ID]*messageDeliveryLogs{}
+	require.NoError(collectDeliveryLogs(logs, deliveries))
+	require.Len(deliveries, 2)
+	require.True(deliveries[retriedID].executed)
+	require.True(deliveries[retriedID].executionFails)
+	require.False(deliveries[failedID].executed)
+	require.True(deliveries[failedID].executionFails)
+}
+
+func TestPairMessageTracesPending(t *testing.T) {
+	require := require.New(t)
+	messageID := ids.GenerateTestID()
+	traces := []MessageTrace{
+		{MessageID: messageID, Status: MessagePending},
+		{MessageID: ids.GenerateTestID(), Status: MessagePending},
+	}
+	// execution events without a receive event do not mark the message as delivered
+	deliveries := map[ids.ID]*messageDeliveryLogs{
+		messageID: {executed: true},
+	}
+	require.NoError(pairMessageTraces(traces, deliveries, map[ids.ID]Messenger{}, newBlockTimestamps()))
+	for _, trace := range traces {
+		require.Equal(MessagePending, trace.Status)
+		require.Zero(trace.Latency())
+	}
+}
+
+func TestMessageTraceLatency(t *testing.T) {
+	sendTime := time.Unix(1000, 0)
+	trace := MessageTrace{
+		SendTime:     sendTime,
+		DeliveryTime: sendTime.Add(3 * time.Second),
+		Status:       MessageDelivered,
+	}
+	require.Equal(t, 3*time.Second, trace.Latency())
+}
+
+func TestBlockTimestampsCache(t *testing.T) {
+	require := require.New(t)
+	timestamps := newBlockTimestamps()
+	defer timestamps.close()
+	blockTime := time.Unix(1000, 0)
+	timestamps.timestamps["http://unreachable"] = map[uint64]time.Time{10: blockTime}
+	// cached timestamps are returned without connecting to the blockchain
+	timestamp, err := timestamps.get("http://unreachable", 10)
+	require.NoError(err)
+	require.Equal(blockTime, timestamp)
+	require.Empty(timestamps.clients)
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/spf13/cobra"
+)
+
+// MessageFeeFlags are the flags to set the relayer incentives of the messages
+// sent by a command
+type MessageFeeFlags struct {
+	FeeToken         string
+	FeeAmount        string
+	RequiredGasLimit uint64
+	AllowedRelayers  []string
+}
+
+// AddMessageFeeFlagsToCmd adds the message fee flags to [cmd]. --allowed-relayers
+// is only added if [allowedRelayers] is set, as not all senders support it
+func AddMessageFeeFlagsToCmd(
+	cmd *cobra.Command,
+	messageFeeFlags *MessageFeeFlags,
+	allowedRelayers bool,
+) {
+	cmd.Flags().StringVar(
+		&messageFeeFlags.FeeToken,
+		"fee-token",
+		"",
+		"ERC20 token to pay the relayer fee with (address, or name of a CLI deployed token)",
+	)
+	cmd.Flags().StringVar(
+		&messageFeeFlags.FeeAmount,
+		"fee-amount",
+		"",
+		"relayer fee amount, in the fee token smallest unit",
+	)
+	cmd.Flags().Uint64Var(
+		&messageFeeFlags.RequiredGasLimit,
+		"required-gas-limit",
+		0,
+		"gas limit required to execute the message at the destination",
+	)
+	if allowedRelayers {
+		cmd.Flags().StringSliceVar(
+			&messageFeeFlags.AllowedRelayers,
+			"allowed-relayers",
+			nil,
+			"only allow the given relayer addresses to deliver the message",
+		)
+	}
+}
+
+// GetMessageOptions converts the flags into message options for a message sent from
+// [subnetName] (or C-Chain if [isCChain]). Fee tokens can be given by address or by
+// the name of a CLI deployed token of the subnet
+func (f MessageFeeFlags) GetMessageOptions(
+	app *application.Avalanche,
+	network models.Network,
+	subnetName string,
+	isCChain bool,
+) (MessageOptions, error) {
+	options := MessageOptions{}
+	if f.FeeAmount != "" {
+		amount, b := new(big.Int).SetString(f.FeeAmount, 10)
+		if !b || amount.Sign() < 0 {
+			return MessageOptions{}, fmt.Errorf("invalid fee amount %q: expected a non negative integer", f.FeeAmount)
+		}
+		options.FeeAmount = amount
+	}
+	if f.FeeToken != "" {
+		tokenAddress, err := contract.GetERC20TokenAddress(app, network, subnetName, isCChain, f.FeeToken)
+		if err != nil {
+			return MessageOptions{}, err
+		}
+		options.FeeTokenAddress = tokenAddress
+	}
+	if f.RequiredGasLimit != 0 {
+		options.RequiredGasLimit = new(big.Int).SetUint64(f.RequiredGasLimit)
+	}
+	for _, relayer := range f.AllowedRelayers {
+		if !common.IsHexAddress(relayer) {
+			return MessageOptions{}, fmt.Errorf("invalid allowed relayer address %q", relayer)
+		}
+		options.AllowedRelayerAddresses = append(options.AllowedRelayerAddresses, common.HexToAddress(relayer))
+	}
+	return options, nil
+}
@@ -0,0 +1,44 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMessageOptions(t *testing.T) {
+	require := require.New(t)
+	network := models.NewLocalNetwork()
+	options, err := MessageFeeFlags{}.GetMessageOptions(nil, network, "", true)
+	require.NoError(err)
+	require.Equal(MessageOptions{}, options)
+
+	tokenAddress := "0x5DB9A7629912EBF95876228C24A848de0bfB43A9"
+	relayerAddress := "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"
+	options, err = MessageFeeFlags{
+		FeeToken:         tokenAddress,
+		FeeAmount:        "1000000000000000000000",
+		RequiredGasLimit: 200000,
+		AllowedRelayers:  []string{relayerAddress},
+	}.GetMessageOptions(nil, network, "", true)
+	require.NoError(err)
+	feeAmount, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	require.Equal(MessageOptions{
+		FeeTokenAddress:         common.HexToAddress(tokenAddress),
+		FeeAmount:               feeAmount,
+		RequiredGasLimit:        big.NewInt(200000),
+		AllowedRelayerAddresses: []common.Address{common.HexToAddress(relayerAddress)},
+	}, options)
+
+	_, err = MessageFeeFlags{FeeAmount: "-1"}.GetMessageOptions(nil, network, "", true)
+	require.ErrorContains(err, "invalid fee amount")
+	_, err = MessageFeeFlags{FeeToken: "TOK"}.GetMessageOptions(nil, network, "", true)
+	require.ErrorContains(err, "is not an address")
+	_, err = MessageFeeFlags{AllowedRelayers: []string{"0x12"}}.GetMessageOptions(nil, network, "", true)
+	require.ErrorContains(err, "invalid allowed relayer address")
+}
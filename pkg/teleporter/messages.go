@@ -0,0 +1,438 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ava-labs/subnet-evm/interfaces"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	teleporterMessageEsp             = "(uint256,address,bytes32,address,uint256,[address],[(uint256,address)],bytes)"
+	sendCrossChainMessageEventEsp    = "SendCrossChainMessage(bytes32,bytes32," + teleporterMessageEsp + ",(address,uint256))"
+	receiveCrossChainMessageEventEsp = "ReceiveCrossChainMessage(bytes32,bytes32,address,address," + teleporterMessageEsp + ")"
+	messageExecutedEventEsp          = "MessageExecuted(bytes32,bytes32)"
+	messageExecutionFailedEventEsp   = "MessageExecutionFailed(bytes32,bytes32," + teleporterMessageEsp + ")"
+)
+
+var (
+	ErrMessageNotFound = errors.New("teleporter message not found")
+
+	// events emitted by the destination messenger when a message is delivered
+	deliveryEventEsps = map[string][]int{
+		receiveCrossChainMessageEventEsp: {0, 1, 2},
+		messageExecutedEventEsp:          {0, 1},
+		messageExecutionFailedEventEsp:   {0, 1},
+	}
+)
+
+type TeleporterMessengerReceiveCrossChainMessage struct {
+	MessageID          [32]byte
+	SourceBlockchainID [32]byte
+	Deliverer          common.Address
+	RewardRedeemer     common.Address
+	Message            TeleporterMessage
+}
+
+// MessageDelivery is the state of a teleporter message at its destination
+type MessageDelivery struct {
+	Received bool
+	// delivery tx info, only set if the delivery event was found
+	DeliveryTx     common.Hash
+	DeliveryBlock  uint64
+	Deliverer      common.Address
+	RewardRedeemer common.Address
+	// execution of the message by the destination address. A failed execution
+	// can be retried with RetryMessageExecution
+	Executed        bool
+	ExecutionFailed bool
+}
+
+func ParseReceiveCrossChainMessage(log types.Log) (*TeleporterMessengerReceiveCrossChainMessage, error) {
+	event := new(TeleporterMessengerReceiveCrossChainMessage)
+	if err := contract.UnpackLog(
+		receiveCrossChainMessageEventEsp,
+		[]int{0, 1, 2},
+		log,
+		event,
+	); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// SentMessage is a teleporter message sent on a tx, together with the messenger that sent it
+type SentMessage struct {
+	MessengerAddress common.Address
+	Event            *TeleporterMessengerSendCrossChainMessage
+}
+
+// GetSentMessages returns the teleporter messages sent on the tx of [receipt]
+func GetSentMessages(receipt *types.Receipt) ([]SentMessage, error) {
+	sendTopic, err := contract.GetEventTopic(sendCrossChainMessageEventEsp, []int{0, 1})
+	if err != nil {
+		return nil, err
+	}
+	messages := []SentMessage{}
+	for _, log := range receipt.Logs {
+		if len(log.Topics) == 0 || log.Topics[0] != sendTopic {
+			continue
+		}
+		event, err := ParseSendCrossChainMessage(*log)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, SentMessage{
+			MessengerAddress: log.Address,
+			Event:            event,
+		})
+	}
+	return messages, nil
+}
+
+// gets the logs of the events [eventEsps] emitted by [messengerAddress] starting at
+// [fromBlock], only for [messageIDs] if given
+func getMessengerLogs(
+	rpcURL string,
+	messengerAddress common.Address,
+	fromBlock uint64,
+	eventEsps map[string][]int,
+	messageIDs ...ids.ID,
+) ([]types.Log, error) {
+	topics := []common.Hash{}
+	for eventEsp, indexedFields := range eventEsps {
+		topic, err := contract.GetEventTopic(eventEsp, indexedFields)
+		if err != nil {
+			return nil, err
+		}
+		topics = append(topics, topic)
+	}
+	query := interfaces.FilterQuery{
+		Addresses: []common.Address{messengerAddress},
+		Topics:    [][]common.Hash{topics},
+	}
+	if len(messageIDs) > 0 {
+		messageIDTopics := []common.Hash{}
+		for _, messageID := range messageIDs {
+			messageIDTopics = append(messageIDTopics, common.Hash(messageID))
+		}
+		query.Topics = append(query.Topics, messageIDTopics)
+	}
+	client, err := evm.GetClient(rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	toBlock, err := evm.GetBlockNumber(client)
+	if err != nil {
+		return nil, err
+	}
+	return evm.GetLogsInRange(client, query, fromBlock, toBlock)
+}
+
+// GetMessageSend looks for the SendCrossChainMessage event of [messageID] on the source
+// blockchain at [rpcURL], starting at [fromBlock]. Returns ErrMessageNotFound if the message
+// was not sent from the blockchain
+func GetMessageSend(
+	rpcURL string,
+	messengerAddress common.Address,
+	messageID ids.ID,
+	fromBlock uint64,
+) (*TeleporterMessengerSendCrossChainMessage, types.Log, error) {
+	logs, err := getMessengerLogs(
+		rpcURL,
+		messengerAddress,
+		fromBlock,
+		map[string][]int{sendCrossChainMessageEventEsp: {0, 1}},
+		messageID,
+	)
+	if err != nil {
+		return nil, types.Log{}, err
+	}
+	if len(logs) == 0 {
+		return nil, types.Log{}, ErrMessageNotFound
+	}
+	event, err := ParseSendCrossChainMessage(logs[0])
+	if err != nil {
+		return nil, types.Log{}, err
+	}
+	return event, logs[0], nil
+}
+
+// GetMessageDelivery returns the state of [messageID] on the destination blockchain
+// at [rpcURL], looking for its delivery and execution events starting at [fromBlock]
+func GetMessageDelivery(
+	rpcURL string,
+	messengerAddress common.Address,
+	messageID ids.ID,
+	fromBlock uint64,
+) (MessageDelivery, error) {
+	delivery := MessageDelivery{}
+	received, err := MessageReceived(rpcURL, messengerAddress, messageID)
+	if err != nil {
+		return delivery, err
+	}
+	if !received {
+		return delivery, nil
+	}
+	delivery.Received = true
+	receiveTopic, err := contract.GetEventTopic(receiveCrossChainMessageEventEsp, []int{0, 1, 2})
+	if err != nil {
+		return delivery, err
+	}
+	executedTopic, err := contract.GetEventTopic(messageExecutedEventEsp, []int{0, 1})
+	if err != nil {
+		return delivery, err
+	}
+	logs, err := getMessengerLogs(
+		rpcURL,
+		messengerAddress,
+		fromBlock,
+		deliveryEventEsps,
+		messageID,
+	)
+	if err != nil {
+		return delivery, err
+	}
+	failed := false
+	for _, log := range logs {
+		switch log.Topics[0] {
+		case receiveTopic:
+			event, err := ParseReceiveCrossChainMessage(log)
+			if err != nil {
+				return delivery, err
+			}
+			delivery.DeliveryTx = log.TxHash
+			delivery.DeliveryBlock = log.BlockNumber
+			delivery.Deliverer = event.Deliverer
+			delivery.RewardRedeemer = event.RewardRedeemer
+		case executedTopic:
+			delivery.Executed = true
+		default:
+			failed = true
+		}
+	}
+	delivery.ExecutionFailed = failed && !delivery.Executed
+	return delivery, nil
+}
+
+// GetMessageHash returns the hash of the sent message [messageID], that is kept by the
+// source messenger until the delivery receipt is received, so fees can be added to it
+func GetMessageHash(
+	rpcURL string,
+	messengerAddress common.Address,
+	messageID ids.ID,
+) (common.Hash, error) {
+	out, err := contract.CallToMethod(
+		rpcURL,
+		messengerAddress,
+		"getMessageHash(bytes32)->(bytes32)",
+		messageID,
+	)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	hash, b := out[0].([32]byte)
+	if !b {
+		return common.Hash{}, fmt.Errorf("error at getMessageHash call, expected [32]byte, got %T", out[0])
+	}
+	return hash, nil
+}
+
+// GetRelayerRewardAddress returns the address that can redeem the relayer reward of the
+// received message [messageID]
+func GetRelayerRewardAddress(
+	rpcURL string,
+	messengerAddress common.Address,
+	messageID ids.ID,
+) (common.Address, error) {
+	out, err := contract.CallToMethod(
+		rpcURL,
+		messengerAddress,
+		"getRelayerRewardAddress(bytes32)->(address)",
+		messageID,
+	)
+	if err != nil {
+		return common.Address{}, err
+	}
+	address, b := out[0].(common.Address)
+	if !b {
+		return common.Address{}, fmt.Errorf("error at getRelayerRewardAddress call, expected common.Address, got %T", out[0])
+	}
+	return address, nil
+}
+
+// RetryMessageExecution executes again on the destination a received message whose
+// execution failed
+func RetryMessageExecution(
+	rpcURL string,
+	messengerAddress common.Address,
+	privateKey string,
+	sourceBlockchainID ids.ID,
+	message TeleporterMessage,
+) (*types.Transaction, *types.Receipt, error) {
+	return contract.TxToMethod(
+		rpcURL,
+		privateKey,
+		messengerAddress,
+		nil,
+		"retryMessageExecution(bytes32, (uint256, address, bytes32, address, uint256, [address], [(uint256, address)], bytes))",
+		sourceBlockchainID,
+		message,
+	)
+}
+
+// AddFeeAmount increases the relayer fee of the sent message [messageID], approving
+// first the messenger to spend [amount] of [feeTokenAddress]
+func AddFeeAmount(
+	rpcURL string,
+	messengerAddress common.Address,
+	privateKey string,
+	messageID ids.ID,
+	feeTokenAddress common.Address,
+	amount *big.Int,
+) (*types.Transaction, *types.Receipt, error) {
+	if _, _, err := contract.TxToMethod(
+		rpcURL,
+		privateKey,
+		feeTokenAddress,
+		nil,
+		"approve(address, uint256)->(bool)",
+		messengerAddress,
+		amount,
+	); err != nil {
+		return nil, nil, err
+	}
+	return contract.TxToMethod(
+		rpcURL,
+		privateKey,
+		messengerAddress,
+		nil,
+		"addFeeAmount(bytes32, address, uint256)",
+		messageID,
+		feeTokenAddress,
+		amount,
+	)
+}
+
+// GetReceiptQueue returns the receipts kept by the destination messenger for the messages
+// received from [sourceBlockchainID], waiting to be sent back to the source
+func GetReceiptQueue(
+	rpcURL string,
+	messengerAddress common.Address,
+	sourceBlockchainID ids.ID,
+) ([]TeleporterMessageReceipt, error) {
+	out, err := contract.CallToMethod(
+		rpcURL,
+		messengerAddress,
+		"getReceiptQueueSize(bytes32)->(uint256)",
+		sourceBlockchainID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	size, b := out[0].(*big.Int)
+	if !b {
+		return nil, fmt.Errorf("error at getReceiptQueueSize call, expected *big.Int, got %T", out[0])
+	}
+	receipts := []TeleporterMessageReceipt{}
+	for i := int64(0); i < size.Int64(); i++ {
+		out, err := contract.CallToMethod(
+			rpcURL,
+			messengerAddress,
+			"getReceiptAtIndex(bytes32, uint256)->((uint256, address))",
+			sourceBlockchainID,
+			big.NewInt(i),
+		)
+		if err != nil {
+			return nil, err
+		}
+		// output tuple is decoded into an unnamed struct
+		rv := reflect.ValueOf(out[0])
+		if rv.Kind() != reflect.Struct || rv.NumField() != 2 {
+			return nil, fmt.Errorf("error at getReceiptAtIndex call, expected (uint256, address) tuple, got %T", out[0])
+		}
+		nonce, b := rv.Field(0).Interface().(*big.Int)
+		if !b {
+			return nil, fmt.Errorf("error at getReceiptAtIndex call, expected *big.Int, got %s", rv.Field(0).Type())
+		}
+		rewardAddress, b := rv.Field(1).Interface().(common.Address)
+		if !b {
+			return nil, fmt.Errorf("error at getReceiptAtIndex call, expected common.Address, got %s", rv.Field(1).Type())
+		}
+		receipts = append(receipts, TeleporterMessageReceipt{
+			ReceivedMessageNonce: nonce,
+			RelayerRewardAddress: rewardAddress,
+		})
+	}
+	return receipts, nil
+}
+
+// CalculateMessageID returns the ID of the message sent from [sourceBlockchainID] to
+// [destinationBlockchainID] with [nonce]
+func CalculateMessageID(
+	rpcURL string,
+	messengerAddress common.Address,
+	sourceBlockchainID ids.ID,
+	destinationBlockchainID ids.ID,
+	nonce *big.Int,
+) (ids.ID, error) {
+	out, err := contract.CallToMethod(
+		rpcURL,
+		messengerAddress,
+		"calculateMessageID(bytes32, bytes32, uint256)->(bytes32)",
+		sourceBlockchainID,
+		destinationBlockchainID,
+		nonce,
+	)
+	if err != nil {
+		return ids.Empty, err
+	}
+	messageID, b := out[0].([32]byte)
+	if !b {
+		return ids.Empty, fmt.Errorf("error at calculateMessageID call, expected [32]byte, got %T", out[0])
+	}
+	return messageID, nil
+}
+
+// SendSpecifiedReceipts sends back to [sourceBlockchainID] the receipts of the received
+// messages [messageIDs], so the relayers that delivered them can redeem their rewards
+func SendSpecifiedReceipts(
+	rpcURL string,
+	messengerAddress common.Address,
+	privateKey string,
+	sourceBlockchainID ids.ID,
+	messageIDs []ids.ID,
+) (*types.Transaction, *types.Receipt, error) {
+	type FeeInfo struct {
+		FeeTokenAddress common.Address
+		Amount          *big.Int
+	}
+	messageIDsBytes := [][32]byte{}
+	for _, messageID := range messageIDs {
+		messageIDsBytes = append(messageIDsBytes, messageID)
+	}
+	return contract.TxToMethod(
+		rpcURL,
+		privateKey,
+		messengerAddress,
+		nil,
+		"sendSpecifiedReceipts(bytes32, [bytes32], (address, uint256), [address])->(bytes32)",
+		sourceBlockchainID,
+		messageIDsBytes,
+		FeeInfo{
+			FeeTokenAddress: common.Address{},
+			Amount:          big.NewInt(0),
+		},
+		[]common.Address{},
+	)
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/subnet-evm/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReceiveCrossChainMessage(t *testing.T) {
+	require := require.New(t)
+	event, err := contract.GetEvent(receiveCrossChainMessageEventEsp, []int{0, 1, 2})
+	require.NoError(err)
+	messageID := common.HexToHash("0x01")
+	sourceBlockchainID := common.HexToHash("0x02")
+	deliverer := common.HexToAddress("0x03")
+	rewardRedeemer := common.HexToAddress("0x04")
+	type receipt struct {
+		Field0 *big.Int
+		Field1 common.Address
+	}
+	type message struct {
+		Field0 *big.Int
+		Field1 common.Address
+		Field2 [32]byte
+		Field3 common.Address
+		Field4 *big.Int
+		Field5 []common.Address
+		Field6 []receipt
+		Field7 []byte
+	}
+	data, err := event.Inputs.NonIndexed().Pack(
+		rewardRedeemer,
+		message{
+			Field0: big.NewInt(7),
+			Field1: common.HexToAddress("0x05"),
+			Field3: common.HexToAddress("0x06"),
+			Field4: big.NewInt(100000),
+			Field5: []common.Address{},
+			Field6: []receipt{{Field0: big.NewInt(3), Field1: common.HexToAddress("0x07")}},
+			Field7: []byte("hello"),
+		},
+	)
+	require.NoError(err)
+	log := types.Log{
+		Topics: []common.Hash{
+			event.ID,
+			messageID,
+			sourceBlockchainID,
+			common.BytesToHash(deliverer.Bytes()),
+		},
+		Data: data,
+	}
+	parsed, err := ParseReceiveCrossChainMessage(log)
+	require.NoError(err)
+	require.Equal([32]byte(messageID), parsed.MessageID)
+	require.Equal([32]byte(sourceBlockchainID), parsed.SourceBlockchainID)
+	require.Equal(deliverer, parsed.Deliverer)
+	require.Equal(rewardRedeemer, parsed.RewardRedeemer)
+	require.Equal(big.NewInt(7), parsed.Message.MessageNonce)
+	require.Equal(common.HexToAddress("0x06"), parsed.Message.DestinationAddress)
+	require.Len(parsed.Message.Receipts, 1)
+	require.Equal(common.HexToAddress("0x07"), parsed.Message.Receipts[0].RelayerRewardAddress)
+	require.Equal([]byte("hello"), parsed.Message.Message)
+}
+
+func TestGetSentMessages(t *testing.T) {
+	require := require.New(t)
+	event, err := contract.GetEvent(sendCrossChainMessageEventEsp, []int{0, 1})
+	require.NoError(err)
+	type receipt struct {
+		Field0 *big.Int
+		Field1 common.Address
+	}
+	type message struct {
+		Field0 *big.Int
+		Field1 common.Address
+		Field2 [32]byte
+		Field3 common.Address
+		Field4 *big.Int
+		Field5 []common.Address
+		Field6 []receipt
+		Field7 []byte
+	}
+	type feeInfo struct {
+		Field0 common.Address
+		Field1 *big.Int
+	}
+	data, err := event.Inputs.NonIndexed().Pack(
+		message{
+			Field0: big.NewInt(1),
+			Field4: big.NewInt(100000),
+			Field5: []common.Address{},
+			Field6: []receipt{},
+			Field7: []byte("transfer"),
+		},
+		feeInfo{Field1: big.NewInt(0)},
+	)
+	require.NoError(err)
+	messengerAddress := common.HexToAddress("0x0a")
+	messageID := common.HexToHash("0x01")
+	destinationBlockchainID := common.HexToHash("0x02")
+	sendLog := &types.Log{
+		Address: messengerAddress,
+		Topics:  []common.Hash{event.ID, messageID, destinationBlockchainID},
+		Data:    data,
+	}
+	// other events on the tx, as an ERC20 transfer, are skipped
+	otherLog := &types.Log{
+		Address: common.HexToAddress("0x0b"),
+		Topics:  []common.Hash{common.HexToHash("0x0c")},
+	}
+	messages, err := GetSentMessages(&types.Receipt{Logs: []*types.Log{otherLog, sendLog}})
+	require.NoError(err)
+	require.Len(messages, 1)
+	require.Equal(messengerAddress, messages[0].MessengerAddress)
+	require.Equal([32]byte(messageID), messages[0].Event.MessageID)
+	require.Equal([32]byte(destinationBlockchainID), messages[0].Event.DestinationBlockchainID)
+	require.Equal([]byte("transfer"), messages[0].Event.Message.Message)
+}
@@ -55,6 +55,18 @@ func MessageReceived(
 	return received, nil
 }
 
+// MessageOptions are the optional params of a teleporter message: the fee offered to
+// relayers for its delivery, the gas limit required to execute it at the destination,
+// and the relayers allowed to deliver it (any relayer if empty)
+type MessageOptions struct {
+	FeeTokenAddress         common.Address
+	FeeAmount               *big.Int
+	RequiredGasLimit        *big.Int
+	AllowedRelayerAddresses []common.Address
+}
+
+// SendCrossChainMessage sends [message] to [destinationAddress] on [destinationBlockchainID].
+// If [options] includes a fee, the messenger is first approved to take it from the sender
 func SendCrossChainMessage(
 	rpcURL string,
 	messengerAddress common.Address,
@@ -62,6 +74,7 @@ func SendCrossChainMessage(
 	destinationBlockchainID ids.ID,
 	destinationAddress common.Address,
 	message []byte,
+	options MessageOptions,
 ) (*types.Transaction, *types.Receipt, error) {
 	type FeeInfo struct {
 		FeeTokenAddress common.Address
@@ -75,15 +88,43 @@ func SendCrossChainMessage(
 		AllowedRelayerAddresses []common.Address
 		Message                 []byte
 	}
+	feeAmount := options.FeeAmount
+	if feeAmount == nil {
+		feeAmount = big.NewInt(0)
+	}
+	if feeAmount.Sign() > 0 {
+		if options.FeeTokenAddress == (common.Address{}) {
+			return nil, nil, fmt.Errorf("a fee token address is needed to pay the message fee")
+		}
+		if _, _, err := contract.TxToMethod(
+			rpcURL,
+			privateKey,
+			options.FeeTokenAddress,
+			nil,
+			"approve(address, uint256)->(bool)",
+			messengerAddress,
+			feeAmount,
+		); err != nil {
+			return nil, nil, err
+		}
+	}
+	requiredGasLimit := options.RequiredGasLimit
+	if requiredGasLimit == nil {
+		requiredGasLimit = big.NewInt(1)
+	}
+	allowedRelayerAddresses := options.AllowedRelayerAddresses
+	if allowedRelayerAddresses == nil {
+		allowedRelayerAddresses = []common.Address{}
+	}
 	params := Params{
 		DestinationBlockchainID: destinationBlockchainID,
 		DestinationAddress:      destinationAddress,
 		FeeInfo: FeeInfo{
-			FeeTokenAddress: common.Address{},
-			Amount:          big.NewInt(0),
+			FeeTokenAddress: options.FeeTokenAddress,
+			Amount:          feeAmount,
 		},
-		RequiredGasLimit:        big.NewInt(1),
-		AllowedRelayerAddresses: []common.Address{},
+		RequiredGasLimit:        requiredGasLimit,
+		AllowedRelayerAddresses: allowedRelayerAddresses,
 		Message:                 message,
 	}
 	return contract.TxToMethod(
@@ -126,7 +167,7 @@ type TeleporterMessengerSendCrossChainMessage struct {
 func ParseSendCrossChainMessage(log types.Log) (*TeleporterMessengerSendCrossChainMessage, error) {
 	event := new(TeleporterMessengerSendCrossChainMessage)
 	if err := contract.UnpackLog(
-		"SendCrossChainMessage(bytes32,bytes32,(uint256,address,bytes32,address,uint256,[address],[(uint256,address)],bytes),(address,uint256))",
+		sendCrossChainMessageEventEsp,
 		[]int{0, 1},
 		log,
 		event,
@@ -24,9 +24,7 @@ import (
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
-	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/awm-relayer/config"
-	offchainregistry "github.com/ava-labs/awm-relayer/messages/off-chain-registry"
 )
 
 const (
@@ -278,28 +276,16 @@ func UpdateRelayerConfig(
 	teleporterContractAddress string,
 	teleporterRegistryAddress string,
 ) error {
-	awmRelayerConfig := config.Config{}
-	if utils.FileExists(relayerConfigPath) {
-		bs, err := os.ReadFile(relayerConfigPath)
-		if err != nil {
-			return err
-		}
-		if err := json.Unmarshal(bs, &awmRelayerConfig); err != nil {
-			return err
-		}
-	} else {
-		awmRelayerConfig = createRelayerConfig(
-			logging.Info.LowerString(),
-			relayerStorageDir,
-			network.Endpoint,
-		)
+	awmRelayerConfig, err := LoadRelayerConfig(relayerConfigPath, relayerStorageDir, network)
+	if err != nil {
+		return err
 	}
 	host, port, _, err := utils.GetURIHostPortAndPath(network.Endpoint)
 	if err != nil {
 		return err
 	}
 	addChainToRelayerConfig(
-		&awmRelayerConfig,
+		awmRelayerConfig,
 		host,
 		port,
 		subnetID,
@@ -319,19 +305,21 @@ func UpdateRelayerConfig(
 	return nil
 }
 
+// creates an empty relayer config for [network]. Relayers of local networks get
+// their ports from the local network instance, so they can run side by side
 func createRelayerConfig(
 	logLevel string,
 	storageLocation string,
-	endpoint string,
+	network models.Network,
 ) config.Config {
-	return config.Config{
+	relayerConfig := config.Config{
 		LogLevel: logLevel,
 		PChainAPI: &config.APIConfig{
-			BaseURL:     endpoint,
+			BaseURL:     network.Endpoint,
 			QueryParams: map[string]string{},
 		},
 		InfoAPI: &config.APIConfig{
-			BaseURL:     endpoint,
+			BaseURL:     network.Endpoint,
 			QueryParams: map[string]string{},
 		},
 		StorageLocation:        storageLocation,
@@ -340,6 +328,12 @@ func createRelayerConfig(
 		DestinationBlockchains: []*config.DestinationBlockchain{},
 		MetricsPort:            constants.AWMRelayerMetricsPort,
 	}
+	if network.Kind == models.Local {
+		instance := models.GetLocalNetworkInstance()
+		relayerConfig.APIPort = uint16(instance.RelayerAPIPort())
+		relayerConfig.MetricsPort = uint16(instance.RelayerMetricsPort())
+	}
+	return relayerConfig
 }
 
 func addChainToRelayerConfig(
@@ -353,40 +347,23 @@ func addChainToRelayerConfig(
 	relayerRewardAddress string,
 	relayerFundedAddressKey string,
 ) {
-	source := &config.SourceBlockchain{
-		SubnetID:     subnetID,
-		BlockchainID: blockchainID,
-		VM:           config.EVM.String(),
-		RPCEndpoint: config.APIConfig{
-			BaseURL: fmt.Sprintf("http://%s:%d/ext/bc/%s/rpc", host, port, blockchainID),
-		},
-		WSEndpoint: config.APIConfig{
-			BaseURL: fmt.Sprintf("ws://%s:%d/ext/bc/%s/ws", host, port, blockchainID),
-		},
-		MessageContracts: map[string]config.MessageProtocolConfig{
-			teleporterContractAddress: {
-				MessageFormat: config.TELEPORTER.String(),
-				Settings: map[string]interface{}{
-					"reward-address": relayerRewardAddress,
-				},
-			},
-			offchainregistry.OffChainRegistrySourceAddress.Hex(): {
-				MessageFormat: config.OFF_CHAIN_REGISTRY.String(),
-				Settings: map[string]interface{}{
-					"teleporter-registry-address": teleporterRegistryAddress,
-				},
-			},
-		},
-	}
-	destination := &config.DestinationBlockchain{
-		SubnetID:     subnetID,
-		BlockchainID: blockchainID,
-		VM:           config.EVM.String(),
-		RPCEndpoint: config.APIConfig{
-			BaseURL: fmt.Sprintf("http://%s:%d/ext/bc/%s/rpc", host, port, blockchainID),
-		},
-		AccountPrivateKey: relayerFundedAddressKey,
-	}
+	source := newRelayerSource(
+		host,
+		port,
+		subnetID,
+		blockchainID,
+		teleporterContractAddress,
+		teleporterRegistryAddress,
+		relayerRewardAddress,
+		RelayerSourceSettings{},
+	)
+	destination := newRelayerDestination(
+		host,
+		port,
+		subnetID,
+		blockchainID,
+		RelayerDestinationKey{PrivateKey: relayerFundedAddressKey},
+	)
 	if !utils.Any(relayerConfig.SourceBlockchains, func(s *config.SourceBlockchain) bool { return s.BlockchainID == blockchainID }) {
 		relayerConfig.SourceBlockchains = append(relayerConfig.SourceBlockchains, source)
 	}
@@ -0,0 +1,141 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/localnet"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/node"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RelayerAccess gives access to the configuration, endpoints and database of a relayer
+// managed by CLI, either on the local network or on a cloud node
+type RelayerAccess struct {
+	Network models.Network
+	// local path of the relayer config. for a cloud relayer, the copy that CLI
+	// uploads to the node
+	ConfigPath string
+	StorageDir string
+	// cloud only
+	Host            *models.Host
+	NodeInstanceDir string
+}
+
+// GetRelayerAccess returns the access to the relayer managed by CLI on [network]
+func GetRelayerAccess(app *application.Avalanche, network models.Network) (RelayerAccess, error) {
+	switch {
+	case network.Kind == models.Local:
+		clusterInfo, err := localnet.GetClusterInfo()
+		if err != nil {
+			return RelayerAccess{}, err
+		}
+		return RelayerAccess{
+			Network:    network,
+			ConfigPath: filepath.Join(clusterInfo.GetRootDataDir(), constants.AWMRelayerConfigFilename),
+			StorageDir: app.GetAWMRelayerStorageDir(),
+		}, nil
+	case network.ClusterName != "":
+		host, err := node.GetAWMRelayerHost(app, network.ClusterName)
+		if err != nil {
+			return RelayerAccess{}, err
+		}
+		nodeInstanceDir := app.GetNodeInstanceDirPath(host.GetCloudID())
+		configPath := app.GetAWMRelayerServiceConfigPath(nodeInstanceDir)
+		if err := os.MkdirAll(filepath.Dir(configPath), constants.DefaultPerms755); err != nil {
+			return RelayerAccess{}, err
+		}
+		return RelayerAccess{
+			Network:         network,
+			ConfigPath:      configPath,
+			StorageDir:      app.GetAWMRelayerServiceStorageDir(constants.AWMRelayerDockerDir),
+			Host:            host,
+			NodeInstanceDir: nodeInstanceDir,
+		}, nil
+	default:
+		return RelayerAccess{}, fmt.Errorf("unsupported network %s", network.Name())
+	}
+}
+
+// IsCloud tells if the relayer runs on a cloud node
+func (a RelayerAccess) IsCloud() bool {
+	return a.Host != nil
+}
+
+// LoadConfig loads the relayer config managed by CLI, or creates a new one if it
+// does not exist
+func (a RelayerAccess) LoadConfig() (*config.Config, error) {
+	return LoadRelayerConfig(a.ConfigPath, a.StorageDir, a.Network)
+}
+
+// LoadRunningConfig loads the config the relayer runs with. For a cloud relayer,
+// it is read from the node
+func (a RelayerAccess) LoadRunningConfig() (*config.Config, error) {
+	var (
+		configBytes []byte
+		err         error
+	)
+	if a.IsCloud() {
+		configBytes, err = a.Host.ReadFileBytes(
+			filepath.Join(a.cloudRelayerDir(), constants.AWMRelayerConfigFilename),
+			constants.SSHFileOpsTimeout,
+		)
+	} else {
+		configBytes, err = os.ReadFile(a.ConfigPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	relayerConfig := config.Config{}
+	if err := json.Unmarshal(configBytes, &relayerConfig); err != nil {
+		return nil, fmt.Errorf("invalid relayer config: %w", err)
+	}
+	return &relayerConfig, nil
+}
+
+// Get returns the response of the relayer endpoint [path] on [port]. The relayer
+// answers with an error status if it is not healthy, so the body is returned anyway
+func (a RelayerAccess) Get(port uint16, path string) ([]byte, error) {
+	if a.IsCloud() {
+		return a.Host.Command(fmt.Sprintf("curl -s http://localhost:%d%s", port, path), nil, constants.SSHScriptTimeout)
+	}
+	resp, err := http.Get(fmt.Sprintf("http://localhost:%d%s", port, path))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// ReadRouteState returns the content of the relayer database file of [routeID],
+// or nil if it does not exist
+func (a RelayerAccess) ReadRouteState(routeID common.Hash) ([]byte, error) {
+	if a.IsCloud() {
+		statePath := filepath.Join(a.cloudRelayerDir(), constants.AWMRelayerStorageDir, routeID.String()+".json")
+		exists, err := a.Host.FileExists(statePath)
+		if err != nil || !exists {
+			return nil, err
+		}
+		return a.Host.ReadFileBytes(statePath, constants.SSHFileOpsTimeout)
+	}
+	statePath := filepath.Join(a.StorageDir, routeID.String()+".json")
+	if !utils.FileExists(statePath) {
+		return nil, nil
+	}
+	return os.ReadFile(statePath)
+}
+
+func (a RelayerAccess) cloudRelayerDir() string {
+	return filepath.Join(constants.CloudNodeCLIConfigBasePath, constants.ServicesDir, constants.AWMRelayerInstallDir)
+}
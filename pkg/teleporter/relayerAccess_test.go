@@ -0,0 +1,37 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalRelayerAccess(t *testing.T) {
+	require := require.New(t)
+	access := RelayerAccess{
+		Network:    models.NewLocalNetwork(),
+		ConfigPath: filepath.Join(t.TempDir(), "config.json"),
+		StorageDir: t.TempDir(),
+	}
+	require.False(access.IsCloud())
+	routeID := common.Hash{1}
+	state, err := access.ReadRouteState(routeID)
+	require.NoError(err)
+	require.Nil(state)
+	require.NoError(os.WriteFile(filepath.Join(access.StorageDir, routeID.String()+".json"), []byte("{}"), 0o600))
+	state, err = access.ReadRouteState(routeID)
+	require.NoError(err)
+	require.Equal([]byte("{}"), state)
+	_, err = access.LoadRunningConfig()
+	require.ErrorIs(err, os.ErrNotExist)
+	// the managed config is created if it does not exist
+	relayerConfig, err := access.LoadConfig()
+	require.NoError(err)
+	require.Equal(access.StorageDir, relayerConfig.StorageLocation)
+}
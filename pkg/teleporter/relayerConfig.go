@@ -0,0 +1,298 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanche-cli/pkg/constants"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/awm-relayer/config"
+	offchainregistry "github.com/ava-labs/awm-relayer/messages/off-chain-registry"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// message formats a relayer source can be set to relay
+var RelayerMessageFormats = []string{
+	config.TELEPORTER.String(),
+	config.OFF_CHAIN_REGISTRY.String(),
+}
+
+// RelayerSourceSettings restricts the messages relayed from a source blockchain
+type RelayerSourceSettings struct {
+	// message formats to relay. All of them if empty
+	MessageFormats []string
+	// only relay messages sent by these addresses. Any sender if empty
+	AllowedOriginSenders []string
+	// only relay to these destinations (and addresses). Every destination if empty
+	SupportedDestinations []*config.SupportedDestination
+	// process the messages sent since this height when the relayer starts
+	ProcessFromHeight uint64
+}
+
+// RelayerDestinationKey is the key the relayer uses to deliver messages on a
+// destination, either a private key or an AWS KMS one
+type RelayerDestinationKey struct {
+	PrivateKey   string
+	KMSKeyID     string
+	KMSAWSRegion string
+}
+
+func newRelayerSource(
+	host string,
+	port uint32,
+	subnetID string,
+	blockchainID string,
+	teleporterContractAddress string,
+	teleporterRegistryAddress string,
+	relayerRewardAddress string,
+	settings RelayerSourceSettings,
+) *config.SourceBlockchain {
+	messageFormats := settings.MessageFormats
+	if len(messageFormats) == 0 {
+		messageFormats = RelayerMessageFormats
+	}
+	messageContracts := map[string]config.MessageProtocolConfig{}
+	if utils.Belongs(messageFormats, config.TELEPORTER.String()) {
+		messageContracts[teleporterContractAddress] = config.MessageProtocolConfig{
+			MessageFormat: config.TELEPORTER.String(),
+			Settings: map[string]interface{}{
+				"reward-address": relayerRewardAddress,
+			},
+		}
+	}
+	if utils.Belongs(messageFormats, config.OFF_CHAIN_REGISTRY.String()) {
+		messageContracts[offchainregistry.OffChainRegistrySourceAddress.Hex()] = config.MessageProtocolConfig{
+			MessageFormat: config.OFF_CHAIN_REGISTRY.String(),
+			Settings: map[string]interface{}{
+				"teleporter-registry-address": teleporterRegistryAddress,
+			},
+		}
+	}
+	return &config.SourceBlockchain{
+		SubnetID:     subnetID,
+		BlockchainID: blockchainID,
+		VM:           config.EVM.String(),
+		RPCEndpoint: config.APIConfig{
+			BaseURL: fmt.Sprintf("http://%s:%d/ext/bc/%s/rpc", host, port, blockchainID),
+		},
+		WSEndpoint: config.APIConfig{
+			BaseURL: fmt.Sprintf("ws://%s:%d/ext/bc/%s/ws", host, port, blockchainID),
+		},
+		MessageContracts:                  messageContracts,
+		SupportedDestinations:             settings.SupportedDestinations,
+		ProcessHistoricalBlocksFromHeight: settings.ProcessFromHeight,
+		AllowedOriginSenderAddresses:      settings.AllowedOriginSenders,
+	}
+}
+
+func newRelayerDestination(
+	host string,
+	port uint32,
+	subnetID string,
+	blockchainID string,
+	key RelayerDestinationKey,
+) *config.DestinationBlockchain {
+	return &config.DestinationBlockchain{
+		SubnetID:     subnetID,
+		BlockchainID: blockchainID,
+		VM:           config.EVM.String(),
+		RPCEndpoint: config.APIConfig{
+			BaseURL: fmt.Sprintf("http://%s:%d/ext/bc/%s/rpc", host, port, blockchainID),
+		},
+		AccountPrivateKey: key.PrivateKey,
+		KMSKeyID:          key.KMSKeyID,
+		KMSAWSRegion:      key.KMSAWSRegion,
+	}
+}
+
+// LoadRelayerConfig loads the relayer config at [relayerConfigPath], or creates a new
+// one for [network] if it does not exist
+func LoadRelayerConfig(
+	relayerConfigPath string,
+	relayerStorageDir string,
+	network models.Network,
+) (*config.Config, error) {
+	if !utils.FileExists(relayerConfigPath) {
+		relayerConfig := createRelayerConfig(
+			logging.Info.LowerString(),
+			relayerStorageDir,
+			network,
+		)
+		return &relayerConfig, nil
+	}
+	bs, err := os.ReadFile(relayerConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	relayerConfig := config.Config{}
+	if err := json.Unmarshal(bs, &relayerConfig); err != nil {
+		return nil, fmt.Errorf("invalid relayer config %s: %w", relayerConfigPath, err)
+	}
+	return &relayerConfig, nil
+}
+
+// ValidateRelayerConfig checks [relayerConfig] as the relayer does at startup
+func ValidateRelayerConfig(relayerConfig *config.Config) error {
+	_, err := getValidatedRelayerConfig(relayerConfig)
+	return err
+}
+
+// the relayer validation fills some empty fields, as the supported destinations
+// of the sources, so a copy is validated and returned
+func getValidatedRelayerConfig(relayerConfig *config.Config) (*config.Config, error) {
+	bs, err := json.Marshal(relayerConfig)
+	if err != nil {
+		return nil, err
+	}
+	relayerConfigCopy := config.Config{}
+	if err := json.Unmarshal(bs, &relayerConfigCopy); err != nil {
+		return nil, err
+	}
+	if err := relayerConfigCopy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid relayer config: %w", err)
+	}
+	return &relayerConfigCopy, nil
+}
+
+// SaveRelayerConfig validates [relayerConfig] and writes it to [relayerConfigPath]
+func SaveRelayerConfig(
+	relayerConfigPath string,
+	relayerConfig *config.Config,
+) error {
+	if err := ValidateRelayerConfig(relayerConfig); err != nil {
+		return err
+	}
+	bs, err := json.MarshalIndent(relayerConfig, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(relayerConfigPath, bs, constants.WriteReadReadPerms)
+}
+
+// SetRelayerSource adds [blockchainID] as a source of [relayerConfig], or replaces
+// its settings if it is already one
+func SetRelayerSource(
+	relayerConfig *config.Config,
+	network models.Network,
+	subnetID string,
+	blockchainID string,
+	teleporterContractAddress string,
+	teleporterRegistryAddress string,
+	relayerRewardAddress string,
+	settings RelayerSourceSettings,
+) error {
+	for _, messageFormat := range settings.MessageFormats {
+		if !utils.Belongs(RelayerMessageFormats, messageFormat) {
+			return fmt.Errorf("unsupported message format %q: expected one of %v", messageFormat, RelayerMessageFormats)
+		}
+	}
+	for _, address := range settings.AllowedOriginSenders {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("invalid origin sender address %q", address)
+		}
+	}
+	host, port, _, err := utils.GetURIHostPortAndPath(network.Endpoint)
+	if err != nil {
+		return err
+	}
+	source := newRelayerSource(
+		host,
+		port,
+		subnetID,
+		blockchainID,
+		teleporterContractAddress,
+		teleporterRegistryAddress,
+		relayerRewardAddress,
+		settings,
+	)
+	for i := range relayerConfig.SourceBlockchains {
+		if relayerConfig.SourceBlockchains[i].BlockchainID == blockchainID {
+			relayerConfig.SourceBlockchains[i] = source
+			return nil
+		}
+	}
+	relayerConfig.SourceBlockchains = append(relayerConfig.SourceBlockchains, source)
+	return nil
+}
+
+// SetRelayerDestination adds [blockchainID] as a destination of [relayerConfig], or
+// replaces its key if it is already one
+func SetRelayerDestination(
+	relayerConfig *config.Config,
+	network models.Network,
+	subnetID string,
+	blockchainID string,
+	key RelayerDestinationKey,
+) error {
+	host, port, _, err := utils.GetURIHostPortAndPath(network.Endpoint)
+	if err != nil {
+		return err
+	}
+	destination := newRelayerDestination(host, port, subnetID, blockchainID, key)
+	for i := range relayerConfig.DestinationBlockchains {
+		if relayerConfig.DestinationBlockchains[i].BlockchainID == blockchainID {
+			relayerConfig.DestinationBlockchains[i] = destination
+			return nil
+		}
+	}
+	relayerConfig.DestinationBlockchains = append(relayerConfig.DestinationBlockchains, destination)
+	return nil
+}
+
+// RemoveRelayerSource removes [blockchainID] from the sources of [relayerConfig]
+func RemoveRelayerSource(
+	relayerConfig *config.Config,
+	blockchainID string,
+) error {
+	for i, source := range relayerConfig.SourceBlockchains {
+		if source.BlockchainID == blockchainID {
+			relayerConfig.SourceBlockchains = append(relayerConfig.SourceBlockchains[:i], relayerConfig.SourceBlockchains[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("blockchain %s is not a source of the relayer", blockchainID)
+}
+
+// RemoveRelayerDestination removes [blockchainID] from the destinations of [relayerConfig],
+// and from the supported destinations of its sources. Fails if a source would be
+// left with no supported destination, as that means relaying to every one
+func RemoveRelayerDestination(
+	relayerConfig *config.Config,
+	blockchainID string,
+) error {
+	index := -1
+	for i, destination := range relayerConfig.DestinationBlockchains {
+		if destination.BlockchainID == blockchainID {
+			index = i
+		}
+	}
+	if index == -1 {
+		return fmt.Errorf("blockchain %s is not a destination of the relayer", blockchainID)
+	}
+	sourcesSupportedDestinations := map[*config.SourceBlockchain][]*config.SupportedDestination{}
+	for _, source := range relayerConfig.SourceBlockchains {
+		if len(source.SupportedDestinations) == 0 {
+			continue
+		}
+		supportedDestinations := []*config.SupportedDestination{}
+		for _, supportedDestination := range source.SupportedDestinations {
+			if supportedDestination.BlockchainID != blockchainID {
+				supportedDestinations = append(supportedDestinations, supportedDestination)
+			}
+		}
+		if len(supportedDestinations) == 0 {
+			return fmt.Errorf("source %s only relays to %s: remove it, or change its destinations first", source.BlockchainID, blockchainID)
+		}
+		sourcesSupportedDestinations[source] = supportedDestinations
+	}
+	for source, supportedDestinations := range sourcesSupportedDestinations {
+		source.SupportedDestinations = supportedDestinations
+	}
+	relayerConfig.DestinationBlockchains = append(relayerConfig.DestinationBlockchains[:index], relayerConfig.DestinationBlockchains[index+1:]...)
+	return nil
+}
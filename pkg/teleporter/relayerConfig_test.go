@@ -0,0 +1,90 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/stretchr/testify/require"
+)
+
+const testRelayerPrivateKey = "56289e99c94b6912bfc12adc093c9b51124f0dc54ac7a766b2bc5ccf558d8027"
+
+func newTestRelayerConfig(t *testing.T) (*config.Config, models.Network, string, string) {
+	require := require.New(t)
+	network := models.NewLocalNetwork()
+	relayerConfig, err := LoadRelayerConfig(filepath.Join(t.TempDir(), "config.json"), t.TempDir(), network)
+	require.NoError(err)
+	subnetID := ids.GenerateTestID().String()
+	blockchainID := ids.GenerateTestID().String()
+	return relayerConfig, network, subnetID, blockchainID
+}
+
+func TestSetRelayerSourceAndDestination(t *testing.T) {
+	require := require.New(t)
+	relayerConfig, network, subnetID, blockchainID := newTestRelayerConfig(t)
+	messenger := "0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf"
+	registry := "0x17aB05351fC94a1a67Bf3f56DdbB941aE6c63E25"
+	reward := "0x0000000000000000000000000000000000000001"
+	require.NoError(SetRelayerSource(relayerConfig, network, subnetID, blockchainID, messenger, registry, reward, RelayerSourceSettings{}))
+	require.NoError(SetRelayerDestination(relayerConfig, network, subnetID, blockchainID, RelayerDestinationKey{PrivateKey: testRelayerPrivateKey}))
+	require.NoError(ValidateRelayerConfig(relayerConfig))
+	require.Len(relayerConfig.SourceBlockchains, 1)
+	require.Len(relayerConfig.SourceBlockchains[0].MessageContracts, 2)
+	// setting it again updates the existing source
+	require.NoError(SetRelayerSource(relayerConfig, network, subnetID, blockchainID, messenger, registry, reward, RelayerSourceSettings{
+		MessageFormats:       []string{config.TELEPORTER.String()},
+		AllowedOriginSenders: []string{reward},
+		SupportedDestinations: []*config.SupportedDestination{
+			{BlockchainID: blockchainID},
+		},
+		ProcessFromHeight: 10,
+	}))
+	require.NoError(ValidateRelayerConfig(relayerConfig))
+	require.Len(relayerConfig.SourceBlockchains, 1)
+	source := relayerConfig.SourceBlockchains[0]
+	require.Len(source.MessageContracts, 1)
+	require.Equal(config.TELEPORTER.String(), source.MessageContracts[messenger].MessageFormat)
+	require.Equal([]string{reward}, source.AllowedOriginSenderAddresses)
+	require.Equal(uint64(10), source.ProcessHistoricalBlocksFromHeight)
+	// validation does not modify the config
+	require.Len(source.SupportedDestinations, 1)
+	require.Empty(source.SupportedDestinations[0].Addresses)
+
+	require.Error(SetRelayerSource(relayerConfig, network, subnetID, blockchainID, messenger, registry, reward, RelayerSourceSettings{
+		MessageFormats: []string{"unknown"},
+	}))
+	require.Error(SetRelayerSource(relayerConfig, network, subnetID, blockchainID, messenger, registry, reward, RelayerSourceSettings{
+		AllowedOriginSenders: []string{"0x1"},
+	}))
+	require.NoError(SetRelayerDestination(relayerConfig, network, subnetID, blockchainID, RelayerDestinationKey{PrivateKey: "invalid"}))
+	require.Error(ValidateRelayerConfig(relayerConfig))
+}
+
+func TestRemoveRelayerSourceAndDestination(t *testing.T) {
+	require := require.New(t)
+	relayerConfig, network, subnetID, blockchainID := newTestRelayerConfig(t)
+	otherBlockchainID := ids.GenerateTestID().String()
+	messenger := "0x253b2784c75e510dD0fF1da844684a1aC0aa5fcf"
+	require.NoError(SetRelayerSource(relayerConfig, network, subnetID, blockchainID, messenger, "", "", RelayerSourceSettings{
+		MessageFormats: []string{config.TELEPORTER.String()},
+		SupportedDestinations: []*config.SupportedDestination{
+			{BlockchainID: otherBlockchainID},
+		},
+	}))
+	require.NoError(SetRelayerDestination(relayerConfig, network, subnetID, blockchainID, RelayerDestinationKey{PrivateKey: testRelayerPrivateKey}))
+	require.NoError(SetRelayerDestination(relayerConfig, network, subnetID, otherBlockchainID, RelayerDestinationKey{PrivateKey: testRelayerPrivateKey}))
+	// the source only relays to the other blockchain
+	require.Error(RemoveRelayerDestination(relayerConfig, otherBlockchainID))
+	require.Len(relayerConfig.DestinationBlockchains, 2)
+	require.NoError(RemoveRelayerDestination(relayerConfig, blockchainID))
+	require.Len(relayerConfig.DestinationBlockchains, 1)
+	require.Error(RemoveRelayerDestination(relayerConfig, blockchainID))
+	require.NoError(RemoveRelayerSource(relayerConfig, blockchainID))
+	require.Empty(relayerConfig.SourceBlockchains)
+	require.Error(RemoveRelayerSource(relayerConfig, blockchainID))
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// RelayerFundingPolicy sets when and how much a relayer key is topped up
+type RelayerFundingPolicy struct {
+	// top up when the balance is below this amount
+	Threshold *big.Int
+	// top up to this amount
+	Target *big.Int
+	// max amount to send on a single top up. No cap if nil
+	MaxTopUp *big.Int
+	// max amount to send over all the top ups. No cap if nil
+	MaxTotal *big.Int
+	// only compute the top ups, without funding
+	DryRun bool
+}
+
+func (p RelayerFundingPolicy) Validate() error {
+	if p.Threshold == nil || p.Target == nil {
+		return fmt.Errorf("relayer funding threshold and target must be set")
+	}
+	if p.Target.Cmp(p.Threshold) < 0 {
+		return fmt.Errorf("relayer funding target %s is lower than the threshold %s", p.Target, p.Threshold)
+	}
+	if p.MaxTopUp != nil && p.MaxTopUp.Sign() <= 0 {
+		return fmt.Errorf("relayer funding max top up must be positive")
+	}
+	if p.MaxTotal != nil && p.MaxTotal.Sign() <= 0 {
+		return fmt.Errorf("relayer funding max total must be positive")
+	}
+	return nil
+}
+
+// RelayerTopUp is the result of checking the relayer key balance on a destination blockchain
+type RelayerTopUp struct {
+	BlockchainID string
+	// empty if a KMS key is used, as its address is unknown
+	Address string
+	Balance *big.Int
+	// amount sent, or to be sent on dry runs. 0 if the balance is above the threshold
+	Amount *big.Int
+	// the amount was limited by the policy max top up or max total
+	Capped bool
+}
+
+// GetRelayerTopUpAmount returns the amount needed to take [balance] to the policy target,
+// if it is below the threshold, given that [sent] was already sent on previous top ups.
+// Returns true if the amount was capped
+func GetRelayerTopUpAmount(balance *big.Int, sent *big.Int, policy RelayerFundingPolicy) (*big.Int, bool) {
+	if balance.Cmp(policy.Threshold) >= 0 {
+		return big.NewInt(0), false
+	}
+	amount := new(big.Int).Sub(policy.Target, balance)
+	capped := false
+	if policy.MaxTopUp != nil && amount.Cmp(policy.MaxTopUp) > 0 {
+		amount, capped = new(big.Int).Set(policy.MaxTopUp), true
+	}
+	if policy.MaxTotal != nil {
+		left := new(big.Int).Sub(policy.MaxTotal, sent)
+		if left.Sign() < 0 {
+			left = big.NewInt(0)
+		}
+		if amount.Cmp(left) > 0 {
+			amount, capped = left, true
+		}
+	}
+	return amount, capped
+}
+
+// TopUpRelayerKeys checks the balance of the relayer key on every destination of
+// [relayerConfig], and funds it from [fundingPrivateKey] as given by [policy].
+// Blockchains are accessed at [network] endpoints
+func TopUpRelayerKeys(
+	network models.Network,
+	relayerConfig *config.Config,
+	fundingPrivateKey string,
+	policy RelayerFundingPolicy,
+) ([]RelayerTopUp, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	topUps := []RelayerTopUp{}
+	sent := big.NewInt(0)
+	for _, destination := range relayerConfig.DestinationBlockchains {
+		topUp := RelayerTopUp{
+			BlockchainID: destination.BlockchainID,
+			Amount:       big.NewInt(0),
+		}
+		if destination.AccountPrivateKey == "" {
+			topUps = append(topUps, topUp)
+			continue
+		}
+		privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(destination.AccountPrivateKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid relayer key for %s: %w", destination.BlockchainID, err)
+		}
+		topUp.Address = crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+		client, err := evm.GetClient(network.BlockchainEndpoint(destination.BlockchainID))
+		if err != nil {
+			return nil, err
+		}
+		topUp.Balance, err = evm.GetAddressBalance(client, topUp.Address)
+		if err == nil {
+			topUp.Amount, topUp.Capped = GetRelayerTopUpAmount(topUp.Balance, sent, policy)
+			if topUp.Amount.Sign() > 0 && !policy.DryRun {
+				err = evm.FundAddress(client, fundingPrivateKey, topUp.Address, topUp.Amount)
+			}
+			sent.Add(sent, topUp.Amount)
+		}
+		client.Close()
+		if err != nil {
+			return topUps, fmt.Errorf("failure funding relayer key %s on %s: %w", topUp.Address, destination.BlockchainID, err)
+		}
+		topUps = append(topUps, topUp)
+	}
+	return topUps, nil
+}
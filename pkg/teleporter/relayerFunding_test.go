@@ -0,0 +1,48 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRelayerTopUpAmount(t *testing.T) {
+	require := require.New(t)
+	policy := RelayerFundingPolicy{
+		Threshold: big.NewInt(100),
+		Target:    big.NewInt(500),
+	}
+	require.NoError(policy.Validate())
+	amount, capped := GetRelayerTopUpAmount(big.NewInt(100), big.NewInt(0), policy)
+	require.Zero(amount.Sign())
+	require.False(capped)
+	amount, capped = GetRelayerTopUpAmount(big.NewInt(99), big.NewInt(0), policy)
+	require.Equal(big.NewInt(401), amount)
+	require.False(capped)
+	policy.MaxTopUp = big.NewInt(300)
+	amount, capped = GetRelayerTopUpAmount(big.NewInt(0), big.NewInt(0), policy)
+	require.Equal(big.NewInt(300), amount)
+	require.True(capped)
+	policy.Threshold = big.NewInt(300)
+	amount, capped = GetRelayerTopUpAmount(big.NewInt(250), big.NewInt(0), policy)
+	require.Equal(big.NewInt(250), amount)
+	require.False(capped)
+	policy.MaxTotal = big.NewInt(1000)
+	amount, capped = GetRelayerTopUpAmount(big.NewInt(250), big.NewInt(900), policy)
+	require.Equal(big.NewInt(100), amount)
+	require.True(capped)
+	amount, capped = GetRelayerTopUpAmount(big.NewInt(250), big.NewInt(1000), policy)
+	require.Zero(amount.Sign())
+	require.True(capped)
+}
+
+func TestRelayerFundingPolicyValidate(t *testing.T) {
+	require := require.New(t)
+	require.Error(RelayerFundingPolicy{}.Validate())
+	require.Error(RelayerFundingPolicy{Threshold: big.NewInt(500), Target: big.NewInt(100)}.Validate())
+	require.Error(RelayerFundingPolicy{Threshold: big.NewInt(100), Target: big.NewInt(500), MaxTopUp: big.NewInt(0)}.Validate())
+	require.Error(RelayerFundingPolicy{Threshold: big.NewInt(100), Target: big.NewInt(500), MaxTotal: big.NewInt(0)}.Validate())
+}
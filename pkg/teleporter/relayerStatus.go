@@ -0,0 +1,334 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanche-cli/pkg/models"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/common/expfmt"
+)
+
+const (
+	// relayer defaults for ports not set on its config
+	relayerDefaultAPIPort     = 8080
+	relayerDefaultMetricsPort = 9090
+
+	relayerSuccessfulMessagesMetric = "successful_relay_message_count"
+	relayerFailedMessagesMetric     = "failed_relay_message_count"
+	relayerSourceLabel              = "source_chain_id"
+	relayerDestinationLabel         = "destination_chain_id"
+	// key of the relayer database where each route stores its last processed height
+	relayerLatestProcessedBlockKey = "latestProcessedBlock"
+	relayerHealthUp                = "up"
+)
+
+// RelayerRouteStatus is the state of the messages relayed from a source blockchain
+// to a destination blockchain
+type RelayerRouteStatus struct {
+	SourceBlockchainID      string
+	DestinationBlockchainID string
+	Successful              uint64
+	Failed                  uint64
+	// messages sent to the destination after the last processed height
+	Pending uint64
+	// last source height processed by the relayer, 0 if it did not yet process any
+	LastProcessedHeight uint64
+	SourceHeight        uint64
+}
+
+// RelayerDestinationStatus is the balance of the relayer key on a destination blockchain
+type RelayerDestinationStatus struct {
+	BlockchainID string
+	// empty if a KMS key is used
+	Address string
+	Balance *big.Int
+}
+
+type RelayerStatus struct {
+	Routes       []*RelayerRouteStatus
+	Destinations []RelayerDestinationStatus
+}
+
+// RelayerMessageCounters are the relayed messages counters of a route, as given by the relayer metrics
+type RelayerMessageCounters struct {
+	Successful uint64
+	Failed     uint64
+}
+
+// GetRelayerAPIPort returns the port the relayer serves its health endpoint at
+func GetRelayerAPIPort(relayerConfig *config.Config) uint16 {
+	if relayerConfig.APIPort == 0 {
+		return relayerDefaultAPIPort
+	}
+	return relayerConfig.APIPort
+}
+
+// GetRelayerMetricsPort returns the port the relayer serves its prometheus metrics at
+func GetRelayerMetricsPort(relayerConfig *config.Config) uint16 {
+	if relayerConfig.MetricsPort == 0 {
+		return relayerDefaultMetricsPort
+	}
+	return relayerConfig.MetricsPort
+}
+
+// ParseRelayerHealth parses the response of the relayer health endpoint. Returns
+// the relayer errors if it is not healthy
+func ParseRelayerHealth(response []byte) (bool, []string, error) {
+	health := struct {
+		Status  string `json:"status"`
+		Details map[string]struct {
+			Status string `json:"status"`
+			Error  string `json:"error"`
+		} `json:"details"`
+	}{}
+	if err := json.Unmarshal(response, &health); err != nil {
+		return false, nil, fmt.Errorf("invalid relayer health response: %w", err)
+	}
+	checkErrors := []string{}
+	for _, check := range health.Details {
+		if check.Error != "" {
+			checkErrors = append(checkErrors, check.Error)
+		}
+	}
+	return health.Status == relayerHealthUp, checkErrors, nil
+}
+
+// ParseRelayerMetrics parses the relayer prometheus metrics, returning the relayed
+// messages counters by source and destination blockchain IDs
+func ParseRelayerMetrics(metrics []byte) (map[string]map[string]*RelayerMessageCounters, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(metrics))
+	if err != nil {
+		return nil, fmt.Errorf("invalid relayer metrics: %w", err)
+	}
+	counters := map[string]map[string]*RelayerMessageCounters{}
+	// failures are also labeled by reason, so their counters are added up
+	for metricName, failed := range map[string]bool{
+		relayerSuccessfulMessagesMetric: false,
+		relayerFailedMessagesMetric:     true,
+	} {
+		family, ok := families[metricName]
+		if !ok {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			source, destination := labels[relayerSourceLabel], labels[relayerDestinationLabel]
+			if counters[source] == nil {
+				counters[source] = map[string]*RelayerMessageCounters{}
+			}
+			if counters[source][destination] == nil {
+				counters[source][destination] = &RelayerMessageCounters{}
+			}
+			value := uint64(metric.GetCounter().GetValue())
+			if failed {
+				counters[source][destination].Failed += value
+			} else {
+				counters[source][destination].Successful += value
+			}
+		}
+	}
+	return counters, nil
+}
+
+// GetRelayerRouteIDs returns the IDs the relayer uses to store the state of the route
+// from [source] to [destinationBlockchainID], one for each allowed sender and destination
+// address. Mirrors the relayer database implementation
+func GetRelayerRouteIDs(source *config.SourceBlockchain, destinationBlockchainID string) []common.Hash {
+	zeroAddress := common.Address{}
+	originSenders := source.AllowedOriginSenderAddresses
+	if len(originSenders) == 0 {
+		originSenders = []string{zeroAddress.Hex()}
+	}
+	routeIDs := []common.Hash{}
+	for _, supportedDestination := range source.SupportedDestinations {
+		if supportedDestination.BlockchainID != destinationBlockchainID {
+			continue
+		}
+		destinationAddresses := supportedDestination.Addresses
+		if len(destinationAddresses) == 0 {
+			destinationAddresses = []string{zeroAddress.Hex()}
+		}
+		for _, originSender := range originSenders {
+			for _, destinationAddress := range destinationAddresses {
+				routeIDs = append(routeIDs, crypto.Keccak256Hash([]byte(strings.Join(
+					[]string{
+						source.BlockchainID,
+						destinationBlockchainID,
+						common.HexToAddress(originSender).String(),
+						common.HexToAddress(destinationAddress).String(),
+					},
+					"-",
+				))))
+			}
+		}
+	}
+	return routeIDs
+}
+
+// ParseRelayerRouteState returns the last processed height stored on a relayer database
+// file, 0 if there is none
+func ParseRelayerRouteState(state []byte) (uint64, error) {
+	if len(state) == 0 {
+		return 0, nil
+	}
+	values := map[string]string{}
+	if err := json.Unmarshal(state, &values); err != nil {
+		return 0, fmt.Errorf("invalid relayer state: %w", err)
+	}
+	height, ok := values[relayerLatestProcessedBlockKey]
+	if !ok {
+		return 0, nil
+	}
+	return strconv.ParseUint(height, 10, 64)
+}
+
+// GetRelayerStatus gathers the state of every route and destination of [relayerConfig].
+// [metrics] are the relayer prometheus metrics, and [readRouteState] gets the content of
+// the relayer database file of a route ID, or nil if there is none. Blockchains are
+// queried at [network] endpoints
+func GetRelayerStatus(
+	network models.Network,
+	relayerConfig *config.Config,
+	metrics []byte,
+	readRouteState func(routeID common.Hash) ([]byte, error),
+) (*RelayerStatus, error) {
+	relayerConfig, err := getValidatedRelayerConfig(relayerConfig)
+	if err != nil {
+		return nil, err
+	}
+	counters := map[string]map[string]*RelayerMessageCounters{}
+	if metrics != nil {
+		counters, err = ParseRelayerMetrics(metrics)
+		if err != nil {
+			return nil, err
+		}
+	}
+	status := RelayerStatus{}
+	for _, source := range relayerConfig.SourceBlockchains {
+		rpcURL := network.BlockchainEndpoint(source.BlockchainID)
+		client, err := evm.GetClient(rpcURL)
+		if err != nil {
+			return nil, err
+		}
+		sourceHeight, err := evm.GetBlockNumber(client)
+		client.Close()
+		if err != nil {
+			return nil, err
+		}
+		routes := []*RelayerRouteStatus{}
+		minProcessedHeight := sourceHeight
+		for _, supportedDestination := range source.SupportedDestinations {
+			route := &RelayerRouteStatus{
+				SourceBlockchainID:      source.BlockchainID,
+				DestinationBlockchainID: supportedDestination.BlockchainID,
+				SourceHeight:            sourceHeight,
+			}
+			if routeCounters, ok := counters[source.BlockchainID][supportedDestination.BlockchainID]; ok {
+				route.Successful = routeCounters.Successful
+				route.Failed = routeCounters.Failed
+			}
+			// the route progresses as its slowest allowed sender and address pair
+			for i, routeID := range GetRelayerRouteIDs(source, supportedDestination.BlockchainID) {
+				state, err := readRouteState(routeID)
+				if err != nil {
+					return nil, err
+				}
+				height, err := ParseRelayerRouteState(state)
+				if err != nil {
+					return nil, err
+				}
+				if i == 0 || height < route.LastProcessedHeight {
+					route.LastProcessedHeight = height
+				}
+			}
+			if route.LastProcessedHeight != 0 && route.LastProcessedHeight < minProcessedHeight {
+				minProcessedHeight = route.LastProcessedHeight
+			}
+			routes = append(routes, route)
+		}
+		if err := setRelayerPendingMessages(rpcURL, source, routes, minProcessedHeight, sourceHeight); err != nil {
+			return nil, err
+		}
+		status.Routes = append(status.Routes, routes...)
+	}
+	for _, destination := range relayerConfig.DestinationBlockchains {
+		destinationStatus := RelayerDestinationStatus{
+			BlockchainID: destination.BlockchainID,
+		}
+		if destination.AccountPrivateKey != "" {
+			privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(destination.AccountPrivateKey, "0x"))
+			if err != nil {
+				return nil, err
+			}
+			destinationStatus.Address = crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+			client, err := evm.GetClient(network.BlockchainEndpoint(destination.BlockchainID))
+			if err != nil {
+				return nil, err
+			}
+			destinationStatus.Balance, err = evm.GetAddressBalance(client, destinationStatus.Address)
+			client.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+		status.Destinations = append(status.Destinations, destinationStatus)
+	}
+	return &status, nil
+}
+
+// counts the teleporter messages sent by [source] after each route last processed height.
+// Logs are only queried once, from the lowest last processed height [fromHeight]
+func setRelayerPendingMessages(
+	rpcURL string,
+	source *config.SourceBlockchain,
+	routes []*RelayerRouteStatus,
+	fromHeight uint64,
+	toHeight uint64,
+) error {
+	if fromHeight >= toHeight {
+		return nil
+	}
+	for messengerAddress, messageContract := range source.MessageContracts {
+		if messageContract.MessageFormat != config.TELEPORTER.String() {
+			continue
+		}
+		logs, err := getMessengerLogs(
+			rpcURL,
+			common.HexToAddress(messengerAddress),
+			fromHeight+1,
+			map[string][]int{sendCrossChainMessageEventEsp: {0, 1}},
+		)
+		if err != nil {
+			return err
+		}
+		for _, log := range logs {
+			event, err := ParseSendCrossChainMessage(log)
+			if err != nil {
+				return err
+			}
+			destinationBlockchainID := ids.ID(event.DestinationBlockchainID).String()
+			// routes that did not process any block yet have no meaningful pending count
+			for _, route := range routes {
+				if route.DestinationBlockchainID == destinationBlockchainID && log.BlockNumber > route.LastProcessedHeight && route.LastProcessedHeight != 0 {
+					route.Pending++
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"testing"
+
+	"github.com/ava-labs/awm-relayer/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRelayerMetrics(t *testing.T) {
+	require := require.New(t)
+	metrics := `# HELP successful_relay_message_count Number of messages that relayed successfully
+# TYPE successful_relay_message_count counter
+successful_relay_message_count{destination_chain_id="dst",source_chain_id="src",source_subnet_id="subnet"} 7
+# HELP failed_relay_message_count Number of messages that failed to relay
+# TYPE failed_relay_message_count counter
+failed_relay_message_count{destination_chain_id="dst",failure_reason="a",source_chain_id="src",source_subnet_id="subnet"} 2
+failed_relay_message_count{destination_chain_id="dst",failure_reason="b",source_chain_id="src",source_subnet_id="subnet"} 1
+failed_relay_message_count{destination_chain_id="other",failure_reason="a",source_chain_id="src",source_subnet_id="subnet"} 4
+`
+	counters, err := ParseRelayerMetrics([]byte(metrics))
+	require.NoError(err)
+	require.Equal(RelayerMessageCounters{Successful: 7, Failed: 3}, *counters["src"]["dst"])
+	require.Equal(RelayerMessageCounters{Failed: 4}, *counters["src"]["other"])
+	_, err = ParseRelayerMetrics([]byte("metric{"))
+	require.Error(err)
+}
+
+func TestParseRelayerHealth(t *testing.T) {
+	require := require.New(t)
+	healthy, checkErrors, err := ParseRelayerHealth([]byte(`{"status":"up","details":{"relayers-all":{"status":"up"}}}`))
+	require.NoError(err)
+	require.True(healthy)
+	require.Empty(checkErrors)
+	healthy, checkErrors, err = ParseRelayerHealth([]byte(`{"status":"down","details":{"relayers-all":{"status":"down","error":"relayers are unhealthy"}}}`))
+	require.NoError(err)
+	require.False(healthy)
+	require.Equal([]string{"relayers are unhealthy"}, checkErrors)
+	_, _, err = ParseRelayerHealth([]byte("down"))
+	require.Error(err)
+}
+
+func TestParseRelayerRouteState(t *testing.T) {
+	require := require.New(t)
+	height, err := ParseRelayerRouteState(nil)
+	require.NoError(err)
+	require.Zero(height)
+	height, err = ParseRelayerRouteState([]byte(`{"latestProcessedBlock":"42"}`))
+	require.NoError(err)
+	require.Equal(uint64(42), height)
+	_, err = ParseRelayerRouteState([]byte(`{"latestProcessedBlock":"x"}`))
+	require.Error(err)
+}
+
+func TestGetRelayerRouteIDs(t *testing.T) {
+	require := require.New(t)
+	source := &config.SourceBlockchain{
+		BlockchainID: "src",
+		SupportedDestinations: []*config.SupportedDestination{
+			{BlockchainID: "dst"},
+			{
+				BlockchainID: "other",
+				Addresses: []string{
+					"0x0000000000000000000000000000000000000001",
+					"0x0000000000000000000000000000000000000002",
+				},
+			},
+		},
+	}
+	require.Len(GetRelayerRouteIDs(source, "dst"), 1)
+	require.Len(GetRelayerRouteIDs(source, "other"), 2)
+	require.Empty(GetRelayerRouteIDs(source, "unknown"))
+	allSendersIDs := GetRelayerRouteIDs(source, "dst")
+	source.AllowedOriginSenderAddresses = []string{
+		"0x0000000000000000000000000000000000000001",
+		"0x0000000000000000000000000000000000000002",
+	}
+	sendersIDs := GetRelayerRouteIDs(source, "dst")
+	require.Len(sendersIDs, 2)
+	require.NotContains(sendersIDs, allSendersIDs[0])
+	require.Len(GetRelayerRouteIDs(source, "other"), 4)
+}
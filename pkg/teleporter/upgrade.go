@@ -0,0 +1,257 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanche-cli/pkg/evm"
+	"github.com/ava-labs/avalanchego/ids"
+	avalancheWarp "github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// chain config keys needed to have the validators sign an off-chain warp message
+	warpOffChainMessagesKey = "warp-off-chain-messages"
+	warpAPIEnabledKey       = "warp-api-enabled"
+	// the registry payload is (ProtocolRegistryEntry, destination registry address)
+	protocolRegistryPayloadEsp = "payload((uint256,address),address)"
+)
+
+// ProtocolRegistryEntry is a messenger version as registered on the TeleporterRegistry
+type ProtocolRegistryEntry struct {
+	Version         *big.Int
+	ProtocolAddress common.Address
+}
+
+// GetRegistryLatestVersion returns the latest messenger version registered on [registryAddress]
+func GetRegistryLatestVersion(
+	rpcURL string,
+	registryAddress common.Address,
+) (*big.Int, error) {
+	out, err := contract.CallToMethod(rpcURL, registryAddress, "latestVersion()->(uint256)")
+	if err != nil {
+		return nil, err
+	}
+	version, b := out[0].(*big.Int)
+	if !b {
+		return nil, fmt.Errorf("error at latestVersion call, expected *big.Int, got %T", out[0])
+	}
+	return version, nil
+}
+
+// GetRegistryVersion returns the version [messengerAddress] is registered with on
+// [registryAddress], or nil if it is not registered
+func GetRegistryVersion(
+	rpcURL string,
+	registryAddress common.Address,
+	messengerAddress common.Address,
+) (*big.Int, error) {
+	out, err := contract.CallToMethod(rpcURL, registryAddress, "getVersionFromAddress(address)->(uint256)", messengerAddress)
+	if evm.IsExecutionReverted(err) {
+		// the registry reverts for unknown addresses
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	version, b := out[0].(*big.Int)
+	if !b {
+		return nil, fmt.Errorf("error at getVersionFromAddress call, expected *big.Int, got %T", out[0])
+	}
+	return version, nil
+}
+
+// NewProtocolVersionMessage creates the off-chain warp message the validators of
+// [blockchainID] need to sign to register [messengerAddress] as [version] on
+// [registryAddress]
+func NewProtocolVersionMessage(
+	networkID uint32,
+	blockchainID ids.ID,
+	registryAddress common.Address,
+	version *big.Int,
+	messengerAddress common.Address,
+) (*avalancheWarp.UnsignedMessage, error) {
+	method, err := contract.GetMethodFromEsp(protocolRegistryPayloadEsp)
+	if err != nil {
+		return nil, err
+	}
+	// fields of unnamed tuples get positional names
+	type protocolRegistryEntry struct {
+		Field0 *big.Int
+		Field1 common.Address
+	}
+	registryPayload, err := method.Inputs.Pack(
+		protocolRegistryEntry{
+			Field0: version,
+			Field1: messengerAddress,
+		},
+		registryAddress,
+	)
+	if err != nil {
+		return nil, err
+	}
+	// the registry only accepts messages from the validators set, that is,
+	// with the zero address as source
+	addressedCall, err := payload.NewAddressedCall(common.Address{}.Bytes(), registryPayload)
+	if err != nil {
+		return nil, err
+	}
+	return avalancheWarp.NewUnsignedMessage(networkID, blockchainID, addressedCall.Bytes())
+}
+
+// AddOffChainMessageToChainConfig returns [chainConfig] modified to have the validators
+// sign [unsignedMessage] and serve its signatures. Returns false if no modification
+// was needed
+func AddOffChainMessageToChainConfig(
+	chainConfig []byte,
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+) ([]byte, bool, error) {
+	config := map[string]interface{}{}
+	if len(chainConfig) > 0 {
+		if err := json.Unmarshal(chainConfig, &config); err != nil {
+			return nil, false, fmt.Errorf("invalid chain config: %w", err)
+		}
+	}
+	changed := false
+	if enabled, _ := config[warpAPIEnabledKey].(bool); !enabled {
+		config[warpAPIEnabledKey] = true
+		changed = true
+	}
+	messageHex := hexutil.Encode(unsignedMessage.Bytes())
+	messages, _ := config[warpOffChainMessagesKey].([]interface{})
+	found := false
+	for _, message := range messages {
+		if s, _ := message.(string); s == messageHex {
+			found = true
+		}
+	}
+	if !found {
+		config[warpOffChainMessagesKey] = append(messages, messageHex)
+		changed = true
+	}
+	if !changed {
+		return chainConfig, false, nil
+	}
+	bs, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return nil, false, err
+	}
+	return bs, true, nil
+}
+
+// GetSignedOffChainMessage gathers the signatures of [unsignedMessage] from the validators
+// of [subnetID], through the node at [rpcURL]. The message must have been configured
+// on the validators chain config
+func GetSignedOffChainMessage(
+	rpcURL string,
+	subnetID ids.ID,
+	unsignedMessage *avalancheWarp.UnsignedMessage,
+) (*avalancheWarp.Message, error) {
+	client, err := evm.GetRPCClient(rpcURL)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	signedMessageBytes, err := evm.GetWarpMessageAggregateSignature(
+		client,
+		unsignedMessage.ID(),
+		evm.WarpQuorumPercentage,
+		subnetID.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return avalancheWarp.ParseMessage(signedMessageBytes)
+}
+
+// AddProtocolVersion registers a new messenger version on [registryAddress], as given
+// by the validators signed [signedMessage]
+func AddProtocolVersion(
+	rpcURL string,
+	privateKey string,
+	registryAddress common.Address,
+	signedMessage *avalancheWarp.Message,
+) error {
+	_, _, err := contract.TxToMethodWithWarpMessage(
+		rpcURL,
+		privateKey,
+		registryAddress,
+		signedMessage,
+		nil,
+		"addProtocolVersion(uint32)",
+		uint32(0),
+	)
+	return err
+}
+
+// GetMessengerDApps returns the contracts that sent messages through [messengerAddress],
+// or received messages from it, starting at [fromBlock]
+func GetMessengerDApps(
+	rpcURL string,
+	messengerAddress common.Address,
+	fromBlock uint64,
+) ([]common.Address, error) {
+	logs, err := getMessengerLogs(
+		rpcURL,
+		messengerAddress,
+		fromBlock,
+		map[string][]int{
+			sendCrossChainMessageEventEsp:    {0, 1},
+			receiveCrossChainMessageEventEsp: {0, 1, 2},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	receiveTopic, err := contract.GetEventTopic(receiveCrossChainMessageEventEsp, []int{0, 1, 2})
+	if err != nil {
+		return nil, err
+	}
+	dApps := []common.Address{}
+	seen := map[common.Address]bool{}
+	for _, log := range logs {
+		var dApp common.Address
+		if log.Topics[0] == receiveTopic {
+			event, err := ParseReceiveCrossChainMessage(log)
+			if err != nil {
+				return nil, err
+			}
+			dApp = event.Message.DestinationAddress
+		} else {
+			event, err := ParseSendCrossChainMessage(log)
+			if err != nil {
+				return nil, err
+			}
+			dApp = event.Message.OriginSenderAddress
+		}
+		if !seen[dApp] {
+			seen[dApp] = true
+			dApps = append(dApps, dApp)
+		}
+	}
+	return dApps, nil
+}
+
+// GetMinTeleporterVersion returns the min messenger version accepted by the registry
+// based [dAppAddress], or nil if the dApp is not registry based
+func GetMinTeleporterVersion(
+	rpcURL string,
+	dAppAddress common.Address,
+) (*big.Int, error) {
+	out, err := contract.CallToMethod(rpcURL, dAppAddress, "getMinTeleporterVersion()->(uint256)")
+	if err != nil {
+		return nil, nil
+	}
+	version, b := out[0].(*big.Int)
+	if !b {
+		return nil, fmt.Errorf("error at getMinTeleporterVersion call, expected *big.Int, got %T", out[0])
+	}
+	return version, nil
+}
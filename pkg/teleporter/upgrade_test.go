@@ -0,0 +1,106 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package teleporter
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProtocolVersionMessage(t *testing.T) {
+	require := require.New(t)
+	blockchainID := ids.GenerateTestID()
+	registryAddress := common.HexToAddress("0x01")
+	messengerAddress := common.HexToAddress("0x02")
+	message, err := NewProtocolVersionMessage(1337, blockchainID, registryAddress, big.NewInt(2), messengerAddress)
+	require.NoError(err)
+	require.Equal(uint32(1337), message.NetworkID)
+	require.Equal(blockchainID, message.SourceChainID)
+	addressedCall, err := payload.ParseAddressedCall(message.Payload)
+	require.NoError(err)
+	require.Equal(common.Address{}.Bytes(), addressedCall.SourceAddress)
+	method, err := contract.GetMethodFromEsp(protocolRegistryPayloadEsp)
+	require.NoError(err)
+	values, err := method.Inputs.Unpack(addressedCall.Payload)
+	require.NoError(err)
+	require.Len(values, 2)
+	require.Equal(registryAddress, values[1])
+	entry, err := json.Marshal(values[0])
+	require.NoError(err)
+	require.JSONEq(`{"field0":2,"field1":"0x0000000000000000000000000000000000000002"}`, string(entry))
+}
+
+func TestAddOffChainMessageToChainConfig(t *testing.T) {
+	require := require.New(t)
+	message, err := NewProtocolVersionMessage(1337, ids.GenerateTestID(), common.Address{}, big.NewInt(2), common.Address{})
+	require.NoError(err)
+	chainConfig, changed, err := AddOffChainMessageToChainConfig([]byte(`{"log-level":"info"}`), message)
+	require.NoError(err)
+	require.True(changed)
+	config := map[string]interface{}{}
+	require.NoError(json.Unmarshal(chainConfig, &config))
+	require.Equal("info", config["log-level"])
+	require.Equal(true, config[warpAPIEnabledKey])
+	require.Equal([]interface{}{hexutil.Encode(message.Bytes())}, config[warpOffChainMessagesKey])
+	// adding it again does not modify the config
+	sameChainConfig, changed, err := AddOffChainMessageToChainConfig(chainConfig, message)
+	require.NoError(err)
+	require.False(changed)
+	require.Equal(chainConfig, sameChainConfig)
+	_, _, err = AddOffChainMessageToChainConfig([]byte("{"), message)
+	require.Error(err)
+}
+
+// serves every JSON-RPC request with [response], given as the JSON of its result or
+// error fields
+func newTestRPCServer(t *testing.T, response string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(req.ID) + `,` + response + `}`))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetRegistryVersion(t *testing.T) {
+	require := require.New(t)
+	registry := common.HexToAddress("0x0100000000000000000000000000000000000001")
+	messenger := common.HexToAddress("0x0200000000000000000000000000000000000002")
+
+	server := newTestRPCServer(t, `"result":"0x0000000000000000000000000000000000000000000000000000000000000002"`)
+	version, err := GetRegistryVersion(server.URL, registry, messenger)
+	require.NoError(err)
+	require.Equal(big.NewInt(2), version)
+
+	// the registry reverts for unknown messengers
+	server = newTestRPCServer(t, `"error":{"code":3,"message":"execution reverted","data":"0x"}`)
+	version, err = GetRegistryVersion(server.URL, registry, messenger)
+	require.NoError(err)
+	require.Nil(version)
+	server = newTestRPCServer(t, `"error":{"code":-32000,"message":"execution reverted"}`)
+	version, err = GetRegistryVersion(server.URL, registry, messenger)
+	require.NoError(err)
+	require.Nil(version)
+
+	server = newTestRPCServer(t, `"error":{"code":-32000,"message":"header not found"}`)
+	_, err = GetRegistryVersion(server.URL, registry, messenger)
+	require.ErrorContains(err, "header not found")
+	server.Close()
+	_, err = GetRegistryVersion(server.URL, registry, messenger)
+	require.Error(err)
+}
@@ -4,6 +4,7 @@ package utils
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 )
@@ -60,3 +61,43 @@ func CleanupString(s string) string {
 func CleanupStrings(s []string) []string {
 	return Map(s, CleanupString)
 }
+
+// ParseUnits converts the non negative decimal [amount] (eg "1.5") into an integer amount
+// of the smallest unit, given that a whole unit has [decimals] decimals. It fails if
+// [amount] has more fractional digits than [decimals]
+func ParseUnits(amount string, decimals uint8) (*big.Int, error) {
+	amount = strings.TrimSpace(amount)
+	intPart, fracPart, _ := strings.Cut(amount, ".")
+	if intPart == "" && fracPart == "" {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	if len(fracPart) > int(decimals) {
+		return nil, fmt.Errorf("amount %q has more than %d decimals", amount, decimals)
+	}
+	digits := intPart + fracPart + strings.Repeat("0", int(decimals)-len(fracPart))
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("invalid amount %q", amount)
+		}
+	}
+	units, _ := new(big.Int).SetString(digits, 10)
+	return units, nil
+}
+
+// FormatUnits is the inverse of ParseUnits. It formats the integer [units] amount of the
+// smallest unit as a decimal amount of whole units, without trailing fractional zeros
+func FormatUnits(units *big.Int, decimals uint8) string {
+	sign := ""
+	if units.Sign() < 0 {
+		sign = "-"
+	}
+	digits := new(big.Int).Abs(units).String()
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+	intPart, fracPart := digits[:len(digits)-int(decimals)], strings.TrimRight(digits[len(digits)-int(decimals):], "0")
+	if fracPart == "" {
+		return sign + intPart
+	}
+	return sign + intPart + "." + fracPart
+}
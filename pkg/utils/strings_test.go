@@ -3,6 +3,7 @@
 package utils
 
 import (
+	"math/big"
 	"reflect"
 	"testing"
 )
@@ -26,3 +27,57 @@ func TestSpitStringWithQuotes(t *testing.T) {
 		t.Errorf("Expected %v, but got %v", expected1, result1)
 	}
 }
+
+func TestParseUnits(t *testing.T) {
+	for _, tc := range []struct {
+		amount   string
+		decimals uint8
+		expected string
+	}{
+		{"1.5", 18, "1500000000000000000"},
+		{"0.1", 18, "100000000000000000"},
+		{"1.0000000000000000001", 18, ""},
+		{"123456789.123456789123456789", 27, "123456789123456789123456789000000000"},
+		{"100", 0, "100"},
+		{".5", 1, "5"},
+		{"5.", 1, "50"},
+		{"", 18, ""},
+		{".", 18, ""},
+		{"-1", 18, ""},
+		{"1e18", 18, ""},
+		{"1.2.3", 18, ""},
+	} {
+		units, err := ParseUnits(tc.amount, tc.decimals)
+		if tc.expected == "" {
+			if err == nil {
+				t.Errorf("ParseUnits(%q, %d) expected an error, got %s", tc.amount, tc.decimals, units)
+			}
+			continue
+		}
+		expected, _ := new(big.Int).SetString(tc.expected, 10)
+		if err != nil || units.Cmp(expected) != 0 {
+			t.Errorf("ParseUnits(%q, %d) = %s, %v, expected %s", tc.amount, tc.decimals, units, err, expected)
+		}
+	}
+}
+
+func TestFormatUnits(t *testing.T) {
+	for _, tc := range []struct {
+		units    string
+		decimals uint8
+		expected string
+	}{
+		{"1500000000000000000", 18, "1.5"},
+		{"1", 18, "0.000000000000000001"},
+		{"0", 18, "0"},
+		{"100", 0, "100"},
+		{"-25", 1, "-2.5"},
+		{"123456789123456789123456789000000000", 27, "123456789.123456789123456789"},
+		{"100000000000000000000000000000", 18, "100000000000"},
+	} {
+		units, _ := new(big.Int).SetString(tc.units, 10)
+		if formatted := FormatUnits(units, tc.decimals); formatted != tc.expected {
+			t.Errorf("FormatUnits(%s, %d) = %q, expected %q", tc.units, tc.decimals, formatted, tc.expected)
+		}
+	}
+}
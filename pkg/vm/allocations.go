@@ -8,6 +8,7 @@ import (
 	"math/big"
 
 	"github.com/ava-labs/avalanche-cli/pkg/application"
+	"github.com/ava-labs/avalanche-cli/pkg/contract"
 	"github.com/ava-labs/avalanche-cli/pkg/models"
 	"github.com/ava-labs/avalanche-cli/pkg/utils"
 	"github.com/ava-labs/avalanche-cli/pkg/ux"
@@ -64,6 +65,39 @@ func addInterchainMessagingAllocation(
 	return allocations
 }
 
+// adds the deterministic deployment proxy code, so contracts can be deployed
+// with CREATE2 at the same addresses than on other blockchains
+func addCreate2FactoryAllocation(
+	allocations core.GenesisAlloc,
+) core.GenesisAlloc {
+	if allocations != nil {
+		address, code := contract.GetCreate2FactoryGenesisCode()
+		allocations[address] = core.GenesisAccount{
+			Balance: big.NewInt(0),
+			Code:    code,
+		}
+	}
+	return allocations
+}
+
+// adds Multicall3 at its canonical address, so calls can be batched
+// with the same tooling than on other blockchains
+func addMulticallAllocation(
+	allocations core.GenesisAlloc,
+) (core.GenesisAlloc, error) {
+	if allocations != nil {
+		address, code, err := contract.GetMulticallGenesisCode()
+		if err != nil {
+			return nil, err
+		}
+		allocations[address] = core.GenesisAccount{
+			Balance: big.NewInt(0),
+			Code:    code,
+		}
+	}
+	return allocations, nil
+}
+
 func getAllocation(
 	params SubnetEVMGenesisParams,
 	app *application.Avalanche,
@@ -128,6 +128,17 @@ func CreateEvmGenesis(
 		)
 	}
 
+	if params.UseCreate2Factory {
+		allocations = addCreate2FactoryAllocation(allocations)
+	}
+
+	if params.UseMulticall {
+		allocations, err = addMulticallAllocation(allocations)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if params.UseExternalGasToken {
 		params.enableNativeMinterPrecompile = true
 		params.nativeMinterPrecompileAllowList.AdminAddresses = append(
@@ -59,6 +59,8 @@ type SubnetEVMGenesisParams struct {
 	chainID                             uint64
 	UseTeleporter                       bool
 	UseExternalGasToken                 bool
+	UseCreate2Factory                   bool
+	UseMulticall                        bool
 	initialTokenAllocation              InitialTokenAllocation
 	feeConfig                           FeeConfig
 	enableNativeMinterPrecompile        bool
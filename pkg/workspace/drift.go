@@ -0,0 +1,189 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	ResourceNetwork    = "network"
+	ResourceRelayer    = "relayer"
+	ResourceBlockchain = "blockchain"
+	ResourceKey        = "key"
+	ResourceContract   = "contract"
+	ResourceRoute      = "route"
+)
+
+// State is the observed state of the resources of a manifest. Deployment related state
+// is only meaningful while the local network is running
+type State struct {
+	NetworkRunning bool
+	RelayerRunning bool
+	// blockchain name -> state
+	Blockchains map[string]BlockchainState
+	// key names that are stored
+	Keys map[string]bool
+	// key name -> chain -> balance in wei
+	Balances map[string]map[string]*big.Int
+	// contract name -> deployed
+	Contracts map[string]bool
+	// route name -> deployed
+	Routes map[string]bool
+}
+
+type BlockchainState struct {
+	Created bool
+	// differences between the manifest and the configuration the blockchain was created with
+	ConfigDrift []string
+	Deployed    bool
+}
+
+// BlockchainConfig is the configuration a blockchain was created with
+type BlockchainConfig struct {
+	ChainID     uint64
+	TokenSymbol string
+	VMVersion   string
+	Teleporter  bool
+	Genesis     []byte
+}
+
+// Drift is a difference between the manifest and the observed state
+type Drift struct {
+	Resource string
+	Name     string
+	Issue    string
+}
+
+func (d Drift) String() string {
+	if d.Name == "" {
+		return fmt.Sprintf("%s: %s", d.Resource, d.Issue)
+	}
+	return fmt.Sprintf("%s %s: %s", d.Resource, d.Name, d.Issue)
+}
+
+// GetDrift returns what differs between the manifest and [state], in the order avalanche
+// dev up reconciles it. If the network is not running, deployment drift is not reported,
+// as everything is going to be deployed again
+func (m *Manifest) GetDrift(state State) []Drift {
+	drift := []Drift{}
+	for _, blockchain := range m.Blockchains {
+		blockchainState := state.Blockchains[blockchain.Name]
+		switch {
+		case !blockchainState.Created:
+			drift = append(drift, Drift{ResourceBlockchain, blockchain.Name, "configuration not created"})
+		case len(blockchainState.ConfigDrift) > 0:
+			drift = append(drift, Drift{
+				ResourceBlockchain,
+				blockchain.Name,
+				"configuration differs from the manifest: " + strings.Join(blockchainState.ConfigDrift, ", "),
+			})
+		}
+	}
+	for _, key := range m.Keys {
+		if !state.Keys[key.Name] {
+			drift = append(drift, Drift{ResourceKey, key.Name, "not created"})
+		}
+	}
+	if !state.NetworkRunning {
+		return append(drift, Drift{ResourceNetwork, "", "local network not running"})
+	}
+	for _, blockchain := range m.Blockchains {
+		if !state.Blockchains[blockchain.Name].Deployed {
+			drift = append(drift, Drift{ResourceBlockchain, blockchain.Name, "not deployed"})
+		}
+	}
+	if m.UsesTeleporter() && !state.RelayerRunning {
+		drift = append(drift, Drift{ResourceRelayer, "", "not running"})
+	}
+	for _, key := range m.Keys {
+		chains := make([]string, 0, len(key.Balances))
+		for chain := range key.Balances {
+			chains = append(chains, chain)
+		}
+		sort.Strings(chains)
+		for _, chain := range chains {
+			expected := key.Balances[chain].Wei
+			balance := state.Balances[key.Name][chain]
+			if balance == nil {
+				balance = big.NewInt(0)
+			}
+			if balance.Cmp(expected) < 0 {
+				drift = append(drift, Drift{
+					ResourceKey,
+					key.Name,
+					fmt.Sprintf("balance on %s is %s, expected at least %s", chain, FormatWei(balance), FormatWei(expected)),
+				})
+			}
+		}
+	}
+	for _, contract := range m.Contracts {
+		if !state.Contracts[contract.Name] {
+			drift = append(drift, Drift{ResourceContract, contract.Name, "not deployed on " + contract.Blockchain})
+		}
+	}
+	for _, route := range m.Routes {
+		if !state.Routes[route.GetName()] {
+			drift = append(drift, Drift{ResourceRoute, route.GetName(), fmt.Sprintf("transferrer from %s to %s not deployed", route.Home, route.Remote)})
+		}
+	}
+	return drift
+}
+
+// GetConfigDrift returns what differs between [spec] and the configuration its blockchain
+// was created with. A blockchain created from a genesis file is expected to keep the
+// file content, and one created from a chain ID, to keep that chain ID
+func (m *Manifest) GetConfigDrift(spec BlockchainSpec, config BlockchainConfig) ([]string, error) {
+	drift := []string{}
+	if spec.Genesis != "" {
+		genesisPath := m.ResolvePath(spec.Genesis)
+		genesisBytes, err := os.ReadFile(genesisPath)
+		if err != nil {
+			return nil, err
+		}
+		equal, err := jsonEqual(genesisBytes, config.Genesis)
+		if err != nil {
+			return nil, fmt.Errorf("failure comparing genesis of blockchain %s with %s: %w", spec.Name, genesisPath, err)
+		}
+		if !equal {
+			drift = append(drift, "genesis differs from "+spec.Genesis)
+		}
+	} else if config.ChainID != spec.ChainID {
+		drift = append(drift, fmt.Sprintf("chain ID is %d, expected %d", config.ChainID, spec.ChainID))
+	}
+	if config.TokenSymbol != spec.TokenSymbol {
+		drift = append(drift, fmt.Sprintf("token symbol is %s, expected %s", config.TokenSymbol, spec.TokenSymbol))
+	}
+	if spec.VMVersion != "" && config.VMVersion != spec.VMVersion {
+		drift = append(drift, fmt.Sprintf("Subnet-EVM version is %s, expected %s", config.VMVersion, spec.VMVersion))
+	}
+	if config.Teleporter != spec.UseTeleporter() {
+		drift = append(drift, fmt.Sprintf("teleporter is %s, expected %s", enabledText(config.Teleporter), enabledText(spec.UseTeleporter())))
+	}
+	return drift, nil
+}
+
+func enabledText(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+// tells if [a] and [b] hold the same JSON value, regardless of formatting and key order
+func jsonEqual(a []byte, b []byte) (bool, error) {
+	var aValue, bValue interface{}
+	if err := json.Unmarshal(a, &aValue); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b, &bValue); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(aValue, bValue), nil
+}
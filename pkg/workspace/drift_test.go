@@ -0,0 +1,100 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package workspace
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDrift(t *testing.T) {
+	require := require.New(t)
+	m, err := LoadManifest(writeManifest(t, testManifest))
+	require.NoError(err)
+
+	drift := m.GetDrift(State{})
+	require.Equal([]Drift{
+		{ResourceBlockchain, "alpha", "configuration not created"},
+		{ResourceBlockchain, "beta", "configuration not created"},
+		{ResourceKey, "alice", "not created"},
+		{ResourceNetwork, "", "local network not running"},
+	}, drift)
+	require.Equal("network: local network not running", drift[3].String())
+	require.Equal("key alice: not created", drift[2].String())
+
+	state := State{
+		NetworkRunning: true,
+		Blockchains: map[string]BlockchainState{
+			"alpha": {Created: true, Deployed: true},
+			"beta":  {Created: true},
+		},
+		Keys: map[string]bool{"alice": true},
+		Balances: map[string]map[string]*big.Int{
+			"alice": {"alpha": toWei(t, "100")},
+		},
+		Contracts: map[string]bool{},
+		Routes:    map[string]bool{"usdc": true},
+	}
+	require.Equal([]Drift{
+		{ResourceBlockchain, "beta", "not deployed"},
+		{ResourceRelayer, "", "not running"},
+		{ResourceKey, "alice", "balance on c-chain is 0, expected at least 0.5"},
+		{ResourceContract, "counter", "not deployed on alpha"},
+		{ResourceRoute, "alpha-beta", "transferrer from alpha to beta not deployed"},
+	}, m.GetDrift(state))
+
+	state.RelayerRunning = true
+	state.Blockchains["beta"] = BlockchainState{Created: true, Deployed: true}
+	state.Balances["alice"][CChain] = toWei(t, "2")
+	state.Contracts["counter"] = true
+	state.Routes["alpha-beta"] = true
+	require.Empty(m.GetDrift(state))
+
+	state.Blockchains["alpha"] = BlockchainState{Created: true, Deployed: true, ConfigDrift: []string{"chain ID is 1002, expected 1001"}}
+	require.Equal([]Drift{
+		{ResourceBlockchain, "alpha", "configuration differs from the manifest: chain ID is 1002, expected 1001"},
+	}, m.GetDrift(state))
+}
+
+func TestGetConfigDrift(t *testing.T) {
+	require := require.New(t)
+	path := writeManifest(t, testManifest)
+	m, err := LoadManifest(path)
+	require.NoError(err)
+	alpha, beta := m.Blockchains[0], m.Blockchains[1]
+
+	drift, err := m.GetConfigDrift(alpha, BlockchainConfig{ChainID: 1001, TokenSymbol: "ALP", Teleporter: true})
+	require.NoError(err)
+	require.Empty(drift)
+	drift, err = m.GetConfigDrift(alpha, BlockchainConfig{ChainID: 1002, TokenSymbol: "TST"})
+	require.NoError(err)
+	require.Equal([]string{
+		"chain ID is 1002, expected 1001",
+		"token symbol is TST, expected ALP",
+		"teleporter is disabled, expected enabled",
+	}, drift)
+
+	_, err = m.GetConfigDrift(beta, BlockchainConfig{TokenSymbol: "BET", Teleporter: true})
+	require.ErrorIs(err, os.ErrNotExist)
+	genesisPath := m.ResolvePath(beta.Genesis)
+	require.NoError(os.MkdirAll(filepath.Dir(genesisPath), 0o700))
+	require.NoError(os.WriteFile(genesisPath, []byte(`{"config": {"chainId": 2002}, "gasLimit": "0x7A1200"}`), 0o600))
+	drift, err = m.GetConfigDrift(beta, BlockchainConfig{
+		TokenSymbol: "BET",
+		Teleporter:  true,
+		Genesis:     []byte(`{"gasLimit":"0x7A1200","config":{"chainId":2002}}`),
+	})
+	require.NoError(err)
+	require.Empty(drift)
+	drift, err = m.GetConfigDrift(beta, BlockchainConfig{
+		TokenSymbol: "BET",
+		Teleporter:  true,
+		Genesis:     []byte(`{"gasLimit":"0x7A1200","config":{"chainId":2003}}`),
+	})
+	require.NoError(err)
+	require.Equal([]string{"genesis differs from ./genesis/beta.json"}, drift)
+}
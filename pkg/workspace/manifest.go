@@ -0,0 +1,304 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package workspace
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ethereum/go-ethereum/common"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	ManifestFileName = "avalanche.yaml"
+
+	// chain name to refer to the C-Chain from the manifest
+	CChain = "c-chain"
+	// token of a route that transfers the home native token
+	NativeToken = "native"
+
+	nativeTokenDecimals = 18
+)
+
+var whitespaceRegex = regexp.MustCompile(`\s`)
+
+// Manifest describes a local development environment: the blockchains to be deployed
+// into the local network, the keys to be funded on them, the contracts to be deployed,
+// and the interchain token routes connecting them. Eg:
+//
+//	blockchains:
+//	  - name: alpha
+//	    chainId: 1001
+//	    tokenSymbol: ALP
+//	  - name: beta
+//	    genesis: ./genesis/beta.json
+//	    tokenSymbol: BET
+//	keys:
+//	  - name: alice
+//	    balances:
+//	      alpha: 100
+//	      c-chain: 10
+//	contracts:
+//	  - name: counter
+//	    blockchain: alpha
+//	    bytecode: ./out/Counter.sol/Counter.json
+//	    constructor: "(uint256)"
+//	    args: ["1"]
+//	routes:
+//	  - home: alpha
+//	    remote: beta
+//	    token: native
+type Manifest struct {
+	Blockchains []BlockchainSpec `yaml:"blockchains"`
+	Keys        []KeySpec        `yaml:"keys"`
+	Contracts   []ContractSpec   `yaml:"contracts"`
+	Routes      []RouteSpec      `yaml:"routes"`
+	// dir the manifest was loaded from. relative paths are resolved against it
+	dir string
+}
+
+// BlockchainSpec is a Subnet-EVM blockchain of the manifest. It is either created with
+// the test defaults and the given chain ID, or from the given genesis file
+type BlockchainSpec struct {
+	Name        string `yaml:"name"`
+	Genesis     string `yaml:"genesis"`
+	ChainID     uint64 `yaml:"chainId"`
+	TokenSymbol string `yaml:"tokenSymbol"`
+	// Subnet-EVM version. defaults to the latest release
+	VMVersion string `yaml:"vmVersion"`
+	// defaults to true
+	Teleporter *bool `yaml:"teleporter"`
+}
+
+func (b BlockchainSpec) UseTeleporter() bool {
+	return b.Teleporter == nil || *b.Teleporter
+}
+
+// KeySpec is a CLI stored key, created if missing, to be funded with at least the
+// given balances on the given chains
+type KeySpec struct {
+	Name     string            `yaml:"name"`
+	Balances map[string]Amount `yaml:"balances"`
+}
+
+// Amount is an amount of native token. It is given in token units on the manifest,
+// and kept in wei, parsed without loss of precision
+type Amount struct {
+	Wei *big.Int
+}
+
+func (a *Amount) UnmarshalYAML(value *yaml.Node) error {
+	wei, err := utils.ParseUnits(value.Value, nativeTokenDecimals)
+	if err != nil {
+		return err
+	}
+	a.Wei = wei
+	return nil
+}
+
+// ContractSpec is a contract deployed through the CREATE2 factory, so its address
+// only depends on its bytecode, constructor args and salt
+type ContractSpec struct {
+	Name       string `yaml:"name"`
+	Blockchain string `yaml:"blockchain"`
+	// hex encoded bytecode file, or foundry artifact
+	Bytecode string `yaml:"bytecode"`
+	// constructor params types, using the CLI ESP syntax (eg "(address, uint256)")
+	Constructor string   `yaml:"constructor"`
+	Args        []string `yaml:"args"`
+	// defaults to the contract name
+	Salt string `yaml:"salt"`
+}
+
+func (c ContractSpec) GetSalt() string {
+	if c.Salt != "" {
+		return c.Salt
+	}
+	return c.Name
+}
+
+// RouteSpec is a Token Transferrer that moves [Token] from [Home] to [Remote]
+type RouteSpec struct {
+	// name to register the Transferrer with. defaults to <home>-<remote>
+	Name   string `yaml:"name"`
+	Home   string `yaml:"home"`
+	Remote string `yaml:"remote"`
+	// native, or the address of an ERC20 token on home
+	Token string `yaml:"token"`
+	// make the token the native token of remote, instead of an ERC20
+	RemoteNative bool `yaml:"remoteNative"`
+}
+
+func (r RouteSpec) GetName() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Home + "-" + r.Remote
+}
+
+func (r RouteSpec) IsNative() bool {
+	return r.Token == "" || strings.EqualFold(r.Token, NativeToken)
+}
+
+// IsCChain tells if [chain], as given in the manifest, refers to the C-Chain
+func IsCChain(chain string) bool {
+	return strings.EqualFold(chain, CChain) || chain == "C"
+}
+
+// LoadManifest reads and validates the manifest at [path]
+func LoadManifest(path string) (*Manifest, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err := yaml.Unmarshal(bs, &m); err != nil {
+		return nil, fmt.Errorf("failure parsing manifest %s: %w", path, err)
+	}
+	m.dir = filepath.Dir(path)
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// ResolvePath returns [path] relative to the manifest dir, if not absolute
+func (m *Manifest) ResolvePath(path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(m.dir, path)
+}
+
+func (m *Manifest) GetBlockchain(name string) (BlockchainSpec, bool) {
+	for _, blockchain := range m.Blockchains {
+		if blockchain.Name == name {
+			return blockchain, true
+		}
+	}
+	return BlockchainSpec{}, false
+}
+
+// UsesTeleporter tells if any blockchain needs Teleporter, and so the relayer
+func (m *Manifest) UsesTeleporter() bool {
+	for _, blockchain := range m.Blockchains {
+		if blockchain.UseTeleporter() {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Manifest) validateChain(chain string) error {
+	if IsCChain(chain) {
+		return nil
+	}
+	if _, ok := m.GetBlockchain(chain); !ok {
+		return fmt.Errorf("unknown blockchain %q. use a blockchain of the manifest or %s", chain, CChain)
+	}
+	return nil
+}
+
+func (m *Manifest) Validate() error {
+	names := map[string]bool{}
+	for i, blockchain := range m.Blockchains {
+		if blockchain.Name == "" {
+			return fmt.Errorf("blockchain %d has no name", i)
+		}
+		if IsCChain(blockchain.Name) {
+			return fmt.Errorf("blockchain name %q is reserved", blockchain.Name)
+		}
+		if names[blockchain.Name] {
+			return fmt.Errorf("duplicated blockchain %q", blockchain.Name)
+		}
+		names[blockchain.Name] = true
+		if blockchain.TokenSymbol == "" {
+			return fmt.Errorf("blockchain %q has no tokenSymbol", blockchain.Name)
+		}
+		if blockchain.Genesis != "" && blockchain.ChainID != 0 {
+			return fmt.Errorf("blockchain %q: genesis and chainId are mutually exclusive", blockchain.Name)
+		}
+		if blockchain.Genesis == "" && blockchain.ChainID == 0 {
+			return fmt.Errorf("blockchain %q needs either a genesis or a chainId", blockchain.Name)
+		}
+	}
+	names = map[string]bool{}
+	for i, key := range m.Keys {
+		if key.Name == "" {
+			return fmt.Errorf("key %d has no name", i)
+		}
+		if whitespaceRegex.MatchString(key.Name) {
+			return fmt.Errorf("key name %q contains whitespace", key.Name)
+		}
+		if names[key.Name] {
+			return fmt.Errorf("duplicated key %q", key.Name)
+		}
+		names[key.Name] = true
+		for chain, amount := range key.Balances {
+			if err := m.validateChain(chain); err != nil {
+				return fmt.Errorf("key %q: %w", key.Name, err)
+			}
+			if amount.Wei == nil || amount.Wei.Sign() <= 0 {
+				return fmt.Errorf("key %q: balance on %s should be positive", key.Name, chain)
+			}
+		}
+	}
+	names = map[string]bool{}
+	for i, contract := range m.Contracts {
+		if contract.Name == "" {
+			return fmt.Errorf("contract %d has no name", i)
+		}
+		if names[contract.Name] {
+			return fmt.Errorf("duplicated contract %q", contract.Name)
+		}
+		names[contract.Name] = true
+		if err := m.validateChain(contract.Blockchain); err != nil {
+			return fmt.Errorf("contract %q: %w", contract.Name, err)
+		}
+		if contract.Bytecode == "" {
+			return fmt.Errorf("contract %q has no bytecode", contract.Name)
+		}
+	}
+	names = map[string]bool{}
+	for _, route := range m.Routes {
+		name := route.GetName()
+		if names[name] {
+			return fmt.Errorf("duplicated route %q", name)
+		}
+		names[name] = true
+		if err := m.validateChain(route.Home); err != nil {
+			return fmt.Errorf("route %q home: %w", name, err)
+		}
+		if err := m.validateChain(route.Remote); err != nil {
+			return fmt.Errorf("route %q remote: %w", name, err)
+		}
+		if route.Home == route.Remote || (IsCChain(route.Home) && IsCChain(route.Remote)) {
+			return fmt.Errorf("route %q has the same home and remote", name)
+		}
+		if !route.IsNative() && !common.IsHexAddress(route.Token) {
+			return fmt.Errorf("route %q: token should be %s or an ERC20 address, found %q", name, NativeToken, route.Token)
+		}
+		for _, chain := range []string{route.Home, route.Remote} {
+			if blockchain, ok := m.GetBlockchain(chain); ok && !blockchain.UseTeleporter() {
+				return fmt.Errorf("route %q: blockchain %q has teleporter disabled", name, chain)
+			}
+		}
+	}
+	return nil
+}
+
+// FormatWei formats [amount], in wei, in native token units
+func FormatWei(amount *big.Int) string {
+	amountFlt := new(big.Float).SetInt(amount)
+	amountFlt = amountFlt.Quo(amountFlt, new(big.Float).SetFloat64(float64(units.Avax)))
+	amountFlt = amountFlt.Quo(amountFlt, new(big.Float).SetFloat64(float64(units.Avax)))
+	return amountFlt.Text('f', -1)
+}
@@ -0,0 +1,184 @@
+// Copyright (C) 2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+package workspace
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanche-cli/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+const testManifest = `blockchains:
+  - name: alpha
+    chainId: 1001
+    tokenSymbol: ALP
+  - name: beta
+    genesis: ./genesis/beta.json
+    tokenSymbol: BET
+keys:
+  - name: alice
+    balances:
+      alpha: 100
+      c-chain: 0.5
+contracts:
+  - name: counter
+    blockchain: alpha
+    bytecode: ./out/Counter.sol/Counter.json
+    constructor: "(uint256)"
+    args: ["1"]
+routes:
+  - home: alpha
+    remote: beta
+    token: native
+  - name: usdc
+    home: c-chain
+    remote: alpha
+    token: "0x5425890298aed601595a70AB815c96711a31Bc65"
+    remoteNative: true
+`
+
+func writeManifest(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), ManifestFileName)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadManifest(t *testing.T) {
+	require := require.New(t)
+	path := writeManifest(t, testManifest)
+	m, err := LoadManifest(path)
+	require.NoError(err)
+	require.Len(m.Blockchains, 2)
+	require.Equal(uint64(1001), m.Blockchains[0].ChainID)
+	require.True(m.Blockchains[0].UseTeleporter())
+	require.Equal(filepath.Join(filepath.Dir(path), "genesis", "beta.json"), m.ResolvePath(m.Blockchains[1].Genesis))
+	require.Equal("/abs/genesis.json", m.ResolvePath("/abs/genesis.json"))
+	require.Equal(toWei(t, "0.5"), m.Keys[0].Balances[CChain].Wei)
+	require.Equal(toWei(t, "100"), m.Keys[0].Balances["alpha"].Wei)
+	require.Equal([]string{"1"}, m.Contracts[0].Args)
+	require.Equal("counter", m.Contracts[0].GetSalt())
+	require.Equal("alpha-beta", m.Routes[0].GetName())
+	require.True(m.Routes[0].IsNative())
+	require.Equal("usdc", m.Routes[1].GetName())
+	require.False(m.Routes[1].IsNative())
+	require.True(m.Routes[1].RemoteNative)
+	require.True(m.UsesTeleporter())
+
+	_, err = LoadManifest(filepath.Join(t.TempDir(), ManifestFileName))
+	require.ErrorIs(err, os.ErrNotExist)
+	_, err = LoadManifest(writeManifest(t, "blockchains: [}"))
+	require.ErrorContains(err, "failure parsing manifest")
+	_, err = LoadManifest(writeManifest(t, "keys:\n  - name: alice\n    balances:\n      c-chain: 1e3\n"))
+	require.ErrorContains(err, `invalid amount "1e3"`)
+}
+
+func TestValidateManifest(t *testing.T) {
+	teleporterOff := false
+	for _, tc := range []struct {
+		name     string
+		manifest Manifest
+		err      string
+	}{
+		{
+			name:     "empty",
+			manifest: Manifest{},
+		},
+		{
+			name:     "no token symbol",
+			manifest: Manifest{Blockchains: []BlockchainSpec{{Name: "alpha", ChainID: 1}}},
+			err:      "no tokenSymbol",
+		},
+		{
+			name:     "genesis and chain id",
+			manifest: Manifest{Blockchains: []BlockchainSpec{{Name: "alpha", ChainID: 1, Genesis: "g.json", TokenSymbol: "A"}}},
+			err:      "mutually exclusive",
+		},
+		{
+			name:     "no genesis nor chain id",
+			manifest: Manifest{Blockchains: []BlockchainSpec{{Name: "alpha", TokenSymbol: "A"}}},
+			err:      "needs either a genesis or a chainId",
+		},
+		{
+			name: "duplicated blockchain",
+			manifest: Manifest{Blockchains: []BlockchainSpec{
+				{Name: "alpha", ChainID: 1, TokenSymbol: "A"},
+				{Name: "alpha", ChainID: 2, TokenSymbol: "A"},
+			}},
+			err: "duplicated blockchain",
+		},
+		{
+			name:     "reserved blockchain name",
+			manifest: Manifest{Blockchains: []BlockchainSpec{{Name: "c-chain", ChainID: 1, TokenSymbol: "A"}}},
+			err:      "reserved",
+		},
+		{
+			name:     "key on unknown chain",
+			manifest: Manifest{Keys: []KeySpec{{Name: "alice", Balances: map[string]Amount{"gamma": {big.NewInt(1)}}}}},
+			err:      `unknown blockchain "gamma"`,
+		},
+		{
+			name:     "key with whitespace",
+			manifest: Manifest{Keys: []KeySpec{{Name: "al ice"}}},
+			err:      "whitespace",
+		},
+		{
+			name:     "non positive balance",
+			manifest: Manifest{Keys: []KeySpec{{Name: "alice", Balances: map[string]Amount{"C": {big.NewInt(0)}}}}},
+			err:      "should be positive",
+		},
+		{
+			name:     "contract without bytecode",
+			manifest: Manifest{Contracts: []ContractSpec{{Name: "counter", Blockchain: CChain}}},
+			err:      "no bytecode",
+		},
+		{
+			name:     "route on same chain",
+			manifest: Manifest{Routes: []RouteSpec{{Home: "c-chain", Remote: "C"}}},
+			err:      "same home and remote",
+		},
+		{
+			name: "route with invalid token",
+			manifest: Manifest{
+				Blockchains: []BlockchainSpec{{Name: "alpha", ChainID: 1, TokenSymbol: "A"}},
+				Routes:      []RouteSpec{{Home: "c-chain", Remote: "alpha", Token: "usdc"}},
+			},
+			err: "should be native or an ERC20 address",
+		},
+		{
+			name: "route without teleporter",
+			manifest: Manifest{
+				Blockchains: []BlockchainSpec{{Name: "alpha", ChainID: 1, TokenSymbol: "A", Teleporter: &teleporterOff}},
+				Routes:      []RouteSpec{{Home: "c-chain", Remote: "alpha"}},
+			},
+			err: "teleporter disabled",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.manifest.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func toWei(t *testing.T, amount string) *big.Int {
+	wei, err := utils.ParseUnits(amount, nativeTokenDecimals)
+	require.NoError(t, err)
+	return wei
+}
+
+func TestWeiConversions(t *testing.T) {
+	require := require.New(t)
+	m, err := LoadManifest(writeManifest(t, "keys:\n  - name: alice\n    balances:\n      c-chain: 0.100000000000000001\n"))
+	require.NoError(err)
+	require.Equal(big.NewInt(100_000_000_000_000_001), m.Keys[0].Balances[CChain].Wei)
+	require.Equal("1.5", FormatWei(big.NewInt(1_500_000_000_000_000_000)))
+	require.Equal("0", FormatWei(big.NewInt(0)))
+}
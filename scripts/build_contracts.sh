@@ -3,11 +3,36 @@ set -e
 # go to contracts dir
 base_dir=$(dirname $0)/..
 cd $base_dir/pkg/contract/contracts/
+# solc version the embedded bytecode is built with. keep it pinned, so the bytecode
+# can be reproduced from the sources
+solc_version=0.8.18
 # prepare build env
-touch foundry.toml
+cat > foundry.toml <<EOF
+[profile.default]
+solc_version = "$solc_version"
+optimizer = true
+optimizer_runs = 200
+EOF
 echo @openzeppelin/contracts@4.8.1/=lib/openzeppelin-contracts/contracts/ > remappings.txt
 [ ! -d lib/openzeppelin-contracts ] && git clone https://github.com/OpenZeppelin/openzeppelin-contracts lib/openzeppelin-contracts -b v4.8.1
 # build 
 forge build --extra-output-files bin
 mkdir -p bin
 cp out/Token.sol/Token.bin bin
+cp out/ConfigurableToken.sol/ConfigurableToken.bin bin
+cp out/NFT.sol/NFT.bin bin
+cp out/MultiToken.sol/MultiToken.bin bin
+cp out/ERC1967Proxy.sol/ERC1967Proxy.bin bin
+cp out/TransparentUpgradeableProxy.sol/TransparentUpgradeableProxy.bin bin
+cp out/ProxyAdmin.sol/ProxyAdmin.bin bin
+cp out/Multicall3.sol/Multicall3.bin bin
+forge inspect Multicall3 deployedBytecode > bin/Multicall3Runtime.bin
+# Safe v1.4.1 contracts are taken as published in its npm release, so they match the
+# bytecode of the canonical Safe deployments
+safe_dir=$(mktemp -d)
+trap "rm -rf $safe_dir" EXIT
+npm pack @safe-global/safe-contracts@1.4.1 --pack-destination $safe_dir
+tar -xzf $safe_dir/*.tgz -C $safe_dir
+for artifact in SafeL2.sol/SafeL2 proxies/SafeProxyFactory.sol/SafeProxyFactory handler/CompatibilityFallbackHandler.sol/CompatibilityFallbackHandler; do
+  jq -r .bytecode $safe_dir/package/build/artifacts/contracts/$artifact.json | sed 's/^0x//' > bin/$(basename $artifact).bin
+done
@@ -0,0 +1,25 @@
+#!/usr/bin/env bash
+set -e
+# builds the ICTT contracts of the given release, and stores their ABI and bytecode
+# to be embedded into CLI
+if [ $# -ne 1 ]; then
+  echo "usage: $0 <ictt version>"
+  exit 1
+fi
+version=$1
+base_dir=$(cd $(dirname $0)/.. && pwd)
+out_dir=$base_dir/pkg/ictt/contracts/$version
+src_dir=$(mktemp -d)
+trap "rm -rf $src_dir" EXIT
+# build
+git clone https://github.com/ava-labs/avalanche-interchain-token-transfer $src_dir -b $version --recurse-submodules --shallow-submodules
+cd $src_dir/contracts
+forge build --extra-output-files bin
+# store artifacts
+mkdir -p $out_dir
+for contract in ERC20TokenHome ERC20TokenRemote NativeTokenHome NativeTokenRemote WrappedNativeToken; do
+  cp out/$contract.sol/$contract.bin $out_dir
+  forge inspect $contract abi > $out_dir/$contract.abi.json
+done
+cd $out_dir
+sha256sum *.bin *.abi.json > SHA256SUMS